@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// Backoff doubles an interval for every consecutive failure, up to a maximum, and resets once an operation succeeds.
+type Backoff struct {
+	mu       sync.Mutex
+	base     time.Duration
+	max      time.Duration
+	failures int
+}
+
+func NewBackoff(base time.Duration, max time.Duration) *Backoff {
+	return &Backoff{base: base, max: max}
+}
+
+func (b *Backoff) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+}
+
+func (b *Backoff) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+func (b *Backoff) Interval() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	interval := b.base
+	for i := 0; i < b.failures && interval < b.max; i++ {
+		interval *= 2
+	}
+	if interval > b.max {
+		return b.max
+	}
+	return interval
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBackoffGrowsOnRepeatedFailuresAndResetsOnSuccess(t *testing.T) {
+	// Given
+	backoff := NewBackoff(time.Minute, 10*time.Minute)
+
+	// When
+	var intervals []time.Duration
+	for i := 0; i < 5; i++ {
+		backoff.Failure()
+		intervals = append(intervals, backoff.Interval())
+	}
+	backoff.Success()
+
+	// Then
+	assert.Equal(t, []time.Duration{2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 10 * time.Minute, 10 * time.Minute}, intervals)
+	assert.Equal(t, time.Minute, backoff.Interval())
+}
@@ -8,15 +8,24 @@ import (
 	"flag"
 	"fmt"
 	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	listerAppsv1 "k8s.io/client-go/listers/apps/v1"
+	listerAutoscalingv2 "k8s.io/client-go/listers/autoscaling/v2"
+	listerBatchv1 "k8s.io/client-go/listers/batch/v1"
 	listerCorev1 "k8s.io/client-go/listers/core/v1"
+	listerDiscoveryv1 "k8s.io/client-go/listers/discovery/v1"
+	listerNetworkingv1 "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
@@ -29,23 +38,61 @@ import (
 
 var K8S *Client
 
+const (
+	defaultDiscoveryInterval = time.Minute
+	// maxDiscoveryIntervalFactor limits how far the discovery interval is stretched while the API server reports errors.
+	maxDiscoveryIntervalFactor = 10
+)
+
 type Client struct {
-	Distribution         string
-	daemonSetsLister     listerAppsv1.DaemonSetLister
-	daemonSetsInformer   cache.SharedIndexInformer
-	deploymentsLister    listerAppsv1.DeploymentLister
-	deploymentsInformer  cache.SharedIndexInformer
-	podsLister           listerCorev1.PodLister
-	podsInformer         cache.SharedIndexInformer
-	replicaSetsLister    listerAppsv1.ReplicaSetLister
-	replicaSetsInformer  cache.SharedIndexInformer
-	servicesLister       listerCorev1.ServiceLister
-	servicesInformer     cache.SharedIndexInformer
-	statefulSetsLister   listerAppsv1.StatefulSetLister
-	statefulSetsInformer cache.SharedIndexInformer
-	eventsInformer       cache.SharedIndexInformer
-	nodesLister          listerCorev1.NodeLister
-	nodesInformer        cache.SharedIndexInformer
+	Distribution           string
+	clientset              kubernetes.Interface
+	daemonSetsLister       listerAppsv1.DaemonSetLister
+	daemonSetsIndexer      cache.Indexer
+	deploymentsLister      listerAppsv1.DeploymentLister
+	deploymentsIndexer     cache.Indexer
+	podsLister             listerCorev1.PodLister
+	podsIndexer            cache.Indexer
+	podsInformers          namespacedInformers
+	replicaSetsLister      listerAppsv1.ReplicaSetLister
+	replicaSetsIndexer     cache.Indexer
+	servicesLister         listerCorev1.ServiceLister
+	servicesIndexer        cache.Indexer
+	endpointSlicesLister   listerDiscoveryv1.EndpointSliceLister
+	endpointSlicesIndexer  cache.Indexer
+	statefulSetsLister     listerAppsv1.StatefulSetLister
+	statefulSetsIndexer    cache.Indexer
+	eventsIndexer          cache.Indexer
+	nodesLister            listerCorev1.NodeLister
+	nodesInformer          cache.SharedIndexInformer
+	pvcsLister             listerCorev1.PersistentVolumeClaimLister
+	pvcsIndexer            cache.Indexer
+	namespacesLister       listerCorev1.NamespaceLister
+	namespacesInformer     cache.SharedIndexInformer
+	hpasLister             listerAutoscalingv2.HorizontalPodAutoscalerLister
+	hpasIndexer            cache.Indexer
+	serviceAccountsLister  listerCorev1.ServiceAccountLister
+	serviceAccountsIndexer cache.Indexer
+	jobsLister             listerBatchv1.JobLister
+	jobsIndexer            cache.Indexer
+	cronJobsLister         listerBatchv1.CronJobLister
+	cronJobsIndexer        cache.Indexer
+	ingressesLister        listerNetworkingv1.IngressLister
+	ingressesIndexer       cache.Indexer
+	discoveryBackoff       *Backoff
+	// clusterName and kubeContext are only set if multiple cluster contexts are configured.
+	clusterName string
+	kubeContext string
+}
+
+// DiscoveryInterval is the configured discovery interval, stretched while the informers fail to list or watch resources.
+func (c *Client) DiscoveryInterval() time.Duration {
+	return c.discoveryBackoff.Interval()
+}
+
+// Clientset gives access to the Kubernetes API for actions that need to modify resources.
+func (c *Client) Clientset() kubernetes.Interface {
+	return c.clientset
 }
 
 func (c *Client) Pods() []*corev1.Pod {
@@ -57,6 +104,26 @@ func (c *Client) Pods() []*corev1.Pod {
 	return pods
 }
 
+// PodsByLabelSelector returns the pods of all namespaces matching the selector, which may contain set-based requirements.
+func (c *Client) PodsByLabelSelector(selector labels.Selector) []*corev1.Pod {
+	pods, err := c.podsLister.List(selector)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching pods for selector %s", selector)
+		return []*corev1.Pod{}
+	}
+	return pods
+}
+
+func (c *Client) PodsByNode(nodeName string) []*corev1.Pod {
+	var result []*corev1.Pod
+	for _, pod := range c.Pods() {
+		if pod.Spec.NodeName == nodeName {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
 func (c *Client) PodsByDeployment(deployment *appsv1.Deployment) []*corev1.Pod {
 	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
 	if err != nil {
@@ -71,6 +138,53 @@ func (c *Client) PodsByDeployment(deployment *appsv1.Deployment) []*corev1.Pod {
 	return list
 }
 
+func (c *Client) PodsBySelector(namespace string, labelSelector *metav1.LabelSelector) []*corev1.Pod {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while creating a selector %s", labelSelector)
+		return nil
+	}
+	pods, err := c.podsLister.Pods(namespace).List(selector)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching pods in %s for selector %s", namespace, selector)
+		return nil
+	}
+	return pods
+}
+
+func (c *Client) PersistentVolumeClaims(namespace string) []*corev1.PersistentVolumeClaim {
+	pvcs, err := c.pvcsLister.PersistentVolumeClaims(namespace).List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching persistent volume claims in %s", namespace)
+		return []*corev1.PersistentVolumeClaim{}
+	}
+	return pvcs
+}
+
+// AllPersistentVolumeClaims returns the persistent volume claims of all namespaces.
+func (c *Client) AllPersistentVolumeClaims() []*corev1.PersistentVolumeClaim {
+	return c.PersistentVolumeClaims(metav1.NamespaceAll)
+}
+
+// PodsByPersistentVolumeClaim returns the pods mounting the claim.
+func (c *Client) PodsByPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) []*corev1.Pod {
+	pods, err := c.podsLister.Pods(pvc.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching pods in %s", pvc.Namespace)
+		return []*corev1.Pod{}
+	}
+	var result []*corev1.Pod
+	for _, pod := range pods {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc.Name {
+				result = append(result, pod)
+				break
+			}
+		}
+	}
+	return result
+}
+
 func (c *Client) Deployments() []*appsv1.Deployment {
 	deployments, err := c.deploymentsLister.List(labels.Everything())
 	if err != nil {
@@ -80,6 +194,117 @@ func (c *Client) Deployments() []*appsv1.Deployment {
 	return deployments
 }
 
+func (c *Client) StatefulSets() []*appsv1.StatefulSet {
+	statefulSets, err := c.statefulSetsLister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching statefulsets")
+		return []*appsv1.StatefulSet{}
+	}
+	return statefulSets
+}
+
+func (c *Client) Jobs() []*batchv1.Job {
+	jobs, err := c.jobsLister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching jobs")
+		return []*batchv1.Job{}
+	}
+	return jobs
+}
+
+func (c *Client) CronJobs() []*batchv1.CronJob {
+	cronJobs, err := c.cronJobsLister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching cronjobs")
+		return []*batchv1.CronJob{}
+	}
+	return cronJobs
+}
+
+// JobsByCronJob returns the jobs spawned by the cronjob, i.e. the jobs it is the controlling owner of.
+func (c *Client) JobsByCronJob(cronJob *batchv1.CronJob) []*batchv1.Job {
+	jobs, err := c.jobsLister.Jobs(cronJob.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching jobs in %s", cronJob.Namespace)
+		return []*batchv1.Job{}
+	}
+	var result []*batchv1.Job
+	for _, job := range jobs {
+		if owner := metav1.GetControllerOf(job); owner != nil && owner.UID == cronJob.UID {
+			result = append(result, job)
+		}
+	}
+	return result
+}
+
+func (c *Client) ReplicaSets() []*appsv1.ReplicaSet {
+	replicaSets, err := c.replicaSetsLister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching replicasets")
+		return []*appsv1.ReplicaSet{}
+	}
+	return replicaSets
+}
+
+// ReplicaSetsByDeployment returns the replicasets the deployment is the controlling owner of, including scaled down ones
+// of previous revisions.
+func (c *Client) ReplicaSetsByDeployment(deployment *appsv1.Deployment) []*appsv1.ReplicaSet {
+	replicaSets, err := c.replicaSetsLister.ReplicaSets(deployment.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching replicasets in %s", deployment.Namespace)
+		return []*appsv1.ReplicaSet{}
+	}
+	var result []*appsv1.ReplicaSet
+	for _, replicaSet := range replicaSets {
+		if owner := metav1.GetControllerOf(replicaSet); owner != nil && owner.UID == deployment.UID {
+			result = append(result, replicaSet)
+		}
+	}
+	return result
+}
+
+func (c *Client) Ingresses() []*networkingv1.Ingress {
+	ingresses, err := c.ingressesLister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching ingresses")
+		return []*networkingv1.Ingress{}
+	}
+	return ingresses
+}
+
+func (c *Client) HorizontalPodAutoscalers() []*autoscalingv2.HorizontalPodAutoscaler {
+	hpas, err := c.hpasLister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching horizontal pod autoscalers")
+		return []*autoscalingv2.HorizontalPodAutoscaler{}
+	}
+	return hpas
+}
+
+// HorizontalPodAutoscalerByScaleTarget returns the autoscaler scaling the given resource, e.g. kind Deployment.
+func (c *Client) HorizontalPodAutoscalerByScaleTarget(namespace string, kind string, name string) *autoscalingv2.HorizontalPodAutoscaler {
+	hpas, err := c.hpasLister.HorizontalPodAutoscalers(namespace).List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching horizontal pod autoscalers in %s", namespace)
+		return nil
+	}
+	for _, hpa := range hpas {
+		if hpa.Spec.ScaleTargetRef.Kind == kind && hpa.Spec.ScaleTargetRef.Name == name {
+			return hpa
+		}
+	}
+	return nil
+}
+
+func (c *Client) Services() []*corev1.Service {
+	services, err := c.servicesLister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching services")
+		return []*corev1.Service{}
+	}
+	return services
+}
+
 func (c *Client) ServicesByPod(pod *corev1.Pod) []*corev1.Service {
 	services, err := c.servicesLister.List(labels.Everything())
 	if err != nil {
@@ -101,9 +326,74 @@ func (c *Client) ServicesByPod(pod *corev1.Pod) []*corev1.Service {
 	return result
 }
 
+// EndpointSlicesByService returns the endpoint slices of the service, which are linked through the
+// kubernetes.io/service-name label. This includes slices of services without selector, which are managed manually.
+func (c *Client) EndpointSlicesByService(namespace string, name string) []*discoveryv1.EndpointSlice {
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: name})
+	endpointSlices, err := c.endpointSlicesLister.EndpointSlices(namespace).List(selector)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching endpoint slices of service %s/%s", namespace, name)
+		return []*discoveryv1.EndpointSlice{}
+	}
+	return endpointSlices
+}
+
+// ReadyEndpointServicesByPod returns the names of the services by the names of the pods in the namespace which are a
+// ready endpoint of the service, based on the endpoint slices of the namespace.
+func (c *Client) ReadyEndpointServicesByPod(namespace string) map[string][]string {
+	endpointSlices, err := c.endpointSlicesLister.EndpointSlices(namespace).List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching endpoint slices of namespace %s", namespace)
+		return map[string][]string{}
+	}
+
+	services := map[string]map[string]bool{}
+	for _, endpointSlice := range endpointSlices {
+		service := endpointSlice.Labels[discoveryv1.LabelServiceName]
+		if service == "" {
+			continue
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+				continue
+			}
+			// An unknown ready condition is to be interpreted as ready.
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			if services[endpoint.TargetRef.Name] == nil {
+				services[endpoint.TargetRef.Name] = map[string]bool{}
+			}
+			services[endpoint.TargetRef.Name][service] = true
+		}
+	}
+
+	result := make(map[string][]string, len(services))
+	for pod, names := range services {
+		for name := range names {
+			result[pod] = append(result[pod], name)
+		}
+		sort.Strings(result[pod])
+	}
+	return result
+}
+
+func (c *Client) CronJobByNamespaceAndName(namespace string, name string) *batchv1.CronJob {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	item, _, err := c.cronJobsIndexer.GetByKey(key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of CronJob %s/%s", namespace, name)
+	}
+	if item != nil {
+		return item.(*batchv1.CronJob)
+	} else {
+		return nil
+	}
+}
+
 func (c *Client) DaemonSetByNamespaceAndName(namespace string, name string) *appsv1.DaemonSet {
 	key := fmt.Sprintf("%s/%s", namespace, name)
-	item, _, err := c.daemonSetsInformer.GetIndexer().GetByKey(key)
+	item, _, err := c.daemonSetsIndexer.GetByKey(key)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error during lookup of DaemonSet %s/%s", namespace, name)
 	}
@@ -115,7 +405,7 @@ func (c *Client) DaemonSetByNamespaceAndName(namespace string, name string) *app
 }
 func (c *Client) DeploymentByNamespaceAndName(namespace string, name string) *appsv1.Deployment {
 	key := fmt.Sprintf("%s/%s", namespace, name)
-	item, _, err := c.deploymentsInformer.GetIndexer().GetByKey(key)
+	item, _, err := c.deploymentsIndexer.GetByKey(key)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error during lookup of Deployment %s/%s", namespace, name)
 	}
@@ -125,9 +415,21 @@ func (c *Client) DeploymentByNamespaceAndName(namespace string, name string) *ap
 		return nil
 	}
 }
+func (c *Client) PodByNamespaceAndName(namespace string, name string) *corev1.Pod {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	item, _, err := c.podsIndexer.GetByKey(key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of Pod %s/%s", namespace, name)
+	}
+	if item != nil {
+		return item.(*corev1.Pod)
+	} else {
+		return nil
+	}
+}
 func (c *Client) ReplicaSetByNamespaceAndName(namespace string, name string) *appsv1.ReplicaSet {
 	key := fmt.Sprintf("%s/%s", namespace, name)
-	item, _, err := c.replicaSetsInformer.GetIndexer().GetByKey(key)
+	item, _, err := c.replicaSetsIndexer.GetByKey(key)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error during lookup of ReplicaSet %s/%s", namespace, name)
 	}
@@ -137,9 +439,85 @@ func (c *Client) ReplicaSetByNamespaceAndName(namespace string, name string) *ap
 		return nil
 	}
 }
+func (c *Client) HorizontalPodAutoscalerByNamespaceAndName(namespace string, name string) *autoscalingv2.HorizontalPodAutoscaler {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	item, _, err := c.hpasIndexer.GetByKey(key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of HorizontalPodAutoscaler %s/%s", namespace, name)
+	}
+	if item != nil {
+		return item.(*autoscalingv2.HorizontalPodAutoscaler)
+	} else {
+		return nil
+	}
+}
+func (c *Client) ServiceByNamespaceAndName(namespace string, name string) *corev1.Service {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	item, _, err := c.servicesIndexer.GetByKey(key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of Service %s/%s", namespace, name)
+	}
+	if item != nil {
+		return item.(*corev1.Service)
+	} else {
+		return nil
+	}
+}
+
+func (c *Client) IngressByNamespaceAndName(namespace string, name string) *networkingv1.Ingress {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	item, _, err := c.ingressesIndexer.GetByKey(key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of Ingress %s/%s", namespace, name)
+	}
+	if item != nil {
+		return item.(*networkingv1.Ingress)
+	} else {
+		return nil
+	}
+}
+
+func (c *Client) JobByNamespaceAndName(namespace string, name string) *batchv1.Job {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	item, _, err := c.jobsIndexer.GetByKey(key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of Job %s/%s", namespace, name)
+	}
+	if item != nil {
+		return item.(*batchv1.Job)
+	} else {
+		return nil
+	}
+}
+
+func (c *Client) NamespaceByName(name string) *corev1.Namespace {
+	item, _, err := c.namespacesInformer.GetIndexer().GetByKey(name)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of Namespace %s", name)
+	}
+	if item != nil {
+		return item.(*corev1.Namespace)
+	} else {
+		return nil
+	}
+}
+
+func (c *Client) ServiceAccountByNamespaceAndName(namespace string, name string) *corev1.ServiceAccount {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	item, _, err := c.serviceAccountsIndexer.GetByKey(key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of ServiceAccount %s/%s", namespace, name)
+	}
+	if item != nil {
+		return item.(*corev1.ServiceAccount)
+	} else {
+		return nil
+	}
+}
+
 func (c *Client) StatefulSetByNamespaceAndName(namespace string, name string) *appsv1.StatefulSet {
 	key := fmt.Sprintf("%s/%s", namespace, name)
-	item, _, err := c.statefulSetsInformer.GetIndexer().GetByKey(key)
+	item, _, err := c.statefulSetsIndexer.GetByKey(key)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error during lookup of StatefulSet %s/%s", namespace, name)
 	}
@@ -154,15 +532,60 @@ func (c *Client) NodesReadyCount() int {
 	nodes := c.Nodes()
 	nodeCountReady := 0
 	for _, node := range nodes {
-		for _, condition := range node.Status.Conditions {
-			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
-				nodeCountReady = nodeCountReady + 1
-			}
+		if IsNodeReady(node) {
+			nodeCountReady = nodeCountReady + 1
 		}
 	}
 	return nodeCountReady
 }
 
+func IsNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// SchedulableReadyNodes returns all nodes which are ready and not cordoned.
+func (c *Client) SchedulableReadyNodes() []*corev1.Node {
+	var result []*corev1.Node
+	for _, node := range c.Nodes() {
+		if !node.Spec.Unschedulable && IsNodeReady(node) {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// ClusterCapacity sums up the allocatable cpu (in millicores) and memory (in bytes) of all schedulable and ready nodes.
+func (c *Client) ClusterCapacity() (cpuMillis int64, memoryBytes int64) {
+	for _, node := range c.SchedulableReadyNodes() {
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			cpuMillis += cpu.MilliValue()
+		} else {
+			log.Debug().Msgf("Node %s doesn't report allocatable cpu", node.Name)
+		}
+		if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			memoryBytes += memory.Value()
+		} else {
+			log.Debug().Msgf("Node %s doesn't report allocatable memory", node.Name)
+		}
+	}
+	return cpuMillis, memoryBytes
+}
+
+// MaxNodeAllocatableCpu returns the allocatable cpu of the largest node in the cluster.
+func (c *Client) MaxNodeAllocatableCpu() (cpuMillis int64) {
+	for _, node := range c.Nodes() {
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok && cpu.MilliValue() > cpuMillis {
+			cpuMillis = cpu.MilliValue()
+		}
+	}
+	return cpuMillis
+}
+
 func (c *Client) Nodes() []*corev1.Node {
 	nodes, err := c.nodesLister.List(labels.Everything())
 	if err != nil {
@@ -172,8 +595,20 @@ func (c *Client) Nodes() []*corev1.Node {
 	return nodes
 }
 
+func (c *Client) NodeByName(name string) *corev1.Node {
+	item, _, err := c.nodesInformer.GetIndexer().GetByKey(name)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of Node %s", name)
+	}
+	if item != nil {
+		return item.(*corev1.Node)
+	} else {
+		return nil
+	}
+}
+
 func (c *Client) Events(since time.Time) *[]corev1.Event {
-	events := c.eventsInformer.GetIndexer().List()
+	events := c.eventsIndexer.List()
 	//filter events by time
 	result := filterEvents(events, since)
 	//sort events by time
@@ -183,6 +618,49 @@ func (c *Client) Events(since time.Time) *[]corev1.Event {
 	return &result
 }
 
+// WarningEventsInNamespace returns the events of type Warning in the namespace which were last seen after since.
+func (c *Client) WarningEventsInNamespace(namespace string, since time.Time) []corev1.Event {
+	var result []corev1.Event
+	for _, event := range *c.Events(since) {
+		if event.Namespace == namespace && event.Type == corev1.EventTypeWarning {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// involvedObjectIndex indexes events by the namespace, kind and name of their involved object.
+const involvedObjectIndex = "involvedObject"
+
+func indexByInvolvedObject(obj interface{}) ([]string, error) {
+	event, ok := obj.(*corev1.Event)
+	if !ok {
+		return nil, nil
+	}
+	return []string{involvedObjectKey(event.InvolvedObject.Namespace, event.InvolvedObject.Kind, event.InvolvedObject.Name)}, nil
+}
+
+// involvedObjectKey ignores the case of the kind, so that both "Deployment" and the owner kinds reported as
+// attributes, e.g. "deployment", match.
+func involvedObjectKey(namespace string, kind string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, strings.ToLower(kind), name)
+}
+
+// EventsByObject returns the events of the involved object which were last seen after since, ordered by time. Events
+// of an object which was deleted and recreated with the same name are included.
+func (c *Client) EventsByObject(namespace string, kind string, name string, since time.Time) []corev1.Event {
+	events, err := c.eventsIndexer.ByIndex(involvedObjectIndex, involvedObjectKey(namespace, kind, name))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error during lookup of events of %s %s/%s", kind, namespace, name)
+		return nil
+	}
+	result := filterEvents(events, since)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastTimestamp.Time.Before(result[j].LastTimestamp.Time)
+	})
+	return result
+}
+
 func filterEvents(events []interface{}, since time.Time) []corev1.Event {
 	var filtered []corev1.Event
 	for _, event := range events {
@@ -194,46 +672,119 @@ func filterEvents(events []interface{}, since time.Time) []corev1.Event {
 }
 
 func PrepareClient(stopCh <-chan struct{}) {
+	if len(extconfig.Config.ClusterContexts) > 0 {
+		prepareClusterClients(stopCh)
+		return
+	}
 	clientset, rootApiPath := createClientset()
-	K8S = CreateClient(clientset, stopCh, rootApiPath)
-}
-
-// CreateClient is visible for testing
-func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootApiPath string) *Client {
-	factory := informers.NewSharedInformerFactory(clientset, 0)
-
-	// DeploymentsInformer.SetTransform() // TODO - Check whether we could use transformers to remove stuff --> save RAM?
-	daemonSets := factory.Apps().V1().DaemonSets()
-	daemonSetsInformer := daemonSets.Informer()
-	deployments := factory.Apps().V1().Deployments()
-	deploymentsInformer := deployments.Informer()
-	pods := factory.Core().V1().Pods()
-	podsInformer := pods.Informer()
-	replicaSets := factory.Apps().V1().ReplicaSets()
-	replicaSetsInformer := replicaSets.Informer()
-	services := factory.Core().V1().Services()
-	servicesInformer := services.Informer()
-	statefulSets := factory.Apps().V1().StatefulSets()
-	statefulSetsInformer := statefulSets.Informer()
-	eventsInformer := factory.Core().V1().Events().Informer()
+	K8S = CreateClient(clientset, stopCh, rootApiPath, extconfig.Config.InformerResyncPeriod, extconfig.Config.Namespaces)
+}
+
+// CreateClient is visible for testing. A resyncPeriod of 0 disables the periodic resync of the informers. Namespaced
+// resources are watched in all namespaces if no watchedNamespaces are given.
+func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootApiPath string, resyncPeriod time.Duration, watchedNamespaces []string) *Client {
+	factory := informers.NewSharedInformerFactory(clientset, resyncPeriod)
+	// Namespaced resources are only watched in the configured namespaces, which saves memory in large clusters.
+	namespacedFactories := newNamespacedFactories(clientset, resyncPeriod, factory, watchedNamespaces)
+
+	daemonSetsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().DaemonSets().Informer()
+	})
+	deploymentsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().Deployments().Informer()
+	})
+	podsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Pods().Informer()
+	})
+	replicaSetsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().ReplicaSets().Informer()
+	})
+	servicesInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Services().Informer()
+	})
+	endpointSlicesInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Discovery().V1().EndpointSlices().Informer()
+	})
+	statefulSetsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().StatefulSets().Informer()
+	})
+	eventsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		informer := f.Core().V1().Events().Informer()
+		if err := informer.AddIndexers(cache.Indexers{involvedObjectIndex: indexByInvolvedObject}); err != nil {
+			log.Warn().Err(err).Msg("Failed to register the involved object index of events.")
+		}
+		return informer
+	})
+	pvcsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().PersistentVolumeClaims().Informer()
+	})
+	hpasInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Autoscaling().V2().HorizontalPodAutoscalers().Informer()
+	})
+	serviceAccountsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().ServiceAccounts().Informer()
+	})
+	jobsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Batch().V1().Jobs().Informer()
+	})
+	cronJobsInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Batch().V1().CronJobs().Informer()
+	})
+	ingressesInformers := newNamespacedInformers(namespacedFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Networking().V1().Ingresses().Informer()
+	})
 	nodes := factory.Core().V1().Nodes()
 	nodesInformer := nodes.Informer()
+	namespaces := factory.Core().V1().Namespaces()
+	namespacesInformer := namespaces.Informer()
+
+	allInformers := []cache.SharedIndexInformer{nodesInformer, namespacesInformer}
+	for _, namespaced := range []namespacedInformers{
+		daemonSetsInformers,
+		deploymentsInformers,
+		podsInformers,
+		replicaSetsInformers,
+		servicesInformers,
+		endpointSlicesInformers,
+		statefulSetsInformers,
+		eventsInformers,
+		pvcsInformers,
+		hpasInformers,
+		serviceAccountsInformers,
+		jobsInformers,
+		cronJobsInformers,
+		ingressesInformers,
+	} {
+		for _, informer := range namespaced {
+			allInformers = append(allInformers, informer)
+		}
+	}
+
+	discoveryInterval := time.Duration(extconfig.Config.DiscoveryIntervalSeconds) * time.Second
+	if discoveryInterval <= 0 {
+		discoveryInterval = defaultDiscoveryInterval
+	}
+	discoveryBackoff := NewBackoff(discoveryInterval, maxDiscoveryIntervalFactor*discoveryInterval)
+	hasSynced := make([]cache.InformerSynced, 0, len(allInformers))
+	for _, informer := range allInformers {
+		if err := informer.SetTransform(stripUnusedFields); err != nil {
+			log.Warn().Err(err).Msg("Failed to register transform.")
+		}
+		trackApiErrors(informer, discoveryBackoff)
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
 
 	defer runtime.HandleCrash()
 
 	go factory.Start(stopCh)
+	if len(watchedNamespaces) > 0 {
+		for _, namespacedFactory := range namespacedFactories {
+			go namespacedFactory.Start(stopCh)
+		}
+	}
 
 	log.Info().Msgf("Start Kubernetes cache sync.")
-	if !cache.WaitForCacheSync(stopCh,
-		daemonSetsInformer.HasSynced,
-		deploymentsInformer.HasSynced,
-		podsInformer.HasSynced,
-		replicaSetsInformer.HasSynced,
-		servicesInformer.HasSynced,
-		statefulSetsInformer.HasSynced,
-		eventsInformer.HasSynced,
-		nodesInformer.HasSynced,
-	) {
+	if !cache.WaitForCacheSync(stopCh, hasSynced...) {
 		log.Fatal().Msg("Timed out waiting for caches to sync")
 	}
 	log.Info().Msgf("Caches synced.")
@@ -244,22 +795,61 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	}
 
 	return &Client{
-		Distribution:         distribution,
-		daemonSetsLister:     daemonSets.Lister(),
-		daemonSetsInformer:   daemonSetsInformer,
-		deploymentsLister:    deployments.Lister(),
-		deploymentsInformer:  deploymentsInformer,
-		podsLister:           pods.Lister(),
-		podsInformer:         podsInformer,
-		replicaSetsLister:    replicaSets.Lister(),
-		replicaSetsInformer:  replicaSetsInformer,
-		servicesLister:       services.Lister(),
-		servicesInformer:     servicesInformer,
-		statefulSetsLister:   statefulSets.Lister(),
-		statefulSetsInformer: statefulSetsInformer,
-		eventsInformer:       eventsInformer,
-		nodesLister:          nodes.Lister(),
-		nodesInformer:        nodesInformer,
+		Distribution:           distribution,
+		clientset:              clientset,
+		daemonSetsLister:       listerAppsv1.NewDaemonSetLister(daemonSetsInformers.Indexer()),
+		daemonSetsIndexer:      daemonSetsInformers.Indexer(),
+		deploymentsLister:      listerAppsv1.NewDeploymentLister(deploymentsInformers.Indexer()),
+		deploymentsIndexer:     deploymentsInformers.Indexer(),
+		podsLister:             listerCorev1.NewPodLister(podsInformers.Indexer()),
+		podsIndexer:            podsInformers.Indexer(),
+		podsInformers:          podsInformers,
+		replicaSetsLister:      listerAppsv1.NewReplicaSetLister(replicaSetsInformers.Indexer()),
+		replicaSetsIndexer:     replicaSetsInformers.Indexer(),
+		servicesLister:         listerCorev1.NewServiceLister(servicesInformers.Indexer()),
+		servicesIndexer:        servicesInformers.Indexer(),
+		endpointSlicesLister:   listerDiscoveryv1.NewEndpointSliceLister(endpointSlicesInformers.Indexer()),
+		endpointSlicesIndexer:  endpointSlicesInformers.Indexer(),
+		statefulSetsLister:     listerAppsv1.NewStatefulSetLister(statefulSetsInformers.Indexer()),
+		statefulSetsIndexer:    statefulSetsInformers.Indexer(),
+		eventsIndexer:          eventsInformers.Indexer(),
+		nodesLister:            nodes.Lister(),
+		nodesInformer:          nodesInformer,
+		pvcsLister:             listerCorev1.NewPersistentVolumeClaimLister(pvcsInformers.Indexer()),
+		pvcsIndexer:            pvcsInformers.Indexer(),
+		namespacesLister:       namespaces.Lister(),
+		namespacesInformer:     namespacesInformer,
+		hpasLister:             listerAutoscalingv2.NewHorizontalPodAutoscalerLister(hpasInformers.Indexer()),
+		hpasIndexer:            hpasInformers.Indexer(),
+		serviceAccountsLister:  listerCorev1.NewServiceAccountLister(serviceAccountsInformers.Indexer()),
+		serviceAccountsIndexer: serviceAccountsInformers.Indexer(),
+		jobsLister:             listerBatchv1.NewJobLister(jobsInformers.Indexer()),
+		jobsIndexer:            jobsInformers.Indexer(),
+		cronJobsLister:         listerBatchv1.NewCronJobLister(cronJobsInformers.Indexer()),
+		cronJobsIndexer:        cronJobsInformers.Indexer(),
+		ingressesLister:        listerNetworkingv1.NewIngressLister(ingressesInformers.Indexer()),
+		ingressesIndexer:       ingressesInformers.Indexer(),
+		discoveryBackoff:       discoveryBackoff,
+	}
+}
+
+// trackApiErrors records failing list and watch calls of the informer in the backoff. As soon as the informer
+// receives objects again the API server is considered healthy and the backoff is reset.
+func trackApiErrors(informer cache.SharedIndexInformer, backoff *Backoff) {
+	err := informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		backoff.Failure()
+		cache.DefaultWatchErrorHandler(r, err)
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to register watch error handler.")
+	}
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(_ interface{}) { backoff.Success() },
+		UpdateFunc: func(_, _ interface{}) { backoff.Success() },
+		DeleteFunc: func(_ interface{}) { backoff.Success() },
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to register event handler.")
 	}
 }
 
@@ -267,7 +857,9 @@ func isOpenShift(rootApiPath string) bool {
 	return rootApiPath == "/oapi" || rootApiPath == "oapi"
 }
 
-func createClientset() (*kubernetes.Clientset, string) {
+const baseUserAgent = "steadybit-extension-kubernetes"
+
+func createClientset() (kubernetes.Interface, string) {
 	config, err := rest.InClusterConfig()
 	if err == nil {
 		log.Info().Msgf("Extension is running inside a cluster, config found")
@@ -288,8 +880,18 @@ func createClientset() (*kubernetes.Clientset, string) {
 		log.Fatal().Err(err).Msgf("Could not find kubernetes config")
 	}
 
-	config.UserAgent = "steadybit-extension-kubernetes"
+	return connect(config)
+}
+
+func connect(config *rest.Config) (kubernetes.Interface, string) {
+	config.UserAgent = userAgent(extconfig.Config.UserAgentSuffix)
 	config.Timeout = time.Second * 10
+	return newClientset(config)
+}
+
+// newClientset creates the clientset for the config and returns it together with the root API path. Replaced in tests
+// to avoid the connection to a cluster.
+var newClientset = func(config *rest.Config) (kubernetes.Interface, string) {
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		log.Fatal().Err(err).Msgf("Could not create kubernetes client")
@@ -305,6 +907,15 @@ func createClientset() (*kubernetes.Clientset, string) {
 	return clientset, config.APIPath
 }
 
+// userAgent identifies the extension in the audit logs of the API server. The suffix allows to tell apart
+// multiple installations, e.g. by version or cluster.
+func userAgent(suffix string) string {
+	if suffix == "" {
+		return baseUserAgent
+	}
+	return fmt.Sprintf("%s %s", baseUserAgent, suffix)
+}
+
 func IsExcludedFromDiscovery(objectMeta metav1.ObjectMeta) bool {
 	discoveryEnabled, keyExists := objectMeta.Labels["steadybit.com/discovery-disabled"]
 	if keyExists && strings.ToLower(discoveryEnabled) == "true" {
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClusterCapacity(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset()
+	createNode(t, clientset, "node1", false, corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1500m"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	})
+	createNode(t, clientset, "node2", false, corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("512Mi"),
+	})
+	createNode(t, clientset, "cordoned", true, corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("8"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	})
+	createNode(t, clientset, "no-memory", false, corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("500m"),
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client := CreateClient(clientset, stopCh, "", 0, nil)
+
+	// When
+	cpuMillis, memoryBytes := client.ClusterCapacity()
+
+	// Then
+	assert.Equal(t, int64(4000), cpuMillis)
+	assert.Equal(t, int64(2*1024*1024*1024+512*1024*1024), memoryBytes)
+}
+
+func TestEventsByObject(t *testing.T) {
+	// Given
+	now := time.Now()
+	clientset := testclient.NewSimpleClientset(
+		involvedEvent("shop", "scaled", "Deployment", "shop", now.Add(-time.Minute)),
+		involvedEvent("shop", "rollout", "Deployment", "shop", now.Add(-2*time.Minute)),
+		involvedEvent("shop", "outdated", "Deployment", "shop", now.Add(-time.Hour)),
+		involvedEvent("shop", "pod", "Pod", "shop", now),
+		involvedEvent("checkout", "other-namespace", "Deployment", "shop", now),
+	)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client := CreateClient(clientset, stopCh, "", 0, nil)
+
+	// When
+	events := client.EventsByObject("shop", "deployment", "shop", now.Add(-10*time.Minute))
+
+	// Then
+	require.Len(t, events, 2)
+	assert.Equal(t, "rollout", events[0].Name)
+	assert.Equal(t, "scaled", events[1].Name)
+}
+
+func TestPrepareClientResyncsInformersWithPeriodFromEnvironment(t *testing.T) {
+	// Given
+	fakeConnections(t, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+	parseEnvironment(t, map[string]string{
+		"STEADYBIT_EXTENSION_CLUSTER_NAME":           "prod",
+		"STEADYBIT_EXTENSION_CLUSTER_CONTEXTS":       "prod:prod-context",
+		"STEADYBIT_EXTENSION_INFORMER_RESYNC_PERIOD": "1s",
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// When
+	PrepareClient(stopCh)
+
+	// Then
+	var resyncs atomic.Int32
+	_, err := K8S.nodesInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, _ interface{}) { resyncs.Add(1) },
+	})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return resyncs.Load() > 0 }, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestPrepareClientUsesDiscoveryIntervalFromEnvironment(t *testing.T) {
+	// Given
+	fakeConnections(t)
+	parseEnvironment(t, map[string]string{
+		"STEADYBIT_EXTENSION_CLUSTER_NAME":               "prod",
+		"STEADYBIT_EXTENSION_CLUSTER_CONTEXTS":           "prod:prod-context",
+		"STEADYBIT_EXTENSION_DISCOVERY_INTERVAL_SECONDS": "15",
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// When
+	PrepareClient(stopCh)
+
+	// Then
+	assert.Equal(t, 15*time.Second, K8S.DiscoveryInterval())
+}
+
+func TestUserAgent(t *testing.T) {
+	assert.Equal(t, "steadybit-extension-kubernetes", userAgent(""))
+	assert.Equal(t, "steadybit-extension-kubernetes v2.4.0/prod-eu", userAgent("v2.4.0/prod-eu"))
+}
+
+func TestPrepareClientConnectsWithUserAgentSuffixFromEnvironment(t *testing.T) {
+	// Given
+	connections := fakeConnections(t)
+	parseEnvironment(t, map[string]string{
+		"STEADYBIT_EXTENSION_CLUSTER_NAME":      "prod",
+		"STEADYBIT_EXTENSION_CLUSTER_CONTEXTS":  "prod:prod-context",
+		"STEADYBIT_EXTENSION_USER_AGENT_SUFFIX": "v2.4.0/prod-eu",
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// When
+	PrepareClient(stopCh)
+
+	// Then
+	require.Len(t, *connections, 1)
+	assert.Equal(t, "steadybit-extension-kubernetes v2.4.0/prod-eu", (*connections)[0].UserAgent)
+	assert.Equal(t, 10*time.Second, (*connections)[0].Timeout)
+}
+
+func createNode(t *testing.T, clientset kubernetes.Interface, name string, unschedulable bool, allocatable corev1.ResourceList) {
+	_, err := clientset.CoreV1().Nodes().Create(context.Background(), &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: corev1.NodeSpec{
+			Unschedulable: unschedulable,
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: allocatable,
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func involvedEvent(namespace string, name string, kind string, objectName string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Namespace: namespace, Name: objectName},
+		LastTimestamp:  metav1.Time{Time: lastSeen},
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sort"
+)
+
+// InClusterContext can be configured instead of a kubeconfig context to use the service account of the extension.
+const InClusterContext = "in-cluster"
+
+// clients holds a client per cluster name if multiple cluster contexts are configured. It is empty otherwise and
+// K8S is the only client.
+var clients = map[string]*Client{}
+
+// prepareClusterClients creates a client for every configured cluster context. K8S is set to the client of the
+// configured cluster name, so that code which isn't aware of multiple clusters keeps working.
+func prepareClusterClients(stopCh <-chan struct{}) {
+	for clusterName, kubeContext := range extconfig.Config.ClusterContexts {
+		clientset, rootApiPath := connect(clusterConfig(kubeContext))
+		k8s := CreateClient(clientset, stopCh, rootApiPath, extconfig.Config.InformerResyncPeriod, extconfig.Config.Namespaces)
+		k8s.kubeContext = kubeContext
+		RegisterClusterClient(clusterName, k8s)
+		log.Info().Msgf("Cluster %s connected through context %s", clusterName, kubeContext)
+	}
+
+	K8S = clients[extconfig.Config.ClusterName]
+	if K8S == nil {
+		log.Fatal().Msgf("Cluster %s is missing in the cluster contexts.", extconfig.Config.ClusterName)
+	}
+}
+
+func clusterConfig(kubeContext string) *rest.Config {
+	var config *rest.Config
+	var err error
+	if kubeContext == InClusterContext {
+		config, err = rest.InClusterConfig()
+	} else {
+		// Honors KUBECONFIG and falls back to ~/.kube/config.
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(),
+			&clientcmd.ConfigOverrides{CurrentContext: kubeContext},
+		).ClientConfig()
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Could not find kubernetes config for context %s", kubeContext)
+	}
+	return config
+}
+
+// RegisterClusterClient adds the client to the clients of multiple clusters under the given cluster name. Visible for
+// testing.
+func RegisterClusterClient(clusterName string, k8s *Client) {
+	k8s.clusterName = clusterName
+	clients[clusterName] = k8s
+}
+
+// ResetClusterClients falls back to the single cluster client K8S. Visible for testing.
+func ResetClusterClients() {
+	clients = map[string]*Client{}
+}
+
+// ForCluster returns the client of the cluster with the given name, e.g. taken from the k8s.cluster-name attribute of
+// a target. With a single cluster K8S is returned regardless of the name. Returns nil for unknown clusters if multiple
+// clusters are configured.
+func ForCluster(clusterName string) *Client {
+	if len(clients) == 0 {
+		return K8S
+	}
+	return clients[clusterName]
+}
+
+// Clusters returns the clients of all clusters ordered by cluster name, or just K8S with a single cluster.
+func Clusters() []*Client {
+	if len(clients) == 0 {
+		if K8S == nil {
+			return nil
+		}
+		return []*Client{K8S}
+	}
+	names := make([]string, 0, len(clients))
+	for name := range clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]*Client, 0, len(names))
+	for _, name := range names {
+		result = append(result, clients[name])
+	}
+	return result
+}
+
+// ClusterName is the name of the cluster the client is connected to, which is reported as k8s.cluster-name.
+func (c *Client) ClusterName() string {
+	if c.clusterName != "" {
+		return c.clusterName
+	}
+	return extconfig.Config.ClusterName
+}
+
+// KubectlContextArgs returns the arguments that make kubectl talk to the cluster of the client.
+func (c *Client) KubectlContextArgs() []string {
+	if c == nil || c.kubeContext == "" || c.kubeContext == InClusterContext {
+		return nil
+	}
+	return []string{"--context", c.kubeContext}
+}
+
+// HasCluster reports whether a client for the cluster with the given name exists. Always true with a single cluster.
+func HasCluster(clusterName string) bool {
+	if len(clients) == 0 {
+		return true
+	}
+	_, ok := clients[clusterName]
+	return ok
+}
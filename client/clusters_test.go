@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSingleClusterUsesK8S(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	previous := K8S
+	defer func() { K8S = previous }()
+	K8S = CreateClient(testclient.NewSimpleClientset(), stopCh, "", 0, nil)
+
+	// Then
+	assert.Equal(t, []*Client{K8S}, Clusters())
+	assert.Same(t, K8S, ForCluster("anything"))
+	assert.True(t, HasCluster("anything"))
+	assert.Equal(t, "development", K8S.ClusterName())
+	assert.Nil(t, K8S.KubectlContextArgs())
+}
+
+func TestMultipleClusters(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	defer ResetClusterClients()
+	staging := CreateClient(testclient.NewSimpleClientset(), stopCh, "", 0, nil)
+	prod := CreateClient(testclient.NewSimpleClientset(), stopCh, "", 0, nil)
+	prod.kubeContext = "prod-context"
+	RegisterClusterClient("staging", staging)
+	RegisterClusterClient("prod", prod)
+
+	// Then
+	require.Equal(t, []*Client{prod, staging}, Clusters())
+	assert.Same(t, staging, ForCluster("staging"))
+	assert.Nil(t, ForCluster("unknown"))
+	assert.False(t, HasCluster("unknown"))
+	assert.Equal(t, "prod", prod.ClusterName())
+	assert.Equal(t, []string{"--context", "prod-context"}, prod.KubectlContextArgs())
+	assert.Nil(t, staging.KubectlContextArgs())
+}
+
+func TestPrepareClientUsesClusterContextsFromEnvironment(t *testing.T) {
+	// Given
+	connections := fakeConnections(t)
+	parseEnvironment(t, map[string]string{
+		"STEADYBIT_EXTENSION_CLUSTER_NAME":     "prod",
+		"STEADYBIT_EXTENSION_CLUSTER_CONTEXTS": "prod:prod-context,staging:staging-context",
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// When
+	PrepareClient(stopCh)
+
+	// Then
+	require.Len(t, Clusters(), 2)
+	assert.Same(t, ForCluster("prod"), K8S)
+	assert.Equal(t, "prod", K8S.ClusterName())
+	assert.Equal(t, []string{"--context", "prod-context"}, K8S.KubectlContextArgs())
+	assert.Equal(t, []string{"--context", "staging-context"}, ForCluster("staging").KubectlContextArgs())
+	hosts := make([]string, 0, len(*connections))
+	for _, config := range *connections {
+		hosts = append(hosts, config.Host)
+	}
+	assert.ElementsMatch(t, []string{"https://prod.example.com", "https://staging.example.com"}, hosts)
+}
+
+// parseEnvironment parses the extension configuration from the given environment variables and the kubeconfig of
+// fakeKubeconfig, like the extension does on startup. The previous configuration and clients are restored after the
+// test.
+func parseEnvironment(t *testing.T, env map[string]string) {
+	previousConfig := extconfig.Config
+	previousK8S := K8S
+	t.Cleanup(func() {
+		extconfig.Config = previousConfig
+		K8S = previousK8S
+		ResetClusterClients()
+	})
+	t.Setenv("KUBECONFIG", fakeKubeconfig(t))
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+	extconfig.Config = extconfig.Specification{}
+	extconfig.ParseConfiguration()
+	extconfig.ValidateConfiguration()
+}
+
+// fakeConnections replaces the connection to the API server by a fake clientset with the given objects and records the
+// configs the extension connects with.
+func fakeConnections(t *testing.T, objects ...runtime.Object) *[]*rest.Config {
+	previous := newClientset
+	t.Cleanup(func() { newClientset = previous })
+	connections := make([]*rest.Config, 0)
+	newClientset = func(config *rest.Config) (kubernetes.Interface, string) {
+		connections = append(connections, config)
+		return testclient.NewSimpleClientset(objects...), config.APIPath
+	}
+	return &connections
+}
+
+func fakeKubeconfig(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "config")
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: prod-context
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com
+- name: staging
+  cluster:
+    server: https://staging.example.com
+contexts:
+- name: prod-context
+  context:
+    cluster: prod
+    user: extension
+- name: staging-context
+  context:
+    cluster: staging
+    user: extension
+users:
+- name: extension
+  user:
+    token: secret
+`
+	require.NoError(t, os.WriteFile(path, []byte(kubeconfig), 0600))
+	return path
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"time"
+)
+
+var errReadOnlyIndexer = errors.New("the indexer of multiple namespaces is read-only")
+
+// newNamespacedFactories returns the factories for namespaced resources by namespace. Without configured namespaces
+// the given factory watches all namespaces.
+func newNamespacedFactories(clientset kubernetes.Interface, resyncPeriod time.Duration, factory informers.SharedInformerFactory, namespaces []string) map[string]informers.SharedInformerFactory {
+	if len(namespaces) == 0 {
+		return map[string]informers.SharedInformerFactory{metav1.NamespaceAll: factory}
+	}
+	factories := make(map[string]informers.SharedInformerFactory, len(namespaces))
+	for _, namespace := range namespaces {
+		factories[namespace] = informers.NewSharedInformerFactoryWithOptions(clientset, resyncPeriod, informers.WithNamespace(namespace))
+	}
+	return factories
+}
+
+// namespacedInformers holds the informers of a namespaced resource by namespace, a single one keyed by
+// metav1.NamespaceAll if all namespaces are watched.
+type namespacedInformers map[string]cache.SharedIndexInformer
+
+func newNamespacedInformers(factories map[string]informers.SharedInformerFactory, informer func(factory informers.SharedInformerFactory) cache.SharedIndexInformer) namespacedInformers {
+	result := make(namespacedInformers, len(factories))
+	for namespace, factory := range factories {
+		result[namespace] = informer(factory)
+	}
+	return result
+}
+
+// For returns the informer watching the given namespace, nil if the namespace isn't watched.
+func (n namespacedInformers) For(namespace string) cache.SharedIndexInformer {
+	if informer, ok := n[metav1.NamespaceAll]; ok {
+		return informer
+	}
+	return n[namespace]
+}
+
+// Indexer gives read access to the caches of all watched namespaces, e.g. for listers.
+func (n namespacedInformers) Indexer() cache.Indexer {
+	indexers := make(map[string]cache.Indexer, len(n))
+	for namespace, informer := range n {
+		indexers[namespace] = informer.GetIndexer()
+	}
+	if len(indexers) == 1 {
+		for _, indexer := range indexers {
+			return indexer
+		}
+	}
+	return &multiNamespaceIndexer{indexers: indexers}
+}
+
+// multiNamespaceIndexer combines the indexers of informers watching a single namespace each. Lookups by key or by
+// namespace are routed to the indexer of the namespace, everything else is merged.
+type multiNamespaceIndexer struct {
+	indexers map[string]cache.Indexer
+}
+
+var _ cache.Indexer = (*multiNamespaceIndexer)(nil)
+
+func (m *multiNamespaceIndexer) List() []interface{} {
+	var result []interface{}
+	for _, indexer := range m.indexers {
+		result = append(result, indexer.List()...)
+	}
+	return result
+}
+
+func (m *multiNamespaceIndexer) ListKeys() []string {
+	var result []string
+	for _, indexer := range m.indexers {
+		result = append(result, indexer.ListKeys()...)
+	}
+	return result
+}
+
+func (m *multiNamespaceIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return m.GetByKey(key)
+}
+
+func (m *multiNamespaceIndexer) GetByKey(key string) (interface{}, bool, error) {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	if indexer, ok := m.indexers[namespace]; ok {
+		return indexer.GetByKey(key)
+	}
+	return nil, false, nil
+}
+
+func (m *multiNamespaceIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	if indexName == cache.NamespaceIndex {
+		object, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		return m.ByIndex(indexName, object.GetNamespace())
+	}
+	var result []interface{}
+	for _, indexer := range m.indexers {
+		items, err := indexer.Index(indexName, obj)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, items...)
+	}
+	return result, nil
+}
+
+func (m *multiNamespaceIndexer) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	var result []string
+	for namespace, indexer := range m.indexers {
+		if indexName == cache.NamespaceIndex && namespace != indexedValue {
+			continue
+		}
+		keys, err := indexer.IndexKeys(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, keys...)
+	}
+	return result, nil
+}
+
+func (m *multiNamespaceIndexer) ListIndexFuncValues(indexName string) []string {
+	var result []string
+	for _, indexer := range m.indexers {
+		result = append(result, indexer.ListIndexFuncValues(indexName)...)
+	}
+	return result
+}
+
+func (m *multiNamespaceIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	var result []interface{}
+	for namespace, indexer := range m.indexers {
+		if indexName == cache.NamespaceIndex && namespace != indexedValue {
+			continue
+		}
+		items, err := indexer.ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, items...)
+	}
+	return result, nil
+}
+
+func (m *multiNamespaceIndexer) GetIndexers() cache.Indexers {
+	for _, indexer := range m.indexers {
+		return indexer.GetIndexers()
+	}
+	return cache.Indexers{}
+}
+
+// The informers own the caches, so all modifications are rejected.
+
+func (m *multiNamespaceIndexer) Add(_ interface{}) error {
+	return errReadOnlyIndexer
+}
+
+func (m *multiNamespaceIndexer) Update(_ interface{}) error {
+	return errReadOnlyIndexer
+}
+
+func (m *multiNamespaceIndexer) Delete(_ interface{}) error {
+	return errReadOnlyIndexer
+}
+
+func (m *multiNamespaceIndexer) Replace(_ []interface{}, _ string) error {
+	return errReadOnlyIndexer
+}
+
+func (m *multiNamespaceIndexer) Resync() error {
+	return errReadOnlyIndexer
+}
+
+func (m *multiNamespaceIndexer) AddIndexers(_ cache.Indexers) error {
+	return errReadOnlyIndexer
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sort"
+	"testing"
+)
+
+func TestCreateClientWatchesConfiguredNamespacesOnly(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset(
+		namespacedPod("shop", "cart"),
+		namespacedPod("checkout", "payment"),
+		namespacedPod("kube-system", "coredns"),
+	)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// When
+	client := CreateClient(clientset, stopCh, "", 0, []string{"shop", "checkout"})
+
+	// Then
+	names := make([]string, 0)
+	for _, pod := range client.Pods() {
+		names = append(names, pod.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"cart", "payment"}, names)
+	assert.NotNil(t, client.PodByNamespaceAndName("shop", "cart"))
+	assert.Nil(t, client.PodByNamespaceAndName("kube-system", "coredns"))
+	pods, err := client.podsLister.Pods("checkout").List(labels.Everything())
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	assert.Equal(t, "payment", pods[0].Name)
+}
+
+func TestPrepareClientWatchesNamespacesFromEnvironment(t *testing.T) {
+	// Given
+	fakeConnections(t,
+		namespacedPod("shop", "cart"),
+		namespacedPod("kube-system", "coredns"),
+	)
+	parseEnvironment(t, map[string]string{
+		"STEADYBIT_EXTENSION_CLUSTER_NAME":     "prod",
+		"STEADYBIT_EXTENSION_CLUSTER_CONTEXTS": "prod:prod-context",
+		"STEADYBIT_EXTENSION_NAMESPACES":       "shop",
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// When
+	PrepareClient(stopCh)
+
+	// Then
+	require.Len(t, K8S.Pods(), 1)
+	assert.Equal(t, "cart", K8S.Pods()[0].Name)
+}
+
+func TestWaitForPodConditionFailsForNamespaceNotWatched(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client := CreateClient(testclient.NewSimpleClientset(), stopCh, "", 0, []string{"shop"})
+
+	// When
+	err := client.WaitForPodCondition(context.Background(), "kube-system", "coredns", func(pod *corev1.Pod) bool {
+		return true
+	})
+
+	// Then
+	require.EqualError(t, err, "pods of namespace kube-system are not watched")
+}
+
+func TestMultiNamespaceIndexerIsReadOnly(t *testing.T) {
+	// Given
+	indexer := &multiNamespaceIndexer{}
+
+	// Then
+	require.ErrorIs(t, indexer.Add(namespacedPod("shop", "cart")), errReadOnlyIndexer)
+}
+
+func namespacedPod(namespace string, name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
@@ -86,6 +86,21 @@ func getResource(k8s *Client, kind string, namespace string, name string) (*Owne
 		if statefulset != nil {
 			return extutil.Ptr(OwnerReference{Name: statefulset.Name, Kind: strings.ToLower(kind)}), extutil.Ptr(statefulset.ObjectMeta), nil, nil
 		}
+	} else if strings.EqualFold("job", kind) {
+		job := k8s.JobByNamespaceAndName(namespace, name)
+		if job != nil {
+			return extutil.Ptr(OwnerReference{Name: job.Name, Kind: strings.ToLower(kind)}), extutil.Ptr(job.ObjectMeta), nil, nil
+		}
+	} else if strings.EqualFold("cronjob", kind) {
+		cronJob := k8s.CronJobByNamespaceAndName(namespace, name)
+		if cronJob != nil {
+			return extutil.Ptr(OwnerReference{Name: cronJob.Name, Kind: strings.ToLower(kind)}), extutil.Ptr(cronJob.ObjectMeta), nil, nil
+		}
 	}
 	return nil, nil, nil, nil
 }
+
+// IsStandalonePod returns true if the pod isn't managed by a controller and therefore won't be recreated once deleted.
+func IsStandalonePod(pod *corev1.Pod) bool {
+	return metav1.GetControllerOf(pod) == nil
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/tools/cache"
+	"strings"
+	"sync"
+)
+
+// UnmetReadinessGates returns the condition types of all readiness gates of the pod which aren't reported as true.
+func UnmetReadinessGates(pod *corev1.Pod) []string {
+	var unmet []string
+	for _, gate := range pod.Spec.ReadinessGates {
+		met := false
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == gate.ConditionType && condition.Status == corev1.ConditionTrue {
+				met = true
+			}
+		}
+		if !met {
+			unmet = append(unmet, string(gate.ConditionType))
+		}
+	}
+	return unmet
+}
+
+// ContainersReady returns the number of ready containers and the number of containers reporting a status,
+// i.e. the READY column of `kubectl get pods`.
+func ContainersReady(pod *corev1.Pod) (ready int, total int) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+	}
+	return ready, len(pod.Status.ContainerStatuses)
+}
+
+// IsPodReady reports whether the pod's Ready condition is true.
+func IsPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// GenerateNameBase returns the prefix the name of the pod was generated from, without the trailing dash, e.g.
+// "shop-7d9f8b6c5" for a pod of a replicaset. Empty for pods named explicitly.
+func GenerateNameBase(pod *corev1.Pod) string {
+	return strings.TrimSuffix(pod.GenerateName, "-")
+}
+
+// PodIPs returns the IPs of the pod, one per IP family in dual-stack clusters.
+func PodIPs(status corev1.PodStatus) []string {
+	ips := make([]string, 0, len(status.PodIPs))
+	for _, ip := range status.PodIPs {
+		ips = append(ips, ip.IP)
+	}
+	if len(ips) == 0 && status.PodIP != "" {
+		ips = append(ips, status.PodIP)
+	}
+	return ips
+}
+
+// HostIPs returns the IPs of the node the pod is running on, like PodIPs.
+func HostIPs(status corev1.PodStatus) []string {
+	ips := make([]string, 0, len(status.HostIPs))
+	for _, ip := range status.HostIPs {
+		ips = append(ips, ip.IP)
+	}
+	if len(ips) == 0 && status.HostIP != "" {
+		ips = append(ips, status.HostIP)
+	}
+	return ips
+}
+
+// IsEvicted reports whether the pod was evicted by the kubelet, e.g. due to node pressure.
+func IsEvicted(pod *corev1.Pod) bool {
+	if pod.Status.Reason == "Evicted" {
+		return true
+	}
+	if pod.Status.Phase != corev1.PodFailed {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Reason == corev1.PodReasonTerminationByKubelet {
+			return true
+		}
+	}
+	return false
+}
+
+// QOSClass returns the quality of service class of the pod. It is taken from the status, or derived from the cpu and
+// memory requests and limits of the containers like the kubelet does, if the status doesn't report it yet.
+func QOSClass(pod *corev1.Pod) corev1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	isGuaranteed := true
+	sum := func(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+		if existing, ok := list[name]; ok {
+			quantity.Add(existing)
+		}
+		list[name] = quantity
+	}
+	containers := append(append([]corev1.Container{}, pod.Spec.Containers...), pod.Spec.InitContainers...)
+	for _, container := range containers {
+		for name, quantity := range container.Resources.Requests {
+			if isQOSResource(name) && quantity.Sign() > 0 {
+				sum(requests, name, quantity)
+			}
+		}
+		limitsFound := 0
+		for name, quantity := range container.Resources.Limits {
+			if isQOSResource(name) && quantity.Sign() > 0 {
+				limitsFound++
+				sum(limits, name, quantity)
+			}
+		}
+		if limitsFound < 2 {
+			isGuaranteed = false
+		}
+	}
+
+	if len(requests) == 0 && len(limits) == 0 {
+		return corev1.PodQOSBestEffort
+	}
+	if isGuaranteed {
+		for name, request := range requests {
+			if limit, ok := limits[name]; !ok || limit.Cmp(request) != 0 {
+				isGuaranteed = false
+			}
+		}
+	}
+	if isGuaranteed && len(requests) == len(limits) {
+		return corev1.PodQOSGuaranteed
+	}
+	return corev1.PodQOSBurstable
+}
+
+func isQOSResource(name corev1.ResourceName) bool {
+	return name == corev1.ResourceCPU || name == corev1.ResourceMemory
+}
+
+// WaitForPodCondition blocks until the given pod satisfies the predicate or the context is done. Instead of polling,
+// it is notified by the pod informer whenever the pod is added or updated.
+func (c *Client) WaitForPodCondition(ctx context.Context, namespace string, name string, cond func(*corev1.Pod) bool) error {
+	satisfied := make(chan struct{})
+	var once sync.Once
+	check := func(obj interface{}) {
+		pod, ok := obj.(*corev1.Pod)
+		if ok && pod.Namespace == namespace && pod.Name == name && cond(pod) {
+			once.Do(func() { close(satisfied) })
+		}
+	}
+
+	informer := c.podsInformers.For(namespace)
+	if informer == nil {
+		return fmt.Errorf("pods of namespace %s are not watched", namespace)
+	}
+	// The informer replays all known pods to a new handler, so a pod that already satisfies the predicate resolves immediately.
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    check,
+		UpdateFunc: func(_, newObj interface{}) { check(newObj) },
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = informer.RemoveEventHandler(registration) }()
+
+	select {
+	case <-satisfied:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
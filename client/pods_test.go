@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func TestWaitForPodConditionResolvesOnUpdate(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "checkout",
+			Namespace: "shop",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+		},
+	}
+	_, err := clientset.CoreV1().Pods("shop").Create(context.Background(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client := CreateClient(clientset, stopCh, "", 0, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result := make(chan error, 1)
+	go func() {
+		result <- client.WaitForPodCondition(ctx, "shop", "checkout", func(pod *corev1.Pod) bool {
+			return pod.Status.Phase == corev1.PodRunning
+		})
+	}()
+
+	// When
+	running := pod.DeepCopy()
+	running.Status.Phase = corev1.PodRunning
+	_, err = clientset.CoreV1().Pods("shop").UpdateStatus(context.Background(), running, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// Then
+	require.NoError(t, <-result)
+}
+
+func TestWaitForPodConditionReturnsContextError(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client := CreateClient(clientset, stopCh, "", 0, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// When
+	err := client.WaitForPodCondition(ctx, "shop", "checkout", func(pod *corev1.Pod) bool {
+		return true
+	})
+
+	// Then
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQOSClassPrefersStatus(t *testing.T) {
+	pod := &corev1.Pod{Status: corev1.PodStatus{QOSClass: corev1.PodQOSGuaranteed}}
+
+	require.Equal(t, corev1.PodQOSGuaranteed, QOSClass(pod))
+}
+
+func TestQOSClassDerivedFromResources(t *testing.T) {
+	tests := []struct {
+		name       string
+		containers []corev1.Container
+		want       corev1.PodQOSClass
+	}{
+		{
+			name:       "without resources",
+			containers: []corev1.Container{{Name: "app"}},
+			want:       corev1.PodQOSBestEffort,
+		},
+		{
+			name: "requests equal limits",
+			containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0.5"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+			}}},
+			want: corev1.PodQOSGuaranteed,
+		},
+		{
+			name: "requests below limits",
+			containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+			}}},
+			want: corev1.PodQOSBurstable,
+		},
+		{
+			name: "memory limit only",
+			containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			}}},
+			want: corev1.PodQOSBurstable,
+		},
+		{
+			name: "one container without resources",
+			containers: []corev1.Container{
+				{Name: "app", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+				}},
+				{Name: "sidecar"},
+			},
+			want: corev1.PodQOSBurstable,
+		},
+		{
+			name: "only other resources",
+			containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1Gi")},
+			}}},
+			want: corev1.PodQOSBestEffort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: tt.containers}}
+
+			require.Equal(t, tt.want, QOSClass(pod))
+		})
+	}
+}
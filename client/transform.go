@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// lastAppliedConfigurationAnnotation holds a copy of the whole object if it was applied with kubectl.
+const lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// stripUnusedFields is the transform of all informers. It drops fields which are never read but dominate the memory of
+// the caches in large clusters. Labels, annotations, owner references, specs and status are kept, except for the images
+// of nodes.
+func stripUnusedFields(obj interface{}) (interface{}, error) {
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		// e.g. tombstones of deleted objects, which are passed on as they are.
+		return obj, nil
+	}
+	object.SetManagedFields(nil)
+	if annotations := object.GetAnnotations(); annotations != nil {
+		if _, ok := annotations[lastAppliedConfigurationAnnotation]; ok {
+			delete(annotations, lastAppliedConfigurationAnnotation)
+			object.SetAnnotations(annotations)
+		}
+	}
+	if node, ok := obj.(*corev1.Node); ok {
+		node.Status.Images = nil
+	}
+	return obj, nil
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"testing"
+)
+
+func TestStripUnusedFieldsKeepsFieldsInUse(t *testing.T) {
+	// Given
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "checkout",
+			Namespace: "shop",
+			Labels:    map[string]string{"app": "checkout"},
+			Annotations: map[string]string{
+				lastAppliedConfigurationAnnotation: `{"apiVersion":"apps/v1","kind":"Deployment"}`,
+				"argocd.argoproj.io/tracking-id":   "shop:apps/Deployment:shop/checkout",
+			},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Rollout", Name: "checkout"}},
+			ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: extutil.Ptr(int32(3)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+	}
+
+	// When
+	result, err := stripUnusedFields(deployment)
+
+	// Then
+	require.NoError(t, err)
+	stripped := result.(*appsv1.Deployment)
+	assert.Nil(t, stripped.ManagedFields)
+	assert.NotContains(t, stripped.Annotations, lastAppliedConfigurationAnnotation)
+	assert.Equal(t, "shop:apps/Deployment:shop/checkout", stripped.Annotations["argocd.argoproj.io/tracking-id"])
+	assert.Equal(t, map[string]string{"app": "checkout"}, stripped.Labels)
+	assert.Len(t, stripped.OwnerReferences, 1)
+	assert.Equal(t, int32(3), *stripped.Spec.Replicas)
+	assert.Equal(t, map[string]string{"app": "checkout"}, stripped.Spec.Selector.MatchLabels)
+	assert.Equal(t, int32(2), stripped.Status.ReadyReplicas)
+}
+
+func TestStripUnusedFieldsDropsNodeImages(t *testing.T) {
+	// Given
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			Images:     []corev1.ContainerImage{{Names: []string{"nginx:1.25"}, SizeBytes: 1024}},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	// When
+	result, err := stripUnusedFields(node)
+
+	// Then
+	require.NoError(t, err)
+	stripped := result.(*corev1.Node)
+	assert.Nil(t, stripped.Status.Images)
+	assert.Len(t, stripped.Status.Conditions, 1)
+}
+
+func TestStripUnusedFieldsPassesOnTombstones(t *testing.T) {
+	// Given
+	tombstone := cache.DeletedFinalStateUnknown{Key: "shop/checkout"}
+
+	// When
+	result, err := stripUnusedFields(tombstone)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, tombstone, result)
+}
+
+func TestCreateClientCachesStrippedObjects(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "cart",
+			Namespace:     "shop",
+			Annotations:   map[string]string{lastAppliedConfigurationAnnotation: "{}"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// When
+	client := CreateClient(clientset, stopCh, "", 0, nil)
+
+	// Then
+	pod := client.PodByNamespaceAndName("shop", "cart")
+	require.NotNil(t, pod)
+	assert.Nil(t, pod.ManagedFields)
+	assert.Empty(t, pod.Annotations)
+}
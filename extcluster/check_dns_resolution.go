@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcluster
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// dnsProbeStartupTimeout is how long the probe pod may take to be running, e.g. to pull the image. Until then DNS
+// isn't tested at all.
+const dnsProbeStartupTimeout = 30 * time.Second
+
+type DnsResolutionCheckAction struct {
+}
+
+type DnsResolutionCheckState struct {
+	Cluster         string
+	Timeout         time.Time
+	StartupDeadline time.Time
+	Namespace       string
+	PodName         string
+	Hostname        string
+	Image           string
+}
+
+type DnsResolutionCheckConfig struct {
+	Duration  int
+	Hostname  string
+	Namespace string
+	Image     string
+}
+
+func NewDnsResolutionCheckAction() action_kit_sdk.Action[DnsResolutionCheckState] {
+	return DnsResolutionCheckAction{}
+}
+
+var _ action_kit_sdk.Action[DnsResolutionCheckState] = (*DnsResolutionCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[DnsResolutionCheckState] = (*DnsResolutionCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStop[DnsResolutionCheckState] = (*DnsResolutionCheckAction)(nil)
+
+func (f DnsResolutionCheckAction) NewEmptyState() DnsResolutionCheckState {
+	return DnsResolutionCheckState{}
+}
+
+func (f DnsResolutionCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          dnsResolutionCheckActionId,
+		Label:       "DNS Resolution",
+		Description: "Verify that a name can be resolved from within the cluster. A short-lived probe pod continuously resolves the name and the check fails as soon as a lookup fails.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(clusterIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the name be resolved."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "hostname",
+				Label:        "Hostname",
+				Description:  extutil.Ptr("The name to resolve."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr("kubernetes.default.svc.cluster.local"),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "namespace",
+				Label:        "Namespace",
+				Description:  extutil.Ptr("The namespace to start the probe pod in."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr("default"),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(true),
+				Advanced:     extutil.Ptr(true),
+			},
+			{
+				Name:         "image",
+				Label:        "Image",
+				Description:  extutil.Ptr("The image of the probe pod. It needs to provide `sh` and `nslookup`."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr("busybox:1.36"),
+				Order:        extutil.Ptr(4),
+				Required:     extutil.Ptr(true),
+				Advanced:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f DnsResolutionCheckAction) Prepare(_ context.Context, state *DnsResolutionCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config DnsResolutionCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = config.Namespace
+	state.PodName = fmt.Sprintf("steadybit-dns-check-%s", request.ExecutionId.String()[:8])
+	state.Hostname = config.Hostname
+	state.Image = config.Image
+	return nil, nil
+}
+
+func (f DnsResolutionCheckAction) Start(ctx context.Context, state *DnsResolutionCheckState) (*action_kit_api.StartResult, error) {
+	return startDnsResolutionCheckInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func startDnsResolutionCheckInternal(ctx context.Context, k8s *client.Client, state *DnsResolutionCheckState) (*action_kit_api.StartResult, error) {
+	_, err := k8s.Clientset().CoreV1().Pods(state.Namespace).Create(ctx, dnsProbePod(state), metav1.CreateOptions{})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create DNS probe pod %s/%s.", state.Namespace, state.PodName), err)
+	}
+	state.StartupDeadline = time.Now().Add(dnsProbeStartupTimeout)
+	return nil, nil
+}
+
+func dnsProbePod(state *DnsResolutionCheckState) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      state.PodName,
+			Namespace: state.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by":     "steadybit-extension-kubernetes",
+				"steadybit.com/discovery-disabled": "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			AutomountServiceAccountToken:  extutil.Ptr(false),
+			TerminationGracePeriodSeconds: extutil.Ptr(int64(0)),
+			Containers: []corev1.Container{
+				{
+					Name:    "dns-probe",
+					Image:   state.Image,
+					Command: []string{"sh", "-c", "while nslookup \"$DNS_NAME\" > /dev/null; do sleep 1; done; exit 1"},
+					Env: []corev1.EnvVar{
+						{Name: "DNS_NAME", Value: state.Hostname},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (f DnsResolutionCheckAction) Status(ctx context.Context, state *DnsResolutionCheckState) (*action_kit_api.StatusResult, error) {
+	return statusDnsResolutionCheckInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+// statusDnsResolutionCheckInternal reads the probe pod from the API server, as its namespace isn't necessarily watched.
+// The check only passes if the pod is running, otherwise DNS isn't tested.
+func statusDnsResolutionCheckInternal(ctx context.Context, k8s *client.Client, state *DnsResolutionCheckState) (*action_kit_api.StatusResult, error) {
+	pod, err := k8s.Clientset().CoreV1().Pods(state.Namespace).Get(ctx, state.PodName, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		return dnsResolutionCheckFailed(fmt.Sprintf("DNS probe pod %s/%s is gone.", state.Namespace, state.PodName)), nil
+	} else if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get DNS probe pod %s/%s.", state.Namespace, state.PodName), err)
+	}
+
+	switch {
+	case pod.Status.Phase == corev1.PodFailed:
+		return dnsResolutionCheckFailed(fmt.Sprintf("Failed to resolve %s.", state.Hostname)), nil
+	case pod.Status.Phase != corev1.PodRunning && time.Now().After(state.StartupDeadline):
+		return dnsResolutionCheckFailed(fmt.Sprintf("DNS probe pod %s/%s is not running within %s, but %s.", state.Namespace, state.PodName, dnsProbeStartupTimeout, pod.Status.Phase)), nil
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.Timeout),
+	}, nil
+}
+
+func dnsResolutionCheckFailed(title string) *action_kit_api.StatusResult {
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Error: extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  title,
+			Status: extutil.Ptr(action_kit_api.Failed),
+		}),
+	}
+}
+
+func (f DnsResolutionCheckAction) Stop(ctx context.Context, state *DnsResolutionCheckState) (*action_kit_api.StopResult, error) {
+	return stopDnsResolutionCheckInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func stopDnsResolutionCheckInternal(ctx context.Context, k8s *client.Client, state *DnsResolutionCheckState) (*action_kit_api.StopResult, error) {
+	err := k8s.Clientset().CoreV1().Pods(state.Namespace).Delete(ctx, state.PodName, metav1.DeleteOptions{})
+	if k8sErrors.IsNotFound(err) {
+		log.Debug().Msgf("DNS probe pod %s/%s already deleted.", state.Namespace, state.PodName)
+	} else if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to delete DNS probe pod %s/%s.", state.Namespace, state.PodName), err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcluster
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func TestDnsResolutionCheckPrepareExtractsState(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.MustParse("b8e9e3ad-3f6c-4a6d-8d58-08b3c1f4d4a1"),
+		Config: map[string]interface{}{
+			"duration":  1000 * 10,
+			"hostname":  "shop.default.svc.cluster.local",
+			"namespace": "steadybit-extension",
+			"image":     "busybox:1.36",
+		},
+	}
+	action := NewDnsResolutionCheckAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, state.Timeout.After(time.Now()))
+	require.Equal(t, "steadybit-dns-check-b8e9e3ad", state.PodName)
+	require.Equal(t, "steadybit-extension", state.Namespace)
+	require.Equal(t, "shop.default.svc.cluster.local", state.Hostname)
+}
+
+func TestDnsResolutionCheckCreatesProbePod(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
+	state := createDnsResolutionCheckState()
+
+	// When
+	_, err := startDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	pod, err := clientset.CoreV1().Pods("default").Get(context.Background(), "steadybit-dns-check-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	assert.Equal(t, "true", pod.Labels["steadybit.com/discovery-disabled"])
+	require.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, "busybox:1.36", pod.Spec.Containers[0].Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "DNS_NAME", Value: "shop.default.svc.cluster.local"}}, pod.Spec.Containers[0].Env)
+}
+
+func TestDnsResolutionCheckFailsWhenProbePodFails(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
+	state := createDnsResolutionCheckState()
+	_, err := startDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+	result, err := statusDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+	require.False(t, result.Completed)
+
+	// When
+	pod := dnsProbePod(&state)
+	pod.Status.Phase = corev1.PodFailed
+	_, err = clientset.CoreV1().Pods("default").UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// Then
+	result, err = statusDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Equal(t, "Failed to resolve shop.default.svc.cluster.local.", result.Error.Title)
+}
+
+func TestDnsResolutionCheckPassesWhileProbePodIsRunning(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
+	state := createDnsResolutionCheckState()
+	state.Timeout = time.Now().Add(-time.Second)
+	pod := dnsProbePod(&state)
+	pod.Status.Phase = corev1.PodRunning
+	_, err := clientset.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	result, err := statusDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestDnsResolutionCheckFailsWhenProbePodIsMissing(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(testclient.NewSimpleClientset(), stopCh, "", 0, nil)
+	state := createDnsResolutionCheckState()
+
+	// When
+	result, err := statusDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Equal(t, "DNS probe pod default/steadybit-dns-check-1 is gone.", result.Error.Title)
+}
+
+func TestDnsResolutionCheckFailsWhenProbePodStaysPending(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
+	state := createDnsResolutionCheckState()
+	pod := dnsProbePod(&state)
+	pod.Status.Phase = corev1.PodPending
+	_, err := clientset.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	state.StartupDeadline = time.Now().Add(time.Minute)
+	pending, err := statusDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+	state.StartupDeadline = time.Now().Add(-time.Second)
+	result, err := statusDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.False(t, pending.Completed)
+	require.True(t, result.Completed)
+	require.Equal(t, "DNS probe pod default/steadybit-dns-check-1 is not running within 30s, but Pending.", result.Error.Title)
+}
+
+func TestDnsResolutionCheckDeletesProbePodOnStop(t *testing.T) {
+	// Given
+	clientset := testclient.NewSimpleClientset()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
+	state := createDnsResolutionCheckState()
+	_, err := startDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+
+	// When
+	_, err = stopDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	pods, err := clientset.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, pods.Items)
+
+	_, err = stopDnsResolutionCheckInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+}
+
+func createDnsResolutionCheckState() DnsResolutionCheckState {
+	return DnsResolutionCheckState{
+		Timeout:   time.Now().Add(time.Minute),
+		Namespace: "default",
+		PodName:   "steadybit-dns-check-1",
+		Hostname:  "shop.default.svc.cluster.local",
+		Image:     "busybox:1.36",
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/exthttp"
 	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	"net/http"
 )
@@ -52,19 +54,23 @@ func getClusterTargetDescription() discovery_kit_api.TargetDescription {
 }
 
 func getDiscoveredCluster(w http.ResponseWriter, _ *http.Request, _ []byte) {
-	targets := getDiscoveredClusterTargets()
+	targets := extcommon.DiscoverAllClusters(getDiscoveredClusterTargets)
 	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
 }
 
-func getDiscoveredClusterTargets() []discovery_kit_api.Target {
+func getDiscoveredClusterTargets(k8s *client.Client) []discovery_kit_api.Target {
+	attributes := map[string][]string{
+		"k8s.cluster-name": {k8s.ClusterName()},
+	}
+	extcommon.ApplyAttributeAliases(attributes)
+	extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesCluster)
+
 	return []discovery_kit_api.Target{
 		{
-			Id:         extconfig.Config.ClusterName,
-			Label:      extconfig.Config.ClusterName,
+			Id:         k8s.ClusterName(),
+			Label:      k8s.ClusterName(),
 			TargetType: ClusterTargetType,
-			Attributes: map[string][]string{
-				"k8s.cluster-name": {extconfig.Config.ClusterName},
-			},
+			Attributes: attributes,
 		},
 	}
 }
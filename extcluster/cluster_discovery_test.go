@@ -4,7 +4,10 @@
 package extcluster
 
 import (
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -15,7 +18,7 @@ func Test_getDiscoveredCluster(t *testing.T) {
 	extconfig.Config.ClusterName = "dev-cluster"
 
 	//Then
-	targets := getDiscoveredClusterTargets()
+	targets := getDiscoveredClusterTargets(testsupport.NewClientBuilder(t).Build())
 	require.Len(t, targets, 1)
 	target := targets[0]
 	assert.Equal(t, "dev-cluster", target.Id)
@@ -25,3 +28,20 @@ func Test_getDiscoveredCluster(t *testing.T) {
 		"k8s.cluster-name": {"dev-cluster"},
 	}, target.Attributes)
 }
+
+func Test_getDiscoveredClusterForEveryClusterContext(t *testing.T) {
+	// Given
+	defer client.ResetClusterClients()
+	client.RegisterClusterClient("staging", testsupport.NewClientBuilder(t).Build())
+	client.RegisterClusterClient("prod", testsupport.NewClientBuilder(t).Build())
+
+	// When
+	targets := extcommon.DiscoverAllClusters(getDiscoveredClusterTargets)
+
+	// Then
+	require.Len(t, targets, 2)
+	assert.Equal(t, "prod", targets[0].Id)
+	assert.Equal(t, []string{"prod"}, targets[0].Attributes["k8s.cluster-name"])
+	assert.Equal(t, "staging", targets[1].Id)
+	assert.Equal(t, []string{"staging"}, targets[1].Attributes["k8s.cluster-name"])
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"sync"
+)
+
+// attackSlots limits how many attacks are active at the same time to STEADYBIT_EXTENSION_MAX_CONCURRENT_ATTACKS. An
+// attack is active from its start until its stop, or until its status completes if it has no stop.
+var attackSlots = &slots{}
+
+type slots struct {
+	mu     sync.Mutex
+	active int
+}
+
+// acquire takes a slot and returns false if all slots are taken. A limit of 0 disables the limit.
+func (s *slots) acquire() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if limit := extconfig.Config.MaxConcurrentAttacks; limit > 0 && s.active >= limit {
+		return false
+	}
+	s.active++
+	return true
+}
+
+func (s *slots) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active > 0 {
+		s.active--
+	}
+}
+
+// LimitedAttackState wraps the state of an attack, so that only attacks holding a slot give it back.
+type LimitedAttackState[T any] struct {
+	Attack    T
+	HoldsSlot bool
+}
+
+type limitedAttack[T any] struct {
+	attack action_kit_sdk.Action[T]
+}
+
+type limitedAttackWithStatus[T any] struct {
+	limitedAttack[T]
+}
+
+type limitedAttackWithStop[T any] struct {
+	limitedAttack[T]
+}
+
+type limitedAttackWithStatusAndStop[T any] struct {
+	limitedAttack[T]
+}
+
+var _ action_kit_sdk.ActionWithStatus[LimitedAttackState[struct{}]] = (*limitedAttackWithStatus[struct{}])(nil)
+var _ action_kit_sdk.ActionWithStop[LimitedAttackState[struct{}]] = (*limitedAttackWithStop[struct{}])(nil)
+var _ action_kit_sdk.ActionWithStatus[LimitedAttackState[struct{}]] = (*limitedAttackWithStatusAndStop[struct{}])(nil)
+var _ action_kit_sdk.ActionWithStop[LimitedAttackState[struct{}]] = (*limitedAttackWithStatusAndStop[struct{}])(nil)
+
+// limitConcurrency wraps the attack, keeping the optional status and stop of the attack.
+func limitConcurrency[T any](attack action_kit_sdk.Action[T]) action_kit_sdk.Action[LimitedAttackState[T]] {
+	limited := limitedAttack[T]{attack: attack}
+	_, hasStatus := attack.(action_kit_sdk.ActionWithStatus[T])
+	_, hasStop := attack.(action_kit_sdk.ActionWithStop[T])
+	switch {
+	case hasStatus && hasStop:
+		return limitedAttackWithStatusAndStop[T]{limited}
+	case hasStatus:
+		return limitedAttackWithStatus[T]{limited}
+	case hasStop:
+		return limitedAttackWithStop[T]{limited}
+	default:
+		return limited
+	}
+}
+
+func (a limitedAttack[T]) NewEmptyState() LimitedAttackState[T] {
+	return LimitedAttackState[T]{Attack: a.attack.NewEmptyState()}
+}
+
+func (a limitedAttack[T]) Describe() action_kit_api.ActionDescription {
+	return a.attack.Describe()
+}
+
+func (a limitedAttack[T]) Prepare(ctx context.Context, state *LimitedAttackState[T], request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return a.attack.Prepare(ctx, &state.Attack, request)
+}
+
+func (a limitedAttack[T]) Start(ctx context.Context, state *LimitedAttackState[T]) (*action_kit_api.StartResult, error) {
+	if !attackSlots.acquire() {
+		return nil, extension_kit.ToError(fmt.Sprintf("Too many concurrent attacks, at most %d attacks may run at the same time.", extconfig.Config.MaxConcurrentAttacks), nil)
+	}
+	state.HoldsSlot = true
+
+	result, err := a.attack.Start(ctx, &state.Attack)
+	_, hasStatus := a.attack.(action_kit_sdk.ActionWithStatus[T])
+	_, hasStop := a.attack.(action_kit_sdk.ActionWithStop[T])
+	if err != nil || (!hasStatus && !hasStop) {
+		release(state)
+	}
+	return result, err
+}
+
+func (a limitedAttackWithStatus[T]) Status(ctx context.Context, state *LimitedAttackState[T]) (*action_kit_api.StatusResult, error) {
+	result, err := a.attack.(action_kit_sdk.ActionWithStatus[T]).Status(ctx, &state.Attack)
+	if err != nil || (result != nil && result.Completed) {
+		release(state)
+	}
+	return result, err
+}
+
+func (a limitedAttackWithStop[T]) Stop(ctx context.Context, state *LimitedAttackState[T]) (*action_kit_api.StopResult, error) {
+	defer release(state)
+	return a.attack.(action_kit_sdk.ActionWithStop[T]).Stop(ctx, &state.Attack)
+}
+
+// With a stop the slot is held until the stop, regardless of the status.
+func (a limitedAttackWithStatusAndStop[T]) Status(ctx context.Context, state *LimitedAttackState[T]) (*action_kit_api.StatusResult, error) {
+	return a.attack.(action_kit_sdk.ActionWithStatus[T]).Status(ctx, &state.Attack)
+}
+
+func (a limitedAttackWithStatusAndStop[T]) Stop(ctx context.Context, state *LimitedAttackState[T]) (*action_kit_api.StopResult, error) {
+	defer release(state)
+	return a.attack.(action_kit_sdk.ActionWithStop[T]).Stop(ctx, &state.Attack)
+}
+
+func release[T any](state *LimitedAttackState[T]) {
+	if !state.HoldsSlot {
+		return
+	}
+	attackSlots.release()
+	state.HoldsSlot = false
+	log.Debug().Msg("Released attack slot")
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+type testAttack struct {
+	startErr error
+}
+
+func (a testAttack) NewEmptyState() struct{} {
+	return struct{}{}
+}
+
+func (a testAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:      "com.steadybit.extension_kubernetes.test_attack",
+		Kind:    action_kit_api.Attack,
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    &action_kit_api.MutatingEndpointReference{},
+	}
+}
+
+func (a testAttack) Prepare(_ context.Context, _ *struct{}, _ action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return nil, nil
+}
+
+func (a testAttack) Start(_ context.Context, _ *struct{}) (*action_kit_api.StartResult, error) {
+	return nil, a.startErr
+}
+
+func (a testAttack) Stop(_ context.Context, _ *struct{}) (*action_kit_api.StopResult, error) {
+	return nil, nil
+}
+
+func TestLimitConcurrencyRejectsAttacksExceedingTheLimit(t *testing.T) {
+	// Given
+	extconfig.Config.MaxConcurrentAttacks = 2
+	defer func() { extconfig.Config.MaxConcurrentAttacks = 0 }()
+	attack := limitConcurrency[struct{}](testAttack{}).(action_kit_sdk.ActionWithStop[LimitedAttackState[struct{}]])
+	states := make([]LimitedAttackState[struct{}], 10)
+
+	// When
+	var wg sync.WaitGroup
+	errs := make([]error, len(states))
+	for i := range states {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = attack.Start(context.Background(), &states[i])
+		}(i)
+	}
+	wg.Wait()
+
+	// Then
+	started := 0
+	for i, err := range errs {
+		if err == nil {
+			started++
+			assert.True(t, states[i].HoldsSlot)
+		} else {
+			assert.EqualError(t, err, "Too many concurrent attacks, at most 2 attacks may run at the same time.")
+			assert.False(t, states[i].HoldsSlot)
+		}
+	}
+	assert.Equal(t, 2, started)
+
+	// When
+	for i := range states {
+		_, err := attack.Stop(context.Background(), &states[i])
+		require.NoError(t, err)
+	}
+
+	// Then
+	for i := range states {
+		_, err := attack.Start(context.Background(), &states[i])
+		if i < 2 {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+	for i := range states {
+		_, _ = attack.Stop(context.Background(), &states[i])
+	}
+	assert.Equal(t, 0, attackSlots.active)
+}
+
+func TestLimitConcurrencyReleasesSlotIfStartFails(t *testing.T) {
+	// Given
+	extconfig.Config.MaxConcurrentAttacks = 1
+	defer func() { extconfig.Config.MaxConcurrentAttacks = 0 }()
+	attack := limitConcurrency[struct{}](testAttack{startErr: errors.New("boom")})
+	state := attack.NewEmptyState()
+
+	// When
+	_, err := attack.Start(context.Background(), &state)
+
+	// Then
+	require.EqualError(t, err, "boom")
+	assert.False(t, state.HoldsSlot)
+	assert.Equal(t, 0, attackSlots.active)
+}
+
+func TestLimitConcurrencyKeepsStatusAndStop(t *testing.T) {
+	// When
+	limited := limitConcurrency[struct{}](testAttack{})
+	limitedCheck := limitConcurrency[struct{}](testAction{})
+
+	// Then
+	_, hasStop := limited.(action_kit_sdk.ActionWithStop[LimitedAttackState[struct{}]])
+	_, hasStatus := limited.(action_kit_sdk.ActionWithStatus[LimitedAttackState[struct{}]])
+	assert.True(t, hasStop)
+	assert.False(t, hasStatus)
+	_, hasStop = limitedCheck.(action_kit_sdk.ActionWithStop[LimitedAttackState[struct{}]])
+	assert.False(t, hasStop)
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+)
+
+// ApplyAttributeAliases copies attributes to the alias names configured via extconfig.Config.AttributeAliases.
+// The original attributes are kept, as actions and enrichment rules rely on them. If an alias collides with an
+// already present attribute, the present attribute is kept.
+func ApplyAttributeAliases(attributes map[string][]string) {
+	for original, alias := range extconfig.Config.AttributeAliases {
+		value, ok := attributes[original]
+		if !ok {
+			continue
+		}
+		if _, exists := attributes[alias]; exists {
+			log.Warn().Msgf("Cannot alias attribute %s to %s, because %s is already present.", original, alias, alias)
+			continue
+		}
+		attributes[alias] = value
+	}
+}
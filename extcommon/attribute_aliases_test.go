@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestApplyAttributeAliases(t *testing.T) {
+	// Given
+	extconfig.Config.AttributeAliases = map[string]string{
+		"k8s.namespace":  "team.namespace",
+		"k8s.deployment": "k8s.pod.name",
+		"k8s.daemonset":  "team.daemonset",
+	}
+	defer func() { extconfig.Config.AttributeAliases = nil }()
+	attributes := map[string][]string{
+		"k8s.namespace":  {"shop"},
+		"k8s.deployment": {"checkout"},
+		"k8s.pod.name":   {"checkout-1"},
+	}
+
+	// When
+	ApplyAttributeAliases(attributes)
+
+	// Then
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":  {"shop"},
+		"team.namespace": {"shop"},
+		"k8s.deployment": {"checkout"},
+		"k8s.pod.name":   {"checkout-1"},
+	}, attributes)
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/extension-kubernetes/extconfig"
+)
+
+// ExcludeAttributes drops the attributes matching the excludes, e.g. configured via
+// extconfig.Config.DiscoveryAttributesExcludesContainer, to reduce the size of discovery responses. Excludes ending
+// with `*` drop all attributes starting with the rest, e.g. `k8s.container.image*`. Aliases are excluded by their own
+// name only.
+func ExcludeAttributes(attributes map[string][]string, excludes []string) {
+	if len(excludes) == 0 {
+		return
+	}
+	for key := range attributes {
+		if extconfig.MatchesAnyPattern(excludes, key) {
+			delete(attributes, key)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExcludeAttributes(t *testing.T) {
+	// Given
+	attributes := map[string][]string{
+		"k8s.container.name":       {"nginx"},
+		"k8s.container.image":      {"nginx:1.25"},
+		"k8s.container.image.name": {"nginx"},
+		"k8s.pod.label.app":        {"shop"},
+		"k8s.pod.label.team":       {"checkout"},
+	}
+
+	// When
+	ExcludeAttributes(attributes, []string{"k8s.container.image", "k8s.pod.label.*"})
+
+	// Then
+	assert.Equal(t, map[string][]string{
+		"k8s.container.name":       {"nginx"},
+		"k8s.container.image.name": {"nginx"},
+	}, attributes)
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"strconv"
+)
+
+// Attributes assembles the attributes of a discovered target or enrichment data. It can be passed wherever a
+// map[string][]string is expected.
+type Attributes map[string][]string
+
+func NewAttributes() Attributes {
+	return Attributes{}
+}
+
+// Set replaces the values of the key with the single value.
+func (a Attributes) Set(key string, value string) {
+	a[key] = []string{value}
+}
+
+// SetAll replaces the values of the key. The key is removed if there are no values, as empty attributes are not
+// emitted.
+func (a Attributes) SetAll(key string, values []string) {
+	if len(values) == 0 {
+		delete(a, key)
+		return
+	}
+	a[key] = values
+}
+
+// Add appends the value to the values of the key, unless it is empty or already present.
+func (a Attributes) Add(key string, value string) {
+	if value == "" {
+		return
+	}
+	for _, existing := range a[key] {
+		if existing == value {
+			return
+		}
+	}
+	a[key] = append(a[key], value)
+}
+
+// SetBool sets the value as "true" or "false".
+func (a Attributes) SetBool(key string, value bool) {
+	a.Set(key, strconv.FormatBool(value))
+}
+
+// SetInt sets the value in decimal notation.
+func (a Attributes) SetInt(key string, value int64) {
+	a.Set(key, strconv.FormatInt(value, 10))
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAttributesSetReplacesValues(t *testing.T) {
+	// Given
+	attributes := NewAttributes()
+	attributes.Add("k8s.pod.ip", "10.0.0.1")
+	attributes.Add("k8s.pod.ip", "10.0.0.2")
+
+	// When
+	attributes.Set("k8s.pod.ip", "10.0.0.3")
+
+	// Then
+	assert.Equal(t, []string{"10.0.0.3"}, attributes["k8s.pod.ip"])
+}
+
+func TestAttributesAddKeepsDistinctNonEmptyValues(t *testing.T) {
+	// Given
+	attributes := NewAttributes()
+
+	// When
+	attributes.Add("k8s.service.name", "checkout")
+	attributes.Add("k8s.service.name", "")
+	attributes.Add("k8s.service.name", "frontend")
+	attributes.Add("k8s.service.name", "checkout")
+	attributes.Add("k8s.empty", "")
+
+	// Then
+	assert.Equal(t, Attributes{"k8s.service.name": {"checkout", "frontend"}}, attributes)
+}
+
+func TestAttributesSetAllRemovesKeyWithoutValues(t *testing.T) {
+	// Given
+	attributes := NewAttributes()
+	attributes.Set("k8s.pod.ip", "10.0.0.1")
+
+	// When
+	attributes.SetAll("k8s.pod.ip", nil)
+	attributes.SetAll("k8s.pod.host-ip", []string{"192.168.0.1", "fd00::1"})
+
+	// Then
+	assert.Equal(t, Attributes{"k8s.pod.host-ip": {"192.168.0.1", "fd00::1"}}, attributes)
+}
+
+func TestAttributesTypedSetters(t *testing.T) {
+	// Given
+	attributes := NewAttributes()
+
+	// When
+	attributes.SetBool("k8s.container.ready", false)
+	attributes.SetBool("k8s.pod.standalone", true)
+	attributes.SetInt("k8s.deployment.replicas", 3)
+	attributes.SetInt("k8s.container.run-as-user", -1)
+
+	// Then
+	assert.Equal(t, Attributes{
+		"k8s.container.ready":       {"false"},
+		"k8s.pod.standalone":        {"true"},
+		"k8s.deployment.replicas":   {"3"},
+		"k8s.container.run-as-user": {"-1"},
+	}, attributes)
+}
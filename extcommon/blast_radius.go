@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+)
+
+const MaxBlastRadiusPercentParameterName = "maxBlastRadiusPercent"
+
+// MaxBlastRadiusPercentParameter lets users override extconfig.Config.MaxBlastRadiusPercent for a single action.
+func MaxBlastRadiusPercentParameter(order int) action_kit_api.ActionParameter {
+	return action_kit_api.ActionParameter{
+		Name:        MaxBlastRadiusPercentParameterName,
+		Label:       "Max. blast radius (%)",
+		Description: extutil.Ptr("Abort the attack if it would affect more than this percentage of the relevant population. Defaults to the extension configuration."),
+		Type:        action_kit_api.Percentage,
+		Advanced:    extutil.Ptr(true),
+		Order:       extutil.Ptr(order),
+		Required:    extutil.Ptr(false),
+	}
+}
+
+// CheckBlastRadius returns an error if affecting `affected` out of `population` resources exceeds the allowed
+// percentage. The allowed percentage is taken from maxPercent if set, from extconfig.Config.MaxBlastRadiusPercent otherwise.
+func CheckBlastRadius(resource string, affected int, population int, maxPercent *int) error {
+	limit := extconfig.Config.MaxBlastRadiusPercent
+	if maxPercent != nil {
+		limit = *maxPercent
+	}
+	if affected == 0 {
+		return nil
+	}
+
+	percent := 100.0
+	if population > 0 {
+		percent = float64(affected) * 100 / float64(population)
+	}
+	if percent > float64(limit) {
+		return extension_kit.ToError(fmt.Sprintf("Attack would affect %d of %d %s (%.0f%%), which exceeds the max. blast radius of %d%%.", affected, population, resource, percent, limit), nil)
+	}
+	return nil
+}
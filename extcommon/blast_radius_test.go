@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCheckBlastRadiusWithinLimit(t *testing.T) {
+	extconfig.Config.MaxBlastRadiusPercent = 50
+
+	require.NoError(t, CheckBlastRadius("pods", 2, 4, nil))
+	require.NoError(t, CheckBlastRadius("pods", 0, 0, nil))
+}
+
+func TestCheckBlastRadiusExceedingLimit(t *testing.T) {
+	extconfig.Config.MaxBlastRadiusPercent = 50
+
+	err := CheckBlastRadius("nodes", 3, 4, nil)
+
+	require.EqualError(t, err, "Attack would affect 3 of 4 nodes (75%), which exceeds the max. blast radius of 50%.")
+}
+
+func TestCheckBlastRadiusParameterOverridesConfig(t *testing.T) {
+	extconfig.Config.MaxBlastRadiusPercent = 50
+
+	require.NoError(t, CheckBlastRadius("nodes", 3, 4, extutil.Ptr(80)))
+	require.Error(t, CheckBlastRadius("nodes", 1, 4, extutil.Ptr(20)))
+}
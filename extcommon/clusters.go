@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kubernetes/client"
+)
+
+// DiscoverAllClusters runs the discovery against the client of every configured cluster and concatenates the results.
+func DiscoverAllClusters[T any](discover func(k8s *client.Client) []T) []T {
+	result := make([]T, 0)
+	for _, k8s := range client.Clusters() {
+		result = append(result, discover(k8s)...)
+	}
+	return result
+}
+
+// TargetCluster stores the k8s.cluster-name of the target in cluster, so that the action can talk to the cluster the
+// target was discovered in through client.ForCluster.
+func TargetCluster(target *action_kit_api.Target, cluster *string) error {
+	if target != nil {
+		if names := target.Attributes["k8s.cluster-name"]; len(names) > 0 {
+			*cluster = names[0]
+		}
+	}
+	if !client.HasCluster(*cluster) {
+		return extension_kit.ToError(fmt.Sprintf("Cluster %s is not known to the extension.", *cluster), nil)
+	}
+	return nil
+}
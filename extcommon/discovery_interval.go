@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+)
+
+// DiscoveryCallInterval returns the interval in which the agent should call a discovery. It is taken from the
+// configuration and grows while the Kubernetes API reports errors, to not put additional load on a struggling API server.
+func DiscoveryCallInterval(k8s *client.Client) *string {
+	return extutil.Ptr(fmt.Sprintf("%ds", int(k8s.DiscoveryInterval().Seconds())))
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/extension-kubernetes/client"
+	corev1 "k8s.io/api/core/v1"
+	"strconv"
+)
+
+// EndpointMembership reports which services route traffic to a pod. The endpoint slices of a namespace are resolved
+// once per discovery run.
+type EndpointMembership struct {
+	k8s         *client.Client
+	byNamespace map[string]map[string][]string
+}
+
+func NewEndpointMembership(k8s *client.Client) *EndpointMembership {
+	return &EndpointMembership{k8s: k8s, byNamespace: map[string]map[string][]string{}}
+}
+
+// AddAttributes adds whether the pod is a ready endpoint of any service as k8s.pod.in-endpoints and the names of
+// these services as k8s.pod.services-endpoints.
+func (m *EndpointMembership) AddAttributes(pod *corev1.Pod, attributes map[string][]string) {
+	servicesByPod, ok := m.byNamespace[pod.Namespace]
+	if !ok {
+		servicesByPod = m.k8s.ReadyEndpointServicesByPod(pod.Namespace)
+		m.byNamespace[pod.Namespace] = servicesByPod
+	}
+	services := servicesByPod[pod.Name]
+	attributes["k8s.pod.in-endpoints"] = []string{strconv.FormatBool(len(services) > 0)}
+	if len(services) > 0 {
+		attributes["k8s.pod.services-endpoints"] = services
+	}
+}
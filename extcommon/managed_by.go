@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// gitOpsMarkers are labels and annotations set by GitOps controllers on the objects they reconcile.
+var gitOpsMarkers = []struct {
+	key     string
+	manager string
+}{
+	{"argocd.argoproj.io/tracking-id", "argocd"},
+	{"argocd.argoproj.io/instance", "argocd"},
+	{"kustomize.toolkit.fluxcd.io/name", "flux"},
+	{"helm.toolkit.fluxcd.io/name", "flux"},
+}
+
+// GitOpsManager returns the GitOps controller reconciling the object, which is going to revert changes made by
+// attacks, or an empty string.
+func GitOpsManager(objectMeta metav1.ObjectMeta) string {
+	for _, marker := range gitOpsMarkers {
+		if _, ok := objectMeta.Annotations[marker.key]; ok {
+			return marker.manager
+		}
+		if _, ok := objectMeta.Labels[marker.key]; ok {
+			return marker.manager
+		}
+	}
+	return ""
+}
+
+// ManagedBy returns the tool managing the object. GitOps controllers take precedence over the
+// `app.kubernetes.io/managed-by` label, which is e.g. set to Helm even when Helm is driven by Flux.
+func ManagedBy(objectMeta metav1.ObjectMeta) string {
+	if manager := GitOpsManager(objectMeta); manager != "" {
+		return manager
+	}
+	return objectMeta.Labels["app.kubernetes.io/managed-by"]
+}
+
+// ManagedWorkloadWarning returns a warning if the attacked object is reconciled by a GitOps controller, which may
+// revert the attack or the restoration at the end of it. Returns nil if the object isn't managed or warnings are disabled.
+func ManagedWorkloadWarning(kind string, objectMeta metav1.ObjectMeta) *action_kit_api.Message {
+	if !extconfig.Config.WarnOnManagedWorkloadAttack {
+		return nil
+	}
+	manager := GitOpsManager(objectMeta)
+	if manager == "" {
+		return nil
+	}
+	message := fmt.Sprintf("%s %s/%s is managed by %s, which may revert the changes of the attack.", kind, objectMeta.Namespace, objectMeta.Name, manager)
+	log.Warn().Msg(message)
+	return &action_kit_api.Message{
+		Message: message,
+		Level:   extutil.Ptr(action_kit_api.Warn),
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestManagedByPrefersGitOpsController(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Labels: map[string]string{
+			"app.kubernetes.io/managed-by":     "Helm",
+			"helm.toolkit.fluxcd.io/name":      "shop",
+			"helm.toolkit.fluxcd.io/namespace": "flux-system",
+		},
+	}
+
+	assert.Equal(t, "flux", ManagedBy(objectMeta))
+}
+
+func TestManagedByFallsBackToLabel(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Labels: map[string]string{"app.kubernetes.io/managed-by": "Helm"}}
+
+	assert.Equal(t, "Helm", ManagedBy(objectMeta))
+	assert.Empty(t, GitOpsManager(objectMeta))
+}
+
+func TestManagedByWithoutMarkers(t *testing.T) {
+	assert.Empty(t, ManagedBy(metav1.ObjectMeta{}))
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/extension-kubernetes/client"
+)
+
+const openShiftDisplayNameAnnotation = "openshift.io/display-name"
+
+// AddNamespaceAttributes adds the display name of OpenShift projects as k8s.namespace.display-name.
+func AddNamespaceAttributes(k8s *client.Client, namespace string, attributes map[string][]string) {
+	if k8s.Distribution != "openshift" {
+		return
+	}
+	ns := k8s.NamespaceByName(namespace)
+	if ns == nil {
+		return
+	}
+	if displayName := ns.Annotations[openShiftDisplayNameAnnotation]; displayName != "" {
+		attributes["k8s.namespace.display-name"] = []string{displayName}
+	}
+}
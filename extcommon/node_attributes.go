@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/extension-kubernetes/client"
+	corev1 "k8s.io/api/core/v1"
+	"strconv"
+	"strings"
+)
+
+var cloudProviders = map[string]string{
+	"aws":   "aws",
+	"gce":   "gcp",
+	"azure": "azure",
+}
+
+// AddNodeAttributes adds the attributes of the node the workload is scheduled on.
+func AddNodeAttributes(k8s *client.Client, nodeName string, attributes map[string][]string) {
+	if nodeName == "" {
+		return
+	}
+	node := k8s.NodeByName(nodeName)
+	if node == nil {
+		return
+	}
+	for key, value := range NodeAttributes(node) {
+		attributes[key] = value
+	}
+	for key, value := range NodePodCapacityAttributes(node, activePodCount(k8s.PodsByNode(nodeName))) {
+		attributes[key] = value
+	}
+}
+
+func NodeAttributes(node *corev1.Node) map[string][]string {
+	attributes := make(map[string][]string)
+	if node.Spec.ProviderID != "" {
+		attributes["k8s.node.provider-id"] = []string{node.Spec.ProviderID}
+		if provider, instanceId, ok := parseProviderId(node.Spec.ProviderID); ok {
+			attributes["k8s.node.cloud-provider"] = []string{provider}
+			attributes["k8s.node.instance-id"] = []string{instanceId}
+		}
+	}
+	if pct, ok := allocatablePercent(node, corev1.ResourceCPU); ok {
+		attributes["k8s.node.cpu-allocatable-pct"] = []string{strconv.FormatInt(pct, 10)}
+	}
+	if pct, ok := allocatablePercent(node, corev1.ResourceMemory); ok {
+		attributes["k8s.node.memory-allocatable-pct"] = []string{strconv.FormatInt(pct, 10)}
+	}
+	return attributes
+}
+
+// NodePodCapacityAttributes reports how many pods are scheduled on the node compared to its pod capacity, to spot nodes
+// close to their pod limit.
+func NodePodCapacityAttributes(node *corev1.Node, podCount int) map[string][]string {
+	attributes := map[string][]string{
+		"k8s.node.pod-count": {strconv.Itoa(podCount)},
+	}
+	capacity, ok := node.Status.Capacity[corev1.ResourcePods]
+	if !ok || capacity.Value() == 0 {
+		return attributes
+	}
+	attributes["k8s.node.pod-capacity"] = []string{strconv.FormatInt(capacity.Value(), 10)}
+	attributes["k8s.node.pod-utilization-pct"] = []string{strconv.FormatInt(int64(podCount)*100/capacity.Value(), 10)}
+	return attributes
+}
+
+// activePodCount counts the pods occupying a slot of the node's pod capacity, i.e. which haven't terminated yet.
+func activePodCount(pods []*corev1.Pod) int {
+	count := 0
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			count++
+		}
+	}
+	return count
+}
+
+// allocatablePercent returns the share of the node's capacity which is left for pods after system and kube reservations.
+func allocatablePercent(node *corev1.Node, name corev1.ResourceName) (int64, bool) {
+	capacity, hasCapacity := node.Status.Capacity[name]
+	allocatable, hasAllocatable := node.Status.Allocatable[name]
+	if !hasCapacity || !hasAllocatable || capacity.MilliValue() == 0 {
+		return 0, false
+	}
+	return allocatable.MilliValue() * 100 / capacity.MilliValue(), true
+}
+
+// parseProviderId extracts the cloud provider and the instance id out of provider ids like
+//   - aws:///eu-central-1a/i-0123456789abcdef0
+//   - gce://my-project/europe-west3-a/gke-cluster-default-pool-1234
+//   - azure:///subscriptions/<id>/resourceGroups/<group>/providers/Microsoft.Compute/virtualMachines/<name>
+func parseProviderId(providerId string) (string, string, bool) {
+	scheme, path, found := strings.Cut(providerId, "://")
+	if !found {
+		return "", "", false
+	}
+	provider, known := cloudProviders[scheme]
+	if !known {
+		return "", "", false
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	instanceId := segments[len(segments)-1]
+	if instanceId == "" {
+		return "", "", false
+	}
+	return provider, instanceId, true
+}
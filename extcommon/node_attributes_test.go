@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestNodeAttributesForAwsProviderId(t *testing.T) {
+	node := &corev1.Node{Spec: corev1.NodeSpec{ProviderID: "aws:///eu-central-1a/i-0123456789abcdef0"}}
+
+	assert.Equal(t, map[string][]string{
+		"k8s.node.provider-id":    {"aws:///eu-central-1a/i-0123456789abcdef0"},
+		"k8s.node.cloud-provider": {"aws"},
+		"k8s.node.instance-id":    {"i-0123456789abcdef0"},
+	}, NodeAttributes(node))
+}
+
+func TestNodeAttributesForGcpProviderId(t *testing.T) {
+	node := &corev1.Node{Spec: corev1.NodeSpec{ProviderID: "gce://my-project/europe-west3-a/gke-cluster-default-pool-1234"}}
+
+	assert.Equal(t, map[string][]string{
+		"k8s.node.provider-id":    {"gce://my-project/europe-west3-a/gke-cluster-default-pool-1234"},
+		"k8s.node.cloud-provider": {"gcp"},
+		"k8s.node.instance-id":    {"gke-cluster-default-pool-1234"},
+	}, NodeAttributes(node))
+}
+
+func TestNodeAttributesForUnknownProviderId(t *testing.T) {
+	node := &corev1.Node{Spec: corev1.NodeSpec{ProviderID: "kind://docker/kind/kind-control-plane"}}
+
+	assert.Equal(t, map[string][]string{
+		"k8s.node.provider-id": {"kind://docker/kind/kind-control-plane"},
+	}, NodeAttributes(node))
+}
+
+func TestNodeAttributesWithoutProviderId(t *testing.T) {
+	assert.Empty(t, NodeAttributes(&corev1.Node{}))
+}
+
+func TestNodeAttributesForAllocatableShare(t *testing.T) {
+	node := &corev1.Node{Status: corev1.NodeStatus{
+		Capacity: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("16Gi"),
+		},
+		Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("3"),
+			corev1.ResourceMemory: resource.MustParse("10Gi"),
+		},
+	}}
+
+	assert.Equal(t, map[string][]string{
+		"k8s.node.cpu-allocatable-pct":    {"75"},
+		"k8s.node.memory-allocatable-pct": {"62"},
+	}, NodeAttributes(node))
+}
+
+func TestNodePodCapacityAttributesForNodeNearItsPodLimit(t *testing.T) {
+	node := &corev1.Node{Status: corev1.NodeStatus{
+		Capacity: corev1.ResourceList{
+			corev1.ResourcePods: resource.MustParse("110"),
+		},
+	}}
+
+	assert.Equal(t, map[string][]string{
+		"k8s.node.pod-count":           {"105"},
+		"k8s.node.pod-capacity":        {"110"},
+		"k8s.node.pod-utilization-pct": {"95"},
+	}, NodePodCapacityAttributes(node, 105))
+}
+
+func TestNodePodCapacityAttributesWithoutPodCapacity(t *testing.T) {
+	assert.Equal(t, map[string][]string{
+		"k8s.node.pod-count": {"3"},
+	}, NodePodCapacityAttributes(&corev1.Node{}, 3))
+}
+
+func TestAddNodeAttributesCountsActivePodsOfNode(t *testing.T) {
+	// Given
+	node := testsupport.ReadyNode("worker-1")
+	node.Status.Capacity = corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")}
+	pod := func(name string, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	k8s := testsupport.NewClientBuilder(t).
+		WithNodes(node).
+		WithPods(
+			pod("running-1", "worker-1", corev1.PodRunning),
+			pod("running-2", "worker-1", corev1.PodRunning),
+			pod("pending", "worker-1", corev1.PodPending),
+			pod("completed", "worker-1", corev1.PodSucceeded),
+			pod("elsewhere", "worker-2", corev1.PodRunning),
+		).
+		Build()
+	attributes := map[string][]string{}
+
+	// When
+	AddNodeAttributes(k8s, "worker-1", attributes)
+
+	// Then
+	assert.Equal(t, []string{"3"}, attributes["k8s.node.pod-count"])
+	assert.Equal(t, []string{"4"}, attributes["k8s.node.pod-capacity"])
+	assert.Equal(t, []string{"75"}, attributes["k8s.node.pod-utilization-pct"])
+}
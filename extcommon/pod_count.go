@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"time"
+)
+
+const (
+	PodCountMin1                 = "podCountMin1"
+	PodCountEqualsDesiredCount   = "podCountEqualsDesiredCount"
+	PodCountLessThanDesiredCount = "podCountLessThanDesiredCount"
+)
+
+func PodCountCheckModeOptions() []action_kit_api.ParameterOption {
+	return []action_kit_api.ParameterOption{
+		action_kit_api.ExplicitParameterOption{
+			Label: "ready count > 0",
+			Value: PodCountMin1,
+		},
+		action_kit_api.ExplicitParameterOption{
+			Label: "ready count = desired count",
+			Value: PodCountEqualsDesiredCount,
+		},
+		action_kit_api.ExplicitParameterOption{
+			Label: "ready count < desired count",
+			Value: PodCountLessThanDesiredCount,
+		},
+	}
+}
+
+// PodCountStatus verifies the ready count of a workload, e.g. kind Deployment, according to the pod count check mode.
+// The check completes as soon as the condition is met, otherwise it fails once the timeout is reached.
+func PodCountStatus(kind string, name string, mode string, readyCount int32, desiredReplicas *int32, timeout time.Time) *action_kit_api.StatusResult {
+	now := time.Now()
+
+	desiredCount := int32(0)
+	if desiredReplicas != nil {
+		desiredCount = *desiredReplicas
+	} else if mode == PodCountEqualsDesiredCount || mode == PodCountLessThanDesiredCount {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s %s has no desired count.", kind, name),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var checkError *action_kit_api.ActionKitError
+	if mode == PodCountMin1 && readyCount < 1 {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has no ready pods.", name),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if mode == PodCountEqualsDesiredCount && readyCount != desiredCount {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has only %d of desired %d pods ready.", name, readyCount, desiredCount),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if mode == PodCountLessThanDesiredCount && readyCount == desiredCount {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has all %d desired pods ready.", name, desiredCount),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+
+	if now.After(timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error:     checkError,
+		}
+	} else {
+		return &action_kit_api.StatusResult{
+			Completed: checkError == nil,
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+)
+
+// RegisterAction registers the action unless STEADYBIT_EXTENSION_ENABLED_ACTIONS is set and doesn't contain its id,
+// e.g. to offer checks only. Attacks are limited to STEADYBIT_EXTENSION_MAX_CONCURRENT_ATTACKS.
+func RegisterAction[T any](action action_kit_sdk.Action[T]) {
+	description := action.Describe()
+	if !extconfig.IsActionEnabled(description.Id) {
+		log.Info().Msgf("Action %s is not enabled, skipping registration.", description.Id)
+		return
+	}
+	if description.Kind == action_kit_api.Attack {
+		action_kit_sdk.RegisterAction(limitConcurrency(action))
+		return
+	}
+	action_kit_sdk.RegisterAction(action)
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testAction struct {
+	id string
+}
+
+func (a testAction) NewEmptyState() struct{} {
+	return struct{}{}
+}
+
+func (a testAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:      a.id,
+		Kind:    action_kit_api.Check,
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+	}
+}
+
+func (a testAction) Prepare(_ context.Context, _ *struct{}, _ action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return nil, nil
+}
+
+func (a testAction) Start(_ context.Context, _ *struct{}) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func TestRegisterActionSkipsActionsNotEnabled(t *testing.T) {
+	// Given
+	extconfig.Config.EnabledActions = []string{"com.steadybit.extension_kubernetes.test_enabled"}
+	defer func() { extconfig.Config.EnabledActions = nil }()
+
+	// When
+	RegisterAction[struct{}](testAction{id: "com.steadybit.extension_kubernetes.test_enabled"})
+	RegisterAction[struct{}](testAction{id: "com.steadybit.extension_kubernetes.test_disabled"})
+
+	// Then
+	paths := make([]string, 0)
+	for _, action := range action_kit_sdk.GetActionList().Actions {
+		paths = append(paths, action.Path)
+	}
+	assert.Contains(t, paths, "/com.steadybit.extension_kubernetes.test_enabled")
+	assert.NotContains(t, paths, "/com.steadybit.extension_kubernetes.test_disabled")
+}
+
+func TestIsActionEnabledDefaultsToAllActions(t *testing.T) {
+	// Given
+	extconfig.Config.EnabledActions = nil
+
+	// Then
+	assert.True(t, extconfig.IsActionEnabled("com.steadybit.extension_kubernetes.delete_node_object"))
+}
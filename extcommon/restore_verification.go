@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// VerifyRestoreParameter lets users of restorative attacks opt out of VerifyRestored.
+func VerifyRestoreParameter(order int) action_kit_api.ActionParameter {
+	return action_kit_api.ActionParameter{
+		Name:         "verifyRestore",
+		Label:        "Verify restore",
+		Description:  extutil.Ptr("Re-read the object at the end of the attack and warn if the restored value was overwritten, e.g. by a GitOps controller."),
+		Type:         action_kit_api.Boolean,
+		DefaultValue: extutil.Ptr("true"),
+		Order:        extutil.Ptr(order),
+		Required:     extutil.Ptr(false),
+		Advanced:     extutil.Ptr(true),
+	}
+}
+
+// VerifyRestored re-reads a value an attack restored in its stop phase, e.g. "the cpu request of deployment shop/checkout".
+// It returns a warning if the value differs from the restored one, as another controller has overwritten it, or if it
+// can't be read. Returns nil if the restore took effect.
+func VerifyRestored(ctx context.Context, subject string, restored string, read func(ctx context.Context) (string, error)) *action_kit_api.Message {
+	var message string
+	if actual, err := read(ctx); err != nil {
+		message = fmt.Sprintf("Failed to verify the restore of %s: %s", subject, err.Error())
+	} else if actual != restored {
+		message = fmt.Sprintf("Restored %s to %q, but it is %q now. It was probably overwritten by another controller.", subject, restored, actual)
+	} else {
+		return nil
+	}
+	log.Warn().Msg(message)
+	return &action_kit_api.Message{
+		Message: message,
+		Level:   extutil.Ptr(action_kit_api.Warn),
+	}
+}
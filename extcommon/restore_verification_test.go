@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestVerifyRestoredAcceptsRestoredValue(t *testing.T) {
+	// When
+	message := VerifyRestored(context.Background(), "the replicas of deployment shop/checkout", "3", func(ctx context.Context) (string, error) {
+		return "3", nil
+	})
+
+	// Then
+	require.Nil(t, message)
+}
+
+func TestVerifyRestoredWarnsAboutOverwrittenValue(t *testing.T) {
+	// When
+	message := VerifyRestored(context.Background(), "the replicas of deployment shop/checkout", "3", func(ctx context.Context) (string, error) {
+		return "1", nil
+	})
+
+	// Then
+	require.Equal(t, "Restored the replicas of deployment shop/checkout to \"3\", but it is \"1\" now. It was probably overwritten by another controller.", message.Message)
+	require.Equal(t, action_kit_api.Warn, *message.Level)
+}
+
+func TestVerifyRestoredWarnsIfValueCannotBeRead(t *testing.T) {
+	// When
+	message := VerifyRestored(context.Background(), "the replicas of deployment shop/checkout", "3", func(ctx context.Context) (string, error) {
+		return "", errors.New("forbidden")
+	})
+
+	// Then
+	require.Equal(t, "Failed to verify the restore of the replicas of deployment shop/checkout: forbidden", message.Message)
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	"strings"
+)
+
+// CheckStandalonePodDeletion must be called by actions deleting or evicting pods. Standalone pods aren't recreated,
+// so their deletion is refused unless extconfig.Config.AllowStandalonePodDeletion is set, in which case only a warning is logged.
+func CheckStandalonePodDeletion(pods []*corev1.Pod) error {
+	var standalone []string
+	for _, pod := range pods {
+		if client.IsStandalonePod(pod) {
+			standalone = append(standalone, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	if len(standalone) == 0 {
+		return nil
+	}
+
+	if extconfig.Config.AllowStandalonePodDeletion {
+		log.Warn().Msgf("Deleting pods without a controller, they won't be recreated: %s", strings.Join(standalone, ", "))
+		return nil
+	}
+	return extension_kit.ToError(fmt.Sprintf("Refusing to delete pods without a controller, they won't be recreated: %s. Set STEADYBIT_EXTENSION_ALLOW_STANDALONE_POD_DELETION to allow it.", strings.Join(standalone, ", ")), nil)
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+var (
+	standalonePod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bare", Namespace: "default"},
+	}
+	ownedPod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: "default", OwnerReferences: []metav1.OwnerReference{
+			{Kind: "ReplicaSet", Name: "shop-1234", Controller: extutil.Ptr(true)},
+		}},
+	}
+)
+
+func TestCheckStandalonePodDeletionBlocksByDefault(t *testing.T) {
+	extconfig.Config.AllowStandalonePodDeletion = false
+
+	err := CheckStandalonePodDeletion([]*corev1.Pod{ownedPod, standalonePod})
+
+	require.ErrorContains(t, err, "Refusing to delete pods without a controller, they won't be recreated: default/bare.")
+}
+
+func TestCheckStandalonePodDeletionAllowsControllerOwnedPods(t *testing.T) {
+	extconfig.Config.AllowStandalonePodDeletion = false
+
+	require.NoError(t, CheckStandalonePodDeletion([]*corev1.Pod{ownedPod}))
+}
+
+func TestCheckStandalonePodDeletionAllowedByConfig(t *testing.T) {
+	extconfig.Config.AllowStandalonePodDeletion = true
+	defer func() { extconfig.Config.AllowStandalonePodDeletion = false }()
+
+	require.NoError(t, CheckStandalonePodDeletion([]*corev1.Pod{standalonePod}))
+}
@@ -6,15 +6,44 @@ package extconfig
 import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/labels"
+	"strings"
+	"time"
 )
 
 // Specification is the configuration specification for the extension. Configuration values can be applied
 // through environment variables. Learn more through the documentation of the envconfig package.
 // https://github.com/kelseyhightower/envconfig
 type Specification struct {
-	ClusterName              string   `required:"true" split_words:"true"`
-	LabelFilter              []string `required:"false" split_words:"true" default:"controller-revision-hash,pod-template-generation,pod-template-hash"`
-	DisableDiscoveryExcludes bool     `required:"false" split_words:"true" default:"false"`
+	ClusterName                            string            `required:"true" split_words:"true"`
+	LabelFilter                            []string          `required:"false" split_words:"true" default:"controller-revision-hash,pod-template-generation,pod-template-hash"`
+	DisableDiscoveryExcludes               bool              `required:"false" split_words:"true" default:"false"`
+	AttributeAliases                       map[string]string `required:"false" split_words:"true"`
+	MaxBlastRadiusPercent                  int               `required:"false" split_words:"true" default:"100"`
+	AllowStandalonePodDeletion             bool              `required:"false" split_words:"true" default:"false"`
+	UserAgentSuffix                        string            `required:"false" split_words:"true"`
+	WarnOnManagedWorkloadAttack            bool              `required:"false" split_words:"true" default:"true"`
+	DiscoveryIntervalSeconds               int               `required:"false" split_words:"true" default:"60"`
+	PodSelectorExpression                  string            `required:"false" split_words:"true"`
+	ClusterContexts                        map[string]string `required:"false" split_words:"true"`
+	InformerResyncPeriod                   time.Duration     `required:"false" split_words:"true" default:"0"`
+	Namespaces                             []string          `required:"false" split_words:"true"`
+	EnabledActions                         []string          `required:"false" split_words:"true"`
+	DiscoverOwnedReplicaSets               bool              `required:"false" split_words:"true" default:"false"`
+	MaxConcurrentAttacks                   int               `required:"false" split_words:"true" default:"0"`
+	DiscoveryAttributesExcludesContainer   []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesPod         []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesDeployment  []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesStatefulSet []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesReplicaSet  []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesJob         []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesCronJob     []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesHpa         []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesService     []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesIngress     []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesPvc         []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesNode        []string          `required:"false" split_words:"true"`
+	DiscoveryAttributesExcludesCluster     []string          `required:"false" split_words:"true"`
 }
 
 var (
@@ -29,5 +58,59 @@ func ParseConfiguration() {
 }
 
 func ValidateConfiguration() {
-	// You may optionally validate the configuration here.
+	if _, err := labels.Parse(Config.PodSelectorExpression); err != nil {
+		log.Fatal().Err(err).Msgf("Invalid pod selector expression %q.", Config.PodSelectorExpression)
+	}
+	if Config.InformerResyncPeriod < 0 {
+		log.Fatal().Msgf("Invalid informer resync period %s.", Config.InformerResyncPeriod)
+	}
+	if Config.MaxConcurrentAttacks < 0 {
+		log.Fatal().Msgf("Invalid max. concurrent attacks %d.", Config.MaxConcurrentAttacks)
+	}
+}
+
+// PodSelector returns the selector limiting which pods are discovered, e.g. `environment in (prod,staging)`.
+// Selects all pods if no expression is configured.
+func PodSelector() labels.Selector {
+	selector, err := labels.Parse(Config.PodSelectorExpression)
+	if err != nil {
+		// Unreachable after ValidateConfiguration, but never widen the scope if the expression is broken.
+		log.Error().Err(err).Msgf("Invalid pod selector expression %q.", Config.PodSelectorExpression)
+		return labels.Nothing()
+	}
+	return selector
+}
+
+// IsActionEnabled checks the action id against the configured enabled actions. All actions are enabled if none are
+// configured.
+func IsActionEnabled(actionId string) bool {
+	if len(Config.EnabledActions) == 0 {
+		return true
+	}
+	for _, enabled := range Config.EnabledActions {
+		if enabled == actionId {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLabelFiltered checks the label key against the configured label filter, see MatchesAnyPattern.
+func IsLabelFiltered(key string) bool {
+	return MatchesAnyPattern(Config.LabelFilter, key)
+}
+
+// MatchesAnyPattern checks the key against the patterns. Patterns ending with `*` match all keys starting with the
+// rest of the pattern, e.g. `team.example.com/*`, all others have to match exactly.
+func MatchesAnyPattern(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if prefix, isPrefix := strings.CutSuffix(pattern, "*"); isPrefix {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if pattern == key {
+			return true
+		}
+	}
+	return false
 }
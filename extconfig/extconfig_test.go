@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extconfig
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsLabelFiltered(t *testing.T) {
+	// Given
+	Config.LabelFilter = []string{"pod-template-hash", "team.example.com/*"}
+	defer func() { Config.LabelFilter = nil }()
+
+	// Then
+	assert.True(t, IsLabelFiltered("pod-template-hash"))
+	assert.True(t, IsLabelFiltered("team.example.com/owner"))
+	assert.True(t, IsLabelFiltered("team.example.com/"))
+	assert.False(t, IsLabelFiltered("pod-template-hash-suffix"))
+	assert.False(t, IsLabelFiltered("team.example.org/owner"))
+	assert.False(t, IsLabelFiltered("app"))
+}
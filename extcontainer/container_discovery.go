@@ -10,9 +10,10 @@ import (
 	"github.com/steadybit/extension-kit/exthttp"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/utils/strings/slices"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -33,7 +34,7 @@ func getContainerDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
 		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
 			Method:       "GET",
 			Path:         "/container/discovery/discovered-enrichment-data",
-			CallInterval: extutil.Ptr("1m"),
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
 		},
 	}
 }
@@ -95,6 +96,22 @@ func getContainerToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentR
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.container.ready",
 			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.state",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.waiting-reason",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.exit-code",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.started",
+			},
 			{
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.container.id",
@@ -103,10 +120,62 @@ func getContainerToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentR
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.container.image",
 			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.working-dir",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.termination-message-path",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.termination-message-policy",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.run-as-user",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.cpu-request",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.cpu-limit",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.memory-request",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.memory-limit",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.cpu-limit-request-ratio",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.memory-limit-request-ratio",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.container.is-native-sidecar",
+			},
 			{
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.service.name",
 			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.in-endpoints",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.services-endpoints",
+			},
 			{
 				Matcher: discovery_kit_api.StartsWith,
 				Name:    "k8s.pod.label.",
@@ -115,6 +184,58 @@ func getContainerToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentR
 				Matcher: discovery_kit_api.StartsWith,
 				Name:    "k8s.label.",
 			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.terminating",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.standalone",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.readiness-gates-met",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.scheduler-name",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.generate-name",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.containers-ready",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.qos-class",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.colocated-replicas",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.emptydir-data",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.image-pull-secrets",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.namespace.display-name",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.ip",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.pod.host-ip",
+			},
 			{
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.replicaset",
@@ -131,6 +252,22 @@ func getContainerToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentR
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.statefulset",
 			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.job",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.cronjob",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.deployment.replicas",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.statefulset.replicas",
+			},
 		},
 	}
 }
@@ -181,21 +318,61 @@ func getContainerToHostEnrichmentRule() discovery_kit_api.TargetEnrichmentRule {
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.statefulset",
 			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.job",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.cronjob",
+			},
 			{
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.pod.name",
 			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.node.provider-id",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.node.cloud-provider",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.node.instance-id",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.node.cpu-allocatable-pct",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.node.memory-allocatable-pct",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.node.pod-count",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.node.pod-capacity",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.node.pod-utilization-pct",
+			},
 		},
 	}
 }
 
 func getDiscoveredContainer(w http.ResponseWriter, _ *http.Request, _ []byte) {
-	enrichmentData := getDiscoveredContainerEnrichmentData(client.K8S)
+	enrichmentData := extcommon.DiscoverAllClusters(getDiscoveredContainerEnrichmentData)
 	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{EnrichmentData: &enrichmentData})
 }
 
 func getDiscoveredContainerEnrichmentData(k8s *client.Client) []discovery_kit_api.EnrichmentData {
-	pods := k8s.Pods()
+	pods := k8s.PodsByLabelSelector(extconfig.PodSelector())
 
 	filteredPods := make([]*corev1.Pod, 0, len(pods))
 	if extconfig.Config.DisableDiscoveryExcludes {
@@ -209,48 +386,115 @@ func getDiscoveredContainerEnrichmentData(k8s *client.Client) []discovery_kit_ap
 		}
 	}
 
+	colocated := newColocatedReplicas(k8s)
+	endpoints := extcommon.NewEndpointMembership(k8s)
 	enrichmentDataList := make([]discovery_kit_api.EnrichmentData, 0, len(filteredPods))
 	for _, pod := range filteredPods {
 		podMetadata := pod.ObjectMeta
 		ownerReferences := client.OwnerReferences(k8s, &podMetadata)
+		colocatedReplicas := colocated.count(pod, ownerReferences)
 		services := k8s.ServicesByPod(pod)
+		readyContainers, totalContainers := client.ContainersReady(pod)
 
-		for _, container := range pod.Status.ContainerStatuses {
+		nativeSidecars := nativeSidecarNames(pod.Spec)
+		for _, container := range discoverableContainerStatuses(pod.Status, nativeSidecars) {
 			if container.ContainerID == "" {
 				continue
 			}
 
 			containerIdWithoutPrefix := strings.SplitAfter(container.ContainerID, "://")[1]
 
-			attributes := map[string][]string{
-				"k8s.cluster-name":          {extconfig.Config.ClusterName},
-				"k8s.container.id":          {container.ContainerID},
-				"k8s.container.id.stripped": {containerIdWithoutPrefix},
-				"k8s.container.name":        {container.Name},
-				"k8s.container.ready":       {strconv.FormatBool(container.Ready)},
-				"k8s.container.image":       {container.Image},
-				"k8s.namespace":             {podMetadata.Namespace},
-				"k8s.node.name":             {pod.Spec.NodeName},
-				"k8s.pod.name":              {podMetadata.Name},
-				"k8s.distribution":          {k8s.Distribution},
+			attributes := extcommon.NewAttributes()
+			attributes.Set("k8s.cluster-name", k8s.ClusterName())
+			attributes.Set("k8s.container.id", container.ContainerID)
+			attributes.Set("k8s.container.id.stripped", containerIdWithoutPrefix)
+			attributes.Set("k8s.container.name", container.Name)
+			attributes.SetBool("k8s.container.ready", container.Ready)
+			attributes.Set("k8s.container.image", container.Image)
+			attributes.Set("k8s.namespace", podMetadata.Namespace)
+			attributes.Set("k8s.node.name", pod.Spec.NodeName)
+			attributes.Set("k8s.pod.name", podMetadata.Name)
+			attributes.Add("k8s.pod.generate-name", client.GenerateNameBase(pod))
+			attributes.Set("k8s.pod.scheduler-name", schedulerName(pod.Spec))
+			attributes.Set("k8s.pod.qos-class", string(client.QOSClass(pod)))
+			attributes.Set("k8s.pod.containers-ready", fmt.Sprintf("%d/%d", readyContainers, totalContainers))
+			attributes.Set("k8s.distribution", k8s.Distribution)
+
+			attributes.SetAll("k8s.pod.ip", client.PodIPs(pod.Status))
+			attributes.SetAll("k8s.pod.host-ip", client.HostIPs(pod.Status))
+
+			addStateAttributes(attributes, container.State)
+
+			// Started is gated by the startup probe and may be true long before the container is ready.
+			if container.Started != nil {
+				attributes.SetBool("k8s.container.started", *container.Started)
+			}
+
+			if nativeSidecars[container.Name] {
+				attributes.SetBool("k8s.container.is-native-sidecar", true)
+			}
+
+			if spec := containerSpec(pod.Spec, container.Name); spec != nil {
+				attributes.Add("k8s.container.working-dir", spec.WorkingDir)
+				attributes.Add("k8s.container.termination-message-path", spec.TerminationMessagePath)
+				attributes.Add("k8s.container.termination-message-policy", string(spec.TerminationMessagePolicy))
+				if uid := runAsUser(pod.Spec, spec); uid != nil {
+					attributes.SetInt("k8s.container.run-as-user", *uid)
+				}
+				addResourceAttributes(attributes, spec.Resources)
+			}
+
+			if podMetadata.DeletionTimestamp != nil {
+				attributes.SetBool("k8s.pod.terminating", true)
+			}
+
+			if len(pod.Spec.ReadinessGates) > 0 {
+				attributes.SetBool("k8s.pod.readiness-gates-met", len(client.UnmetReadinessGates(pod)) == 0)
+			}
+
+			attributes.SetAll("k8s.pod.image-pull-secrets", imagePullSecrets(pod.Spec))
+
+			if hasEmptyDirData(pod.Spec) {
+				attributes.SetBool("k8s.pod.emptydir-data", true)
+			}
+
+			if client.IsStandalonePod(pod) {
+				attributes.SetBool("k8s.pod.standalone", true)
 			}
 
 			for key, value := range podMetadata.Labels {
-				if !slices.Contains(extconfig.Config.LabelFilter, key) {
-					attributes[fmt.Sprintf("k8s.pod.label.%v", key)] = []string{value}
-					attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+				if !extconfig.IsLabelFiltered(key) {
+					attributes.Set(fmt.Sprintf("k8s.pod.label.%v", key), value)
+					attributes.Set(fmt.Sprintf("k8s.label.%v", key), value)
 				}
 			}
 
 			for _, service := range services {
-				attributes["k8s.service.name"] = []string{service.Name}
-				attributes["k8s.namespace"] = []string{service.Namespace}
+				attributes.Set("k8s.service.name", service.Name)
+				attributes.Set("k8s.namespace", service.Namespace)
 			}
 
 			for _, ownerRef := range ownerReferences.OwnerRefs {
-				attributes[fmt.Sprintf("k8s.%v", ownerRef.Kind)] = []string{ownerRef.Name}
+				attributes.Set(fmt.Sprintf("k8s.%v", ownerRef.Kind), ownerRef.Name)
+				if ownerRef.Kind == "statefulset" {
+					if statefulSet := k8s.StatefulSetByNamespaceAndName(podMetadata.Namespace, ownerRef.Name); statefulSet != nil && statefulSet.Spec.Replicas != nil {
+						attributes.SetInt("k8s.statefulset.replicas", int64(*statefulSet.Spec.Replicas))
+					}
+				}
+			}
+			if ownerReferences.Deployment != nil && ownerReferences.Deployment.Spec.Replicas != nil {
+				attributes.SetInt("k8s.deployment.replicas", int64(*ownerReferences.Deployment.Spec.Replicas))
+			}
+			if colocatedReplicas > 0 {
+				attributes.SetInt("k8s.pod.colocated-replicas", int64(colocatedReplicas))
 			}
 
+			endpoints.AddAttributes(pod, attributes)
+			extcommon.AddNamespaceAttributes(k8s, podMetadata.Namespace, attributes)
+			extcommon.AddNodeAttributes(k8s, pod.Spec.NodeName, attributes)
+			extcommon.ApplyAttributeAliases(attributes)
+			extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesContainer)
+
 			enrichmentDataList = append(enrichmentDataList, discovery_kit_api.EnrichmentData{
 				Id:                 container.ContainerID,
 				EnrichmentDataType: KubernetesContainerEnrichmentDataType,
@@ -260,3 +504,196 @@ func getDiscoveredContainerEnrichmentData(k8s *client.Client) []discovery_kit_ap
 	}
 	return enrichmentDataList
 }
+
+// colocatedReplicas counts the pods sharing a node by their top-level owner, e.g. the deployment. The pods of a node
+// are resolved once per discovery run.
+type colocatedReplicas struct {
+	k8s          *client.Client
+	countsByNode map[string]map[string]int
+}
+
+func newColocatedReplicas(k8s *client.Client) *colocatedReplicas {
+	return &colocatedReplicas{k8s: k8s, countsByNode: map[string]map[string]int{}}
+}
+
+// count returns the number of pods of the same owner on the node of the pod, including the pod itself. Zero for
+// pods without owner or not scheduled yet.
+func (c *colocatedReplicas) count(pod *corev1.Pod, ownerReferences client.OwnerRefListWithResource) int {
+	owner := topLevelOwnerKey(pod.Namespace, ownerReferences)
+	if owner == "" || pod.Spec.NodeName == "" {
+		return 0
+	}
+	counts, ok := c.countsByNode[pod.Spec.NodeName]
+	if !ok {
+		counts = map[string]int{}
+		for _, sibling := range c.k8s.PodsByNode(pod.Spec.NodeName) {
+			if key := topLevelOwnerKey(sibling.Namespace, client.OwnerReferences(c.k8s, &sibling.ObjectMeta)); key != "" {
+				counts[key]++
+			}
+		}
+		c.countsByNode[pod.Spec.NodeName] = counts
+	}
+	return counts[owner]
+}
+
+func topLevelOwnerKey(namespace string, ownerReferences client.OwnerRefListWithResource) string {
+	if len(ownerReferences.OwnerRefs) == 0 {
+		return ""
+	}
+	owner := ownerReferences.OwnerRefs[len(ownerReferences.OwnerRefs)-1]
+	return fmt.Sprintf("%s/%s/%s", namespace, owner.Kind, owner.Name)
+}
+
+// addStateAttributes reports whether the container is running, waiting or terminated, with the reason of waiting
+// containers, e.g. CrashLoopBackOff, and the exit code of terminated containers.
+func addStateAttributes(attributes extcommon.Attributes, state corev1.ContainerState) {
+	switch {
+	case state.Running != nil:
+		attributes.Set("k8s.container.state", "running")
+	case state.Waiting != nil:
+		attributes.Set("k8s.container.state", "waiting")
+		attributes.Add("k8s.container.waiting-reason", state.Waiting.Reason)
+	case state.Terminated != nil:
+		attributes.Set("k8s.container.state", "terminated")
+		attributes.SetInt("k8s.container.exit-code", int64(state.Terminated.ExitCode))
+	}
+}
+
+func schedulerName(spec corev1.PodSpec) string {
+	if spec.SchedulerName == "" {
+		return corev1.DefaultSchedulerName
+	}
+	return spec.SchedulerName
+}
+
+// imagePullSecrets returns the names of the referenced secrets, the contents are never read.
+func imagePullSecrets(spec corev1.PodSpec) []string {
+	names := make([]string, 0, len(spec.ImagePullSecrets))
+	for _, secret := range spec.ImagePullSecrets {
+		if secret.Name != "" {
+			names = append(names, secret.Name)
+		}
+	}
+	return names
+}
+
+// scratchPaths are mount paths at which an emptyDir is expected to only hold temporary files.
+var scratchPaths = []string{"/tmp", "/var/tmp", "/run", "/var/run", "/dev/shm", "/var/cache"}
+
+// hasEmptyDirData reports whether an emptyDir volume is mounted outside a scratch path, i.e. likely holds data which
+// is lost when the pod is restarted.
+func hasEmptyDirData(spec corev1.PodSpec) bool {
+	emptyDirs := map[string]bool{}
+	for _, volume := range spec.Volumes {
+		if volume.EmptyDir != nil {
+			emptyDirs[volume.Name] = true
+		}
+	}
+	if len(emptyDirs) == 0 {
+		return false
+	}
+
+	for _, container := range spec.Containers {
+		for _, mount := range container.VolumeMounts {
+			if emptyDirs[mount.Name] && !isScratchPath(mount.MountPath) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isScratchPath(path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	for _, scratchPath := range scratchPaths {
+		if path == scratchPath || strings.HasPrefix(path, scratchPath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// nativeSidecarNames returns the init containers which keep running alongside the regular containers
+// (restartPolicy Always, available since Kubernetes 1.28).
+func nativeSidecarNames(spec corev1.PodSpec) map[string]bool {
+	names := map[string]bool{}
+	for _, container := range spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			names[container.Name] = true
+		}
+	}
+	return names
+}
+
+// discoverableContainerStatuses returns the statuses of the regular containers and the native sidecars. Regular init
+// containers are not discovered, as they have already terminated once the pod is running.
+func discoverableContainerStatuses(status corev1.PodStatus, nativeSidecars map[string]bool) []corev1.ContainerStatus {
+	statuses := make([]corev1.ContainerStatus, 0, len(status.ContainerStatuses)+len(nativeSidecars))
+	statuses = append(statuses, status.ContainerStatuses...)
+	for _, initStatus := range status.InitContainerStatuses {
+		if nativeSidecars[initStatus.Name] {
+			statuses = append(statuses, initStatus)
+		}
+	}
+	return statuses
+}
+
+func containerSpec(spec corev1.PodSpec, name string) *corev1.Container {
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == name {
+			return &spec.Containers[i]
+		}
+	}
+	for i := range spec.InitContainers {
+		if spec.InitContainers[i].Name == name {
+			return &spec.InitContainers[i]
+		}
+	}
+	return nil
+}
+
+// addResourceAttributes adds the cpu and memory requests and limits of the container in their canonical form, e.g. 250m
+// or 512Mi. Resources which aren't set are omitted, so that containers without limits can be selected.
+func addResourceAttributes(attributes extcommon.Attributes, resources corev1.ResourceRequirements) {
+	if cpu, ok := resources.Requests[corev1.ResourceCPU]; ok {
+		attributes.Set("k8s.container.cpu-request", cpu.String())
+	}
+	if cpu, ok := resources.Limits[corev1.ResourceCPU]; ok {
+		attributes.Set("k8s.container.cpu-limit", cpu.String())
+	}
+	if memory, ok := resources.Requests[corev1.ResourceMemory]; ok {
+		attributes.Set("k8s.container.memory-request", memory.String())
+	}
+	if memory, ok := resources.Limits[corev1.ResourceMemory]; ok {
+		attributes.Set("k8s.container.memory-limit", memory.String())
+	}
+	if ratio, ok := limitRequestRatio(resources, corev1.ResourceCPU); ok {
+		attributes.Set("k8s.container.cpu-limit-request-ratio", ratio)
+	}
+	if ratio, ok := limitRequestRatio(resources, corev1.ResourceMemory); ok {
+		attributes.Set("k8s.container.memory-limit-request-ratio", ratio)
+	}
+}
+
+// limitRequestRatio reports how far the container may burst beyond its request, rounded to two decimals, e.g. `4` for
+// a limit four times the request or `1` if it can't burst at all. Only reported if both are set.
+func limitRequestRatio(resources corev1.ResourceRequirements, name corev1.ResourceName) (string, bool) {
+	request, hasRequest := resources.Requests[name]
+	limit, hasLimit := resources.Limits[name]
+	if !hasRequest || !hasLimit || request.IsZero() {
+		return "", false
+	}
+	ratio := float64(limit.MilliValue()) / float64(request.MilliValue())
+	return strconv.FormatFloat(math.Round(ratio*100)/100, 'f', -1, 64), true
+}
+
+// runAsUser returns the configured UID of the container, which takes precedence over the one of the pod.
+func runAsUser(podSpec corev1.PodSpec, container *corev1.Container) *int64 {
+	if container.SecurityContext != nil && container.SecurityContext.RunAsUser != nil {
+		return container.SecurityContext.RunAsUser
+	}
+	if podSpec.SecurityContext != nil {
+		return podSpec.SecurityContext.RunAsUser
+	}
+	return nil
+}
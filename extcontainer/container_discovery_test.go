@@ -5,11 +5,16 @@ package extcontainer
 
 import (
 	"context"
+	"fmt"
+	"github.com/steadybit/extension-kit/extutil"
 	kclient "github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
@@ -125,6 +130,11 @@ func Test_getDiscoveredContainer(t *testing.T) {
 		"k8s.label.best-city":       {"Kevelaer"},
 		"k8s.service.name":          {"shop-kevelaer"},
 		"k8s.distribution":          {"openshift"},
+		"k8s.pod.standalone":        {"true"},
+		"k8s.pod.scheduler-name":    {"default-scheduler"},
+		"k8s.pod.qos-class":         {"BestEffort"},
+		"k8s.pod.containers-ready":  {"0/1"},
+		"k8s.pod.in-endpoints":      {"false"},
 	}, target.Attributes)
 }
 
@@ -309,8 +319,1314 @@ func Test_getDiscoveredContainerShouldNotIgnoreLabeledPodsIfExcludesDisabled(t *
 	require.Len(t, targets, 2)
 }
 
+func Test_getDiscoveredContainerShouldMarkTerminatingPods(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "shop",
+				Namespace:         "default",
+				DeletionTimestamp: extutil.Ptr(metav1.Now()),
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "MrFancyPants",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"true"}, targets[0].Attributes["k8s.pod.terminating"])
+}
+
+func Test_getDiscoveredContainerShouldMarkStandalonePods(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	createPod := func(name string, containerId string, owners []metav1.OwnerReference) {
+		_, err := clientset.CoreV1().
+			Pods("default").
+			Create(context.Background(), &v1.Pod{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Pod",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            name,
+					Namespace:       "default",
+					OwnerReferences: owners,
+				},
+				Status: v1.PodStatus{
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							ContainerID: containerId,
+							Name:        "MrFancyPants",
+							Image:       "nginx",
+						},
+					},
+				},
+				Spec: v1.PodSpec{
+					NodeName: "worker-1",
+				},
+			}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	createPod("bare", "crio://bare", nil)
+	createPod("owned", "crio://owned", []metav1.OwnerReference{
+		{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+			Name:       "migration",
+			Controller: extutil.Ptr(true),
+		},
+	})
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 2
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 2)
+	for _, target := range targets {
+		if target.Id == "crio://bare" {
+			assert.Equal(t, []string{"true"}, target.Attributes["k8s.pod.standalone"])
+		} else {
+			assert.NotContains(t, target.Attributes, "k8s.pod.standalone")
+		}
+	}
+}
+
+func Test_getDiscoveredContainerShouldReportCustomSchedulerName(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "batch",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "MrFancyPants",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName:      "worker-1",
+				SchedulerName: "volcano",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"volcano"}, targets[0].Attributes["k8s.pod.scheduler-name"])
+}
+
+func Test_getDiscoveredContainerShouldReportGenerateName(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:         "shop-7d9f8b6c5-x2k4q",
+				GenerateName: "shop-7d9f8b6c5-",
+				Namespace:    "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "shop",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"shop-7d9f8b6c5"}, targets[0].Attributes["k8s.pod.generate-name"])
+}
+
+func Test_getDiscoveredContainerShouldReportWorkingDirAndRunAsUser(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "app",
+						Image:       "nginx",
+					},
+					{
+						ContainerID: "crio://ghijkl",
+						Name:        "sidecar",
+						Image:       "envoy",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+				SecurityContext: &v1.PodSecurityContext{
+					RunAsUser: extutil.Ptr(int64(0)),
+				},
+				Containers: []v1.Container{
+					{
+						Name:       "app",
+						WorkingDir: "/srv/app",
+						SecurityContext: &v1.SecurityContext{
+							RunAsUser: extutil.Ptr(int64(1000)),
+						},
+					},
+					{
+						Name: "sidecar",
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 2
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 2)
+	attributesByName := map[string]map[string][]string{}
+	for _, target := range targets {
+		attributesByName[target.Attributes["k8s.container.name"][0]] = target.Attributes
+	}
+	assert.Equal(t, []string{"/srv/app"}, attributesByName["app"]["k8s.container.working-dir"])
+	assert.Equal(t, []string{"1000"}, attributesByName["app"]["k8s.container.run-as-user"])
+	assert.NotContains(t, attributesByName["sidecar"], "k8s.container.working-dir")
+	assert.Equal(t, []string{"0"}, attributesByName["sidecar"]["k8s.container.run-as-user"])
+}
+
+func Test_getDiscoveredContainerShouldReportResources(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "app",
+						Image:       "nginx",
+					},
+					{
+						ContainerID: "crio://ghijkl",
+						Name:        "sidecar",
+						Image:       "envoy",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+				Containers: []v1.Container{
+					{
+						Name: "app",
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse("0.25"),
+								v1.ResourceMemory: resource.MustParse("256Mi"),
+							},
+							Limits: v1.ResourceList{
+								v1.ResourceMemory: resource.MustParse("512Mi"),
+							},
+						},
+					},
+					{
+						Name: "sidecar",
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 2
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 2)
+	attributesByName := map[string]map[string][]string{}
+	for _, target := range targets {
+		attributesByName[target.Attributes["k8s.container.name"][0]] = target.Attributes
+	}
+	assert.Equal(t, []string{"250m"}, attributesByName["app"]["k8s.container.cpu-request"])
+	assert.Equal(t, []string{"256Mi"}, attributesByName["app"]["k8s.container.memory-request"])
+	assert.Equal(t, []string{"512Mi"}, attributesByName["app"]["k8s.container.memory-limit"])
+	assert.NotContains(t, attributesByName["app"], "k8s.container.cpu-limit")
+	assert.NotContains(t, attributesByName["app"], "k8s.container.cpu-limit-request-ratio")
+	assert.Equal(t, []string{"Burstable"}, attributesByName["app"]["k8s.pod.qos-class"])
+	for _, key := range []string{"k8s.container.cpu-request", "k8s.container.cpu-limit", "k8s.container.memory-request", "k8s.container.memory-limit"} {
+		assert.NotContains(t, attributesByName["sidecar"], key)
+	}
+}
+
+func Test_getDiscoveredContainerShouldReportLimitRequestRatios(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "app",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+				Containers: []v1.Container{
+					{
+						Name: "app",
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse("250m"),
+								v1.ResourceMemory: resource.MustParse("512Mi"),
+							},
+							Limits: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse("1"),
+								v1.ResourceMemory: resource.MustParse("512Mi"),
+							},
+						},
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"4"}, targets[0].Attributes["k8s.container.cpu-limit-request-ratio"])
+	assert.Equal(t, []string{"1"}, targets[0].Attributes["k8s.container.memory-limit-request-ratio"])
+}
+
+func Test_getDiscoveredContainerShouldReportTerminationMessagePolicy(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "app",
+						Image:       "nginx",
+					},
+					{
+						ContainerID: "crio://ghijkl",
+						Name:        "sidecar",
+						Image:       "envoy",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+				Containers: []v1.Container{
+					{
+						Name:                     "app",
+						TerminationMessagePath:   "/dev/termination-log",
+						TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+					},
+					{
+						Name: "sidecar",
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 2
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 2)
+	attributesByName := map[string]map[string][]string{}
+	for _, target := range targets {
+		attributesByName[target.Attributes["k8s.container.name"][0]] = target.Attributes
+	}
+	assert.Equal(t, []string{"FallbackToLogsOnError"}, attributesByName["app"]["k8s.container.termination-message-policy"])
+	assert.Equal(t, []string{"/dev/termination-log"}, attributesByName["app"]["k8s.container.termination-message-path"])
+	assert.NotContains(t, attributesByName["sidecar"], "k8s.container.termination-message-policy")
+	assert.NotContains(t, attributesByName["sidecar"], "k8s.container.termination-message-path")
+}
+
+func Test_getDiscoveredContainerShouldReportReadyContainers(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "app",
+						Image:       "nginx",
+						Ready:       true,
+					},
+					{
+						ContainerID: "crio://ghijkl",
+						Name:        "sidecar",
+						Image:       "envoy",
+						Ready:       false,
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 2
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 2)
+	for _, target := range targets {
+		assert.Equal(t, []string{"1/2"}, target.Attributes["k8s.pod.containers-ready"])
+	}
+}
+
+func Test_getDiscoveredContainerShouldFlagEmptyDirData(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "app",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+				Volumes: []v1.Volume{
+					{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+					{Name: "tmp", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+				},
+				Containers: []v1.Container{
+					{
+						Name: "app",
+						VolumeMounts: []v1.VolumeMount{
+							{Name: "data", MountPath: "/data"},
+							{Name: "tmp", MountPath: "/tmp"},
+						},
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"true"}, targets[0].Attributes["k8s.pod.emptydir-data"])
+}
+
+func Test_hasEmptyDirDataIgnoresScratchPaths(t *testing.T) {
+	// Given
+	spec := v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "tmp", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}},
+		},
+		Containers: []v1.Container{
+			{
+				Name: "app",
+				VolumeMounts: []v1.VolumeMount{
+					{Name: "tmp", MountPath: "/tmp/uploads"},
+					{Name: "config", MountPath: "/data"},
+				},
+			},
+		},
+	}
+
+	// When
+	result := hasEmptyDirData(spec)
+
+	// Then
+	assert.False(t, result)
+}
+
+func Test_getDiscoveredContainerShouldReportImagePullSecrets(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "app",
+						Image:       "registry.example.com/shop",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+				ImagePullSecrets: []v1.LocalObjectReference{
+					{Name: "registry-credentials"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"registry-credentials"}, targets[0].Attributes["k8s.pod.image-pull-secrets"])
+}
+
+func Test_getDiscoveredContainerShouldReportNativeSidecars(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://migrate",
+						Name:        "migrate",
+						Image:       "flyway",
+					},
+					{
+						ContainerID: "crio://proxy",
+						Name:        "proxy",
+						Image:       "envoy",
+						Ready:       true,
+					},
+				},
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://app",
+						Name:        "app",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+				InitContainers: []v1.Container{
+					{Name: "migrate"},
+					{Name: "proxy", RestartPolicy: extutil.Ptr(v1.ContainerRestartPolicyAlways)},
+				},
+				Containers: []v1.Container{
+					{Name: "app"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 2
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 2)
+	attributesByName := map[string]map[string][]string{}
+	for _, target := range targets {
+		attributesByName[target.Attributes["k8s.container.name"][0]] = target.Attributes
+	}
+	assert.NotContains(t, attributesByName, "migrate")
+	assert.NotContains(t, attributesByName["app"], "k8s.container.is-native-sidecar")
+	assert.Equal(t, []string{"true"}, attributesByName["proxy"]["k8s.container.is-native-sidecar"])
+	assert.Equal(t, []string{"crio://proxy"}, attributesByName["proxy"]["k8s.container.id"])
+}
+
+func Test_getDiscoveredContainerShouldReportStartedContainers(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "app",
+						Image:       "nginx",
+						Started:     extutil.Ptr(true),
+						Ready:       false,
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"true"}, targets[0].Attributes["k8s.container.started"])
+	assert.Equal(t, []string{"false"}, targets[0].Attributes["k8s.container.ready"])
+}
+
+func Test_getDiscoveredContainerShouldReportJobAndCronJob(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.BatchV1().
+		CronJobs("default").
+		Create(context.Background(), &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cleanup",
+				Namespace: "default",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = clientset.BatchV1().
+		Jobs("default").
+		Create(context.Background(), &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cleanup-28100",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "batch/v1", Kind: "CronJob", Name: "cleanup", Controller: extutil.Ptr(true)},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cleanup-28100-x7k2p",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "batch/v1", Kind: "Job", Name: "cleanup-28100", Controller: extutil.Ptr(true)},
+				},
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "cleanup",
+						Image:       "busybox",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		targets := getDiscoveredContainerEnrichmentData(client)
+		return len(targets) == 1 && len(targets[0].Attributes["k8s.cronjob"]) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"cleanup-28100"}, targets[0].Attributes["k8s.job"])
+	assert.Equal(t, []string{"cleanup"}, targets[0].Attributes["k8s.cronjob"])
+}
+
+func Test_getDiscoveredContainerShouldReportDesiredReplicasOfDeployment(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(int32(3)),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = clientset.AppsV1().
+		ReplicaSets("default").
+		Create(context.Background(), &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-5d8f7",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "Deployment", Name: "shop", Controller: extutil.Ptr(true)},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-5d8f7-x7k2p",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "shop-5d8f7", Controller: extutil.Ptr(true)},
+				},
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "shop",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		targets := getDiscoveredContainerEnrichmentData(client)
+		return len(targets) == 1 && len(targets[0].Attributes["k8s.deployment.replicas"]) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"shop"}, targets[0].Attributes["k8s.deployment"])
+	assert.Equal(t, []string{"3"}, targets[0].Attributes["k8s.deployment.replicas"])
+	assert.NotContains(t, targets[0].Attributes, "k8s.statefulset.replicas")
+}
+
+func Test_getDiscoveredContainerShouldReportOpenShiftProjectDisplayName(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Namespaces().
+		Create(context.Background(), &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "shop",
+				Annotations: map[string]string{
+					"openshift.io/display-name": "Online Shop",
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = clientset.CoreV1().
+		Pods("shop").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout",
+				Namespace: "shop",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "MrFancyPants",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		targets := getDiscoveredContainerEnrichmentData(client)
+		return len(targets) == 1 && len(targets[0].Attributes["k8s.namespace.display-name"]) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"Online Shop"}, targets[0].Attributes["k8s.namespace.display-name"])
+}
+
+func Test_getDiscoveredContainerShouldReportUnmetReadinessGates(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				Conditions: []v1.PodCondition{
+					{
+						Type:   v1.PodReady,
+						Status: v1.ConditionTrue,
+					},
+					{
+						Type:   "target-health.elbv2.k8s.aws/shop",
+						Status: v1.ConditionFalse,
+					},
+				},
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "MrFancyPants",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+				ReadinessGates: []v1.PodReadinessGate{
+					{ConditionType: "target-health.elbv2.k8s.aws/shop"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"false"}, targets[0].Attributes["k8s.pod.readiness-gates-met"])
+}
+
+func Test_getDiscoveredContainerShouldReportPodAndHostIps(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				PodIP:   "10.244.0.12",
+				PodIPs:  []v1.PodIP{{IP: "10.244.0.12"}, {IP: "fd00:10:244::c"}},
+				HostIP:  "192.168.49.2",
+				HostIPs: []v1.HostIP{{IP: "192.168.49.2"}},
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "MrFancyPants",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"10.244.0.12", "fd00:10:244::c"}, targets[0].Attributes["k8s.pod.ip"])
+	assert.Equal(t, []string{"192.168.49.2"}, targets[0].Attributes["k8s.pod.host-ip"])
+}
+
+func Test_getDiscoveredContainerShouldOnlyDiscoverPodsMatchingSelectorExpression(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.PodSelectorExpression = "environment in (prod,staging)"
+	defer func() { extconfig.Config.PodSelectorExpression = "" }()
+
+	for _, environment := range []string{"prod", "staging", "dev"} {
+		_, err := clientset.CoreV1().
+			Pods("default").
+			Create(context.Background(), &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shop-" + environment,
+					Namespace: "default",
+					Labels:    map[string]string{"environment": environment},
+				},
+				Status: v1.PodStatus{
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							ContainerID: "crio://" + environment,
+							Name:        "MrFancyPants",
+							Image:       "nginx",
+						},
+					},
+				},
+				Spec: v1.PodSpec{
+					NodeName: "worker-1",
+				},
+			}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(client.Pods()) == 3
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	var ids []string
+	for _, target := range targets {
+		ids = append(ids, target.Id)
+	}
+	assert.ElementsMatch(t, []string{"crio://prod", "crio://staging"}, ids)
+}
+
 func getTestClient(stopCh <-chan struct{}) (*kclient.Client, kubernetes.Interface) {
 	clientset := testclient.NewSimpleClientset()
-	client := kclient.CreateClient(clientset, stopCh, "/oapi")
+	client := kclient.CreateClient(clientset, stopCh, "/oapi", 0, nil)
 	return client, clientset
 }
+
+func Test_getDiscoveredContainerShouldReportColocatedReplicas(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.AppsV1().
+		ReplicaSets("default").
+		Create(context.Background(), &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-5d8f7",
+				Namespace: "default",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	for i, nodeName := range []string{"worker-1", "worker-1", "worker-2"} {
+		_, err = clientset.CoreV1().
+			Pods("default").
+			Create(context.Background(), &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("shop-5d8f7-%d", i),
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{
+						{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "shop-5d8f7", Controller: extutil.Ptr(true)},
+					},
+				},
+				Status: v1.PodStatus{
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							ContainerID: fmt.Sprintf("crio://abcdef%d", i),
+							Name:        "shop",
+							Image:       "nginx",
+						},
+					},
+				},
+				Spec: v1.PodSpec{
+					NodeName: nodeName,
+				},
+			}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	// When
+	assert.Eventually(t, func() bool {
+		targets := getDiscoveredContainerEnrichmentData(client)
+		return len(targets) == 3 && len(targets[0].Attributes["k8s.pod.colocated-replicas"]) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	colocatedByPod := map[string][]string{}
+	for _, target := range getDiscoveredContainerEnrichmentData(client) {
+		colocatedByPod[target.Attributes["k8s.pod.name"][0]] = target.Attributes["k8s.pod.colocated-replicas"]
+	}
+	assert.Equal(t, map[string][]string{
+		"shop-5d8f7-0": {"2"},
+		"shop-5d8f7-1": {"2"},
+		"shop-5d8f7-2": {"1"},
+	}, colocatedByPod)
+}
+
+func Test_getDiscoveredContainerShouldReportContainerState(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://running",
+						Name:        "app",
+						State:       v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+					},
+					{
+						ContainerID: "crio://waiting",
+						Name:        "sidecar",
+						State:       v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					},
+					{
+						ContainerID: "crio://terminated",
+						Name:        "migration",
+						State:       v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 3
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	attributesByName := map[string]map[string][]string{}
+	for _, target := range getDiscoveredContainerEnrichmentData(client) {
+		attributesByName[target.Attributes["k8s.container.name"][0]] = target.Attributes
+	}
+	assert.Equal(t, []string{"running"}, attributesByName["app"]["k8s.container.state"])
+	assert.NotContains(t, attributesByName["app"], "k8s.container.waiting-reason")
+	assert.Equal(t, []string{"waiting"}, attributesByName["sidecar"]["k8s.container.state"])
+	assert.Equal(t, []string{"CrashLoopBackOff"}, attributesByName["sidecar"]["k8s.container.waiting-reason"])
+	assert.Equal(t, []string{"terminated"}, attributesByName["migration"]["k8s.container.state"])
+	assert.Equal(t, []string{"137"}, attributesByName["migration"]["k8s.container.exit-code"])
+}
+
+func Test_getDiscoveredContainerShouldDropExcludedAttributes(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DisableDiscoveryExcludes = false
+	extconfig.Config.DiscoveryAttributesExcludesContainer = []string{"k8s.container.image", "k8s.pod.label.*"}
+	defer func() { extconfig.Config.DiscoveryAttributesExcludesContainer = nil }()
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "shop"},
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						ContainerID: "crio://abcdef",
+						Name:        "shop",
+						Image:       "nginx",
+					},
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredContainerEnrichmentData(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredContainerEnrichmentData(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"shop"}, targets[0].Attributes["k8s.pod.name"])
+	assert.NotContains(t, targets[0].Attributes, "k8s.container.image")
+	assert.NotContains(t, targets[0].Attributes, "k8s.pod.label.app")
+	assert.Equal(t, []string{"shop"}, targets[0].Attributes["k8s.label.app"])
+}
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcronjob
+
+const (
+	CronJobTargetType = "com.steadybit.extension_kubernetes.kubernetes-cronjob"
+	cronJobIcon       = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M12%202C6.47715%202%202%206.47715%202%2012C2%2017.5228%206.47715%2022%2012%2022C17.5228%2022%2022%2017.5228%2022%2012C22%206.47715%2017.5228%202%2012%202ZM4%2012C4%207.58172%207.58172%204%2012%204C16.4183%204%2020%207.58172%2020%2012C20%2016.4183%2016.4183%2020%2012%2020C7.58172%2020%204%2016.4183%204%2012Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M13%207C13%206.44772%2012.5523%206%2012%206C11.4477%206%2011%206.44772%2011%207V12C11%2012.2652%2011.1054%2012.5196%2011.2929%2012.7071L14.2929%2015.7071C14.6834%2016.0976%2015.3166%2016.0976%2015.7071%2015.7071C16.0976%2015.3166%2016.0976%2014.6834%2015.7071%2014.2929L13%2011.5858V7Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+)
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcronjob
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	batchv1 "k8s.io/api/batch/v1"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+func RegisterCronJobDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/cronjob/discovery", exthttp.GetterAsHandler(getCronJobDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/cronjob/discovery/target-description", exthttp.GetterAsHandler(getCronJobTargetDescription))
+	exthttp.RegisterHttpHandler("/cronjob/discovery/discovered-targets", getDiscoveredCronJobs)
+}
+
+func getCronJobDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         CronJobTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/cronjob/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getCronJobTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       CronJobTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes CronJob", Other: "Kubernetes CronJobs"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(cronJobIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.cronjob"},
+				{Attribute: "k8s.cronjob.schedule"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.cronjob",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredCronJobs(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredCronJobTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredCronJobTargets(k8s *client.Client) []discovery_kit_api.Target {
+	cronJobs := k8s.CronJobs()
+
+	filteredCronJobs := make([]*batchv1.CronJob, 0, len(cronJobs))
+	if extconfig.Config.DisableDiscoveryExcludes {
+		filteredCronJobs = cronJobs
+	} else {
+		for _, c := range cronJobs {
+			if client.IsExcludedFromDiscovery(c.ObjectMeta) {
+				continue
+			}
+			filteredCronJobs = append(filteredCronJobs, c)
+		}
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredCronJobs))
+	for i, c := range filteredCronJobs {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), c.Namespace, c.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":        {c.Namespace},
+			"k8s.cronjob":          {c.Name},
+			"k8s.cluster-name":     {k8s.ClusterName()},
+			"k8s.distribution":     {k8s.Distribution},
+			"k8s.cronjob.schedule": {c.Spec.Schedule},
+			"k8s.cronjob.suspend":  {strconv.FormatBool(c.Spec.Suspend != nil && *c.Spec.Suspend)},
+		}
+
+		if c.Status.LastScheduleTime != nil {
+			attributes["k8s.cronjob.last-schedule-time"] = []string{c.Status.LastScheduleTime.UTC().Format(time.RFC3339)}
+		}
+
+		if jobs := spawnedJobNames(k8s, c); len(jobs) > 0 {
+			attributes["k8s.job"] = jobs
+		}
+
+		for key, value := range c.ObjectMeta.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes[fmt.Sprintf("k8s.cronjob.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		extcommon.AddNamespaceAttributes(k8s, c.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesCronJob)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: CronJobTargetType,
+			Label:      c.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
+
+// spawnedJobNames returns the sorted names of the jobs owned by the cronjob, which are still around according to the
+// history limits of the cronjob.
+func spawnedJobNames(k8s *client.Client, c *batchv1.CronJob) []string {
+	jobs := k8s.JobsByCronJob(c)
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		names = append(names, job.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extcronjob
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+	"time"
+)
+
+func Test_getDiscoveredCronJobs(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	lastSchedule := metav1.NewTime(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	k8s := testsupport.NewClientBuilder(t).
+		WithCronJobs(&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cleanup",
+				Namespace: "default",
+				Labels: map[string]string{
+					"best-city":    "Kevelaer",
+					"secret-label": "secret-value",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+			Status: batchv1.CronJobStatus{
+				LastScheduleTime: &lastSchedule,
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredCronJobTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/cleanup", target.Id)
+	assert.Equal(t, "cleanup", target.Label)
+	assert.Equal(t, CronJobTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":                  {"default"},
+		"k8s.cronjob":                    {"cleanup"},
+		"k8s.cluster-name":               {"development"},
+		"k8s.distribution":               {"kubernetes"},
+		"k8s.cronjob.schedule":           {"*/5 * * * *"},
+		"k8s.cronjob.suspend":            {"false"},
+		"k8s.cronjob.last-schedule-time": {"2023-06-01T12:00:00Z"},
+		"k8s.cronjob.label.best-city":    {"Kevelaer"},
+		"k8s.label.best-city":            {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredCronJobsShouldReportSpawnedJobs(t *testing.T) {
+	// Given
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cleanup",
+			Namespace: "default",
+			UID:       types.UID("cleanup-uid"),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "@hourly",
+			Suspend:  extutil.Ptr(true),
+		},
+	}
+	ownedBy := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{
+			{APIVersion: "batch/v1", Kind: "CronJob", Name: "cleanup", UID: uid, Controller: extutil.Ptr(true)},
+		}
+	}
+	k8s := testsupport.NewClientBuilder(t).
+		WithCronJobs(cronJob).
+		WithJobs(&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "cleanup-28100", Namespace: "default", OwnerReferences: ownedBy(cronJob.UID)},
+		}, &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "cleanup-28040", Namespace: "default", OwnerReferences: ownedBy(cronJob.UID)},
+		}, &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "cleanup-of-previous-cronjob", Namespace: "default", OwnerReferences: ownedBy("other-uid")},
+		}, &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "manual", Namespace: "default"},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredCronJobTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"cleanup-28040", "cleanup-28100"}, targets[0].Attributes["k8s.job"])
+	assert.Equal(t, []string{"true"}, targets[0].Attributes["k8s.cronjob.suspend"])
+}
+
+func Test_getDiscoveredCronJobsShouldIgnoreLabeledCronJobs(t *testing.T) {
+	// Given
+	extconfig.Config.DisableDiscoveryExcludes = false
+	k8s := testsupport.NewClientBuilder(t).
+		WithCronJobs(&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cleanup",
+				Namespace: "default",
+				Labels: map[string]string{
+					"steadybit.com/discovery-disabled": "true",
+				},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredCronJobTargets(k8s)
+
+	// Then
+	require.Empty(t, targets)
+}
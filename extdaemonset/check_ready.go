@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdaemonset
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	appsv1 "k8s.io/api/apps/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	readyModeAll                = "allReady"
+	readyModeAtLeast            = "atLeastReady"
+	readyModeEveryScheduledNode = "readyOnEveryScheduledNode"
+)
+
+type DaemonSetReadyCheckAction struct {
+}
+
+type DaemonSetReadyCheckState struct {
+	Cluster   string
+	Timeout   time.Time
+	Mode      string
+	MinReady  int32
+	Namespace string
+	DaemonSet string
+}
+
+type DaemonSetReadyCheckConfig struct {
+	Duration  int
+	Mode      string
+	MinReady  int32
+	Namespace string
+	DaemonSet string
+}
+
+func NewDaemonSetReadyCheckAction() action_kit_sdk.Action[DaemonSetReadyCheckState] {
+	return DaemonSetReadyCheckAction{}
+}
+
+var _ action_kit_sdk.Action[DaemonSetReadyCheckState] = (*DaemonSetReadyCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[DaemonSetReadyCheckState] = (*DaemonSetReadyCheckAction)(nil)
+
+func (f DaemonSetReadyCheckAction) NewEmptyState() DaemonSetReadyCheckState {
+	return DaemonSetReadyCheckState{}
+}
+
+func (f DaemonSetReadyCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          daemonSetReadyCheckActionId,
+		Label:       "DaemonSet Ready",
+		Description: "Verify that the pods of a DaemonSet are ready on the nodes they are scheduled to.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(daemonSetIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Timeout",
+				Description:  extutil.Ptr("How long should the check wait for the pods to become ready."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("10s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "namespace",
+				Label:        "Namespace",
+				Description:  extutil.Ptr("The namespace of the DaemonSet."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr("kube-system"),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "daemonSet",
+				Label:       "DaemonSet",
+				Description: extutil.Ptr("The name of the DaemonSet."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(3),
+				Required:    extutil.Ptr(true),
+			},
+			{
+				Name:         "mode",
+				Label:        "Ready pods",
+				Description:  extutil.Ptr("How many pods need to be ready to let the check pass."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr(readyModeAll),
+				Order:        extutil.Ptr(4),
+				Required:     extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "ready count = desired scheduled count",
+						Value: readyModeAll,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "ready count >= min. ready",
+						Value: readyModeAtLeast,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "ready on every scheduled node",
+						Value: readyModeEveryScheduledNode,
+					},
+				}),
+			},
+			{
+				Name:         "minReady",
+				Label:        "Min. ready",
+				Description:  extutil.Ptr("The number of ready pods required by the \"ready count >= min. ready\" mode."),
+				Type:         action_kit_api.Integer,
+				DefaultValue: extutil.Ptr("1"),
+				Order:        extutil.Ptr(5),
+				Required:     extutil.Ptr(false),
+				Advanced:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f DaemonSetReadyCheckAction) Prepare(_ context.Context, state *DaemonSetReadyCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config DaemonSetReadyCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Mode = config.Mode
+	state.MinReady = config.MinReady
+	state.Namespace = config.Namespace
+	state.DaemonSet = config.DaemonSet
+	return nil, nil
+}
+
+func (f DaemonSetReadyCheckAction) Start(_ context.Context, _ *DaemonSetReadyCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f DaemonSetReadyCheckAction) Status(_ context.Context, state *DaemonSetReadyCheckState) (*action_kit_api.StatusResult, error) {
+	return statusDaemonSetReadyCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusDaemonSetReadyCheckInternal(k8s *client.Client, state *DaemonSetReadyCheckState) *action_kit_api.StatusResult {
+	daemonSet := k8s.DaemonSetByNamespaceAndName(state.Namespace, state.DaemonSet)
+	if daemonSet == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("DaemonSet %s not found", state.DaemonSet),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	ready := daemonSet.Status.NumberReady
+	desired := daemonSet.Status.DesiredNumberScheduled
+
+	var checkError *action_kit_api.ActionKitError
+	if state.Mode == readyModeAll && ready != desired {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has only %d of desired %d pods ready.", state.DaemonSet, ready, desired),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if state.Mode == readyModeAtLeast && ready < state.MinReady {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has only %d of required %d pods ready.", state.DaemonSet, ready, state.MinReady),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if state.Mode == readyModeEveryScheduledNode {
+		readyPods := readyPodsByNode(k8s, daemonSet)
+		if nodes := nodesWithoutReadyPod(readyPods); len(nodes) > 0 {
+			checkError = extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has no ready pod on nodes: %s", state.DaemonSet, strings.Join(nodes, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			})
+		} else if int32(len(readyPods)) < desired {
+			checkError = extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has ready pods on only %d of desired %d nodes.", state.DaemonSet, len(readyPods), desired),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			})
+		}
+	}
+
+	if time.Now().After(state.Timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error:     checkError,
+		}
+	}
+	return &action_kit_api.StatusResult{
+		Completed: checkError == nil,
+	}
+}
+
+// readyPodsByNode returns the number of ready pods of the daemonset per node the pods are scheduled to.
+// Nodes with scheduled but unready pods are reported with zero.
+func readyPodsByNode(k8s *client.Client, daemonSet *appsv1.DaemonSet) map[string]int {
+	result := make(map[string]int)
+	for _, pod := range k8s.PodsBySelector(daemonSet.Namespace, daemonSet.Spec.Selector) {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		result[pod.Spec.NodeName] += 0
+		if client.IsPodReady(pod) {
+			result[pod.Spec.NodeName]++
+		}
+	}
+	return result
+}
+
+// nodesWithoutReadyPod returns the sorted names of the nodes without a ready pod.
+func nodesWithoutReadyPod(readyPods map[string]int) []string {
+	var nodes []string
+	for node, count := range readyPods {
+		if count == 0 {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
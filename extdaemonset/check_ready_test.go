@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdaemonset
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestDaemonSetReadyCheckSucceedsWhenAllPodsAreReady(t *testing.T) {
+	// Given
+	k8sclient := createDaemonSetTestClient(t, 3, 3)
+	state := daemonSetReadyTestState(readyModeAll)
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestDaemonSetReadyCheckFailsWhenPodsAreNotReady(t *testing.T) {
+	// Given
+	k8sclient := createDaemonSetTestClient(t, 3, 2)
+	state := daemonSetReadyTestState(readyModeAll)
+	state.Timeout = time.Now().Add(-time.Second)
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "fluent-bit has only 2 of desired 3 pods ready.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestDaemonSetReadyCheckKeepsWaitingWithinTimeout(t *testing.T) {
+	// Given
+	k8sclient := createDaemonSetTestClient(t, 3, 2)
+	state := daemonSetReadyTestState(readyModeAll)
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestDaemonSetReadyCheckSucceedsWithMinimumReadyPods(t *testing.T) {
+	// Given
+	k8sclient := createDaemonSetTestClient(t, 3, 2)
+	state := daemonSetReadyTestState(readyModeAtLeast)
+	state.MinReady = 2
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestDaemonSetReadyCheckFailsBelowMinimumReadyPods(t *testing.T) {
+	// Given
+	k8sclient := createDaemonSetTestClient(t, 3, 1)
+	state := daemonSetReadyTestState(readyModeAtLeast)
+	state.MinReady = 2
+	state.Timeout = time.Now().Add(-time.Second)
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "fluent-bit has only 1 of required 2 pods ready.", result.Error.Title)
+}
+
+func TestDaemonSetReadyCheckSucceedsWhenReadyOnEveryScheduledNode(t *testing.T) {
+	// Given
+	k8sclient := createDaemonSetTestClient(t, 2, 2,
+		daemonSetPod("fluent-bit-a", "node-a", true),
+		daemonSetPod("fluent-bit-b", "node-b", true),
+	)
+	state := daemonSetReadyTestState(readyModeEveryScheduledNode)
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestDaemonSetReadyCheckFailsWhenANodeHasNoReadyPod(t *testing.T) {
+	// Given
+	k8sclient := createDaemonSetTestClient(t, 2, 1,
+		daemonSetPod("fluent-bit-a", "node-a", true),
+		daemonSetPod("fluent-bit-b", "node-b", false),
+	)
+	state := daemonSetReadyTestState(readyModeEveryScheduledNode)
+	state.Timeout = time.Now().Add(-time.Second)
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "fluent-bit has no ready pod on nodes: node-b", result.Error.Title)
+}
+
+func TestDaemonSetReadyCheckFailsWhenPodsAreMissingOnScheduledNodes(t *testing.T) {
+	// Given
+	k8sclient := createDaemonSetTestClient(t, 2, 1,
+		daemonSetPod("fluent-bit-a", "node-a", true),
+	)
+	state := daemonSetReadyTestState(readyModeEveryScheduledNode)
+	state.Timeout = time.Now().Add(-time.Second)
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "fluent-bit has ready pods on only 1 of desired 2 nodes.", result.Error.Title)
+}
+
+func TestDaemonSetReadyCheckDaemonSetNotFound(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	state := daemonSetReadyTestState(readyModeAll)
+
+	// When
+	result := statusDaemonSetReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Equal(t, "DaemonSet fluent-bit not found", result.Error.Title)
+	require.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func daemonSetReadyTestState(mode string) DaemonSetReadyCheckState {
+	return DaemonSetReadyCheckState{
+		Timeout:   time.Now().Add(time.Minute),
+		Mode:      mode,
+		Namespace: "logging",
+		DaemonSet: "fluent-bit",
+	}
+}
+
+func daemonSetPod(name string, node string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "logging",
+			Labels:    map[string]string{"app": "fluent-bit"},
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func createDaemonSetTestClient(t *testing.T, desired int32, ready int32, pods ...*corev1.Pod) *client.Client {
+	return testsupport.NewClientBuilder(t).
+		WithDaemonSets(&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "fluent-bit", Namespace: "logging"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "fluent-bit"}},
+			},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, NumberReady: ready},
+		}).
+		WithPods(pods...).
+		Build()
+}
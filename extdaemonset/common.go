@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdaemonset
+
+const (
+	daemonSetReadyCheckActionId = "com.steadybit.extension_kubernetes.daemonset-ready-check"
+	daemonSetIcon               = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M4%204C4%203.44772%204.44772%203%205%203H9C9.55228%203%2010%203.44772%2010%204V8C10%208.55228%209.55228%209%209%209H5C4.44772%209%204%208.55228%204%208V4ZM6%205V7H8V5H6Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M14%204C14%203.44772%2014.4477%203%2015%203H19C19.5523%203%2020%203.44772%2020%204V8C20%208.55228%2019.5523%209%2019%209H15C14.4477%209%2014%208.55228%2014%208V4ZM16%205V7H18V5H16Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M4%2016C4%2015.4477%204.44772%2015%205%2015H9C9.55228%2015%2010%2015.4477%2010%2016V20C10%2020.5523%209.55228%2021%209%2021H5C4.44772%2021%204%2020.5523%204%2020V16ZM6%2017V19H8V17H6Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M14%2016C14%2015.4477%2014.4477%2015%2015%2015H19C19.5523%2015%2020%2015.4477%2020%2016V20C20%2020.5523%2019.5523%2021%2019%2021H15C14.4477%2021%2014%2020.5523%2014%2020V16ZM16%2017V19H18V17H16Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+)
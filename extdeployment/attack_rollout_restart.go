@@ -13,6 +13,8 @@ import (
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extconversion"
 	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"os/exec"
 	"strings"
 )
@@ -87,6 +89,13 @@ func (f DeploymentRolloutRestartAction) Describe() action_kit_api.ActionDescript
 }
 
 func (f DeploymentRolloutRestartAction) Prepare(_ context.Context, state *DeploymentRolloutRestartState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareDeploymentRolloutRestartInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareDeploymentRolloutRestartInternal(k8s *client.Client, state *DeploymentRolloutRestartState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
 	var config DeploymentRolloutRestartConfig
 	if err := extconversion.Convert(request.Config, &config); err != nil {
 		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
@@ -95,18 +104,27 @@ func (f DeploymentRolloutRestartAction) Prepare(_ context.Context, state *Deploy
 	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
 	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
 	state.Wait = config.Wait
+
+	if deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment); deployment != nil {
+		if warning := extcommon.ManagedWorkloadWarning("Deployment", deployment.ObjectMeta); warning != nil {
+			return &action_kit_api.PrepareResult{
+				Messages: extutil.Ptr([]action_kit_api.Message{*warning}),
+			}, nil
+		}
+	}
 	return nil, nil
 }
 
 func (f DeploymentRolloutRestartAction) Start(_ context.Context, state *DeploymentRolloutRestartState) (*action_kit_api.StartResult, error) {
 	log.Info().Msgf("Starting deployment rollout restart attack for %+v", state)
 
-	cmd := exec.Command("kubectl",
+	args := append(client.ForCluster(state.Cluster).KubectlContextArgs(),
 		"rollout",
 		"restart",
 		"--namespace",
 		state.Namespace,
 		fmt.Sprintf("deployment/%s", state.Deployment))
+	cmd := exec.Command("kubectl", args...)
 	cmdOut, cmdErr := cmd.CombinedOutput()
 	if cmdErr != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to execute rollout restart: %s", cmdOut), cmdErr)
@@ -122,13 +140,14 @@ func (f DeploymentRolloutRestartAction) Status(_ context.Context, state *Deploym
 		}), nil
 	}
 
-	cmd := exec.Command("kubectl",
+	args := append(client.ForCluster(state.Cluster).KubectlContextArgs(),
 		"rollout",
 		"status",
 		"--watch=false",
 		"--namespace",
 		state.Namespace,
 		fmt.Sprintf("deployment/%s", state.Deployment))
+	cmd := exec.Command("kubectl", args...)
 	cmdOut, cmdErr := cmd.CombinedOutput()
 	if cmdErr != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to execute rollout restart status check: %s", cmdOut), cmdErr)
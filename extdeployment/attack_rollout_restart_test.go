@@ -4,10 +4,13 @@
 package extdeployment
 
 import (
-	"context"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
 )
 
@@ -26,11 +29,10 @@ func TestRolloutRestartPrepareCheckExtractsState(t *testing.T) {
 		}),
 	}
 
-	action := NewDeploymentRolloutRestartAction()
-	state := action.NewEmptyState()
+	state := NewDeploymentRolloutRestartAction().NewEmptyState()
 
 	// When
-	_, err := action.Prepare(context.Background(), &state, request)
+	_, err := prepareDeploymentRolloutRestartInternal(testsupport.NewClientBuilder(t).Build(), &state, request)
 	require.NoError(t, err)
 
 	// Then
@@ -39,3 +41,41 @@ func TestRolloutRestartPrepareCheckExtractsState(t *testing.T) {
 	require.Equal(t, "checkout", state.Deployment)
 	require.True(t, state.Wait)
 }
+
+func TestRolloutRestartPrepareWarnsForGitOpsManagedDeployment(t *testing.T) {
+	// Given
+	extconfig.Config.WarnOnManagedWorkloadAttack = true
+	defer func() { extconfig.Config.WarnOnManagedWorkloadAttack = false }()
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout",
+				Namespace: "shop",
+				Labels: map[string]string{
+					"kustomize.toolkit.fluxcd.io/name":      "apps",
+					"kustomize.toolkit.fluxcd.io/namespace": "flux-system",
+				},
+			},
+		}).
+		Build()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+				"k8s.namespace":    {"shop"},
+				"k8s.deployment":   {"checkout"},
+			},
+		}),
+	}
+	state := NewDeploymentRolloutRestartAction().NewEmptyState()
+
+	// When
+	result, err := prepareDeploymentRolloutRestartInternal(k8sclient, &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, *result.Messages, 1)
+	require.Equal(t, "Deployment shop/checkout is managed by flux, which may revert the changes of the attack.", (*result.Messages)[0].Message)
+	require.Equal(t, action_kit_api.Warn, *(*result.Messages)[0].Level)
+}
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// throttledReplicas are the min and max replicas the autoscaler is throttled to.
+const throttledReplicas = int32(1)
+
+type ThrottleHpaAction struct {
+}
+
+// HpaPin records the original bounds of an autoscaler that was pinned for the duration of an experiment.
+// It is part of the action state and therefore needs to be serializable.
+type HpaPin struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	MaxReplicas int32  `json:"maxReplicas"`
+}
+
+type ThrottleHpaState struct {
+	Cluster    string
+	Namespace  string
+	Deployment string
+	// Pin holds the original bounds of the autoscaler, captured during prepare.
+	Pin           *HpaPin
+	VerifyRestore bool
+}
+
+type ThrottleHpaConfig struct {
+	VerifyRestore         bool
+	MaxBlastRadiusPercent *int
+}
+
+func NewThrottleHpaAction() action_kit_sdk.Action[ThrottleHpaState] {
+	return ThrottleHpaAction{}
+}
+
+var _ action_kit_sdk.Action[ThrottleHpaState] = (*ThrottleHpaAction)(nil)
+var _ action_kit_sdk.ActionWithStop[ThrottleHpaState] = (*ThrottleHpaAction)(nil)
+
+func (f ThrottleHpaAction) NewEmptyState() ThrottleHpaState {
+	return ThrottleHpaState{}
+}
+
+func (f ThrottleHpaAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          throttleHpaActionId,
+		Label:       "Throttle Autoscaler",
+		Description: "Set min and max replicas of the horizontal pod autoscaler of the deployment to 1, which scales the deployment down as if the autoscaler was misconfigured. The original bounds are restored at the end of the attack.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("state"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:         DeploymentTargetType,
+			SelectionTemplates: extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the autoscaler be throttled."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			extcommon.VerifyRestoreParameter(2),
+			extcommon.MaxBlastRadiusPercentParameter(3),
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f ThrottleHpaAction) Prepare(_ context.Context, state *ThrottleHpaState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareThrottleHpaInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareThrottleHpaInternal(k8s *client.Client, state *ThrottleHpaState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config ThrottleHpaConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.VerifyRestore = config.VerifyRestore
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+
+	hpa := k8s.HorizontalPodAutoscalerByScaleTarget(state.Namespace, "Deployment", state.Deployment)
+	if hpa == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Deployment %s/%s is not managed by a horizontal pod autoscaler.", state.Namespace, state.Deployment), nil)
+	}
+	if deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment); deployment != nil {
+		// All but the throttled replicas are scaled down.
+		scaledDown := len(k8s.PodsByDeployment(deployment)) - int(throttledReplicas)
+		if scaledDown < 0 {
+			scaledDown = 0
+		}
+		if err := extcommon.CheckBlastRadius("pods", scaledDown, len(k8s.Pods()), config.MaxBlastRadiusPercent); err != nil {
+			return nil, err
+		}
+	}
+	state.Pin = &HpaPin{
+		Namespace:   hpa.Namespace,
+		Name:        hpa.Name,
+		MinReplicas: hpa.Spec.MinReplicas,
+		MaxReplicas: hpa.Spec.MaxReplicas,
+	}
+	return nil, nil
+}
+
+func (f ThrottleHpaAction) Start(ctx context.Context, state *ThrottleHpaState) (*action_kit_api.StartResult, error) {
+	return startThrottleHpaInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func startThrottleHpaInternal(ctx context.Context, k8s *client.Client, state *ThrottleHpaState) (*action_kit_api.StartResult, error) {
+	hpas := k8s.Clientset().AutoscalingV2().HorizontalPodAutoscalers(state.Pin.Namespace)
+	hpa, err := hpas.Get(ctx, state.Pin.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to fetch horizontal pod autoscaler %s/%s.", state.Pin.Namespace, state.Pin.Name), err)
+	}
+
+	hpa.Spec.MinReplicas = extutil.Ptr(throttledReplicas)
+	hpa.Spec.MaxReplicas = throttledReplicas
+	if _, err := hpas.Update(ctx, hpa, metav1.UpdateOptions{}); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to throttle horizontal pod autoscaler %s/%s.", state.Pin.Namespace, state.Pin.Name), err)
+	}
+	log.Info().Msgf("Throttled horizontal pod autoscaler %s/%s to %d replica.", state.Pin.Namespace, state.Pin.Name, throttledReplicas)
+	return nil, nil
+}
+
+func (f ThrottleHpaAction) Stop(ctx context.Context, state *ThrottleHpaState) (*action_kit_api.StopResult, error) {
+	return stopThrottleHpaInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func stopThrottleHpaInternal(ctx context.Context, k8s *client.Client, state *ThrottleHpaState) (*action_kit_api.StopResult, error) {
+	if err := restoreHorizontalPodAutoscaler(ctx, k8s, state.Pin); err != nil {
+		return nil, err
+	}
+
+	if !state.VerifyRestore {
+		return nil, nil
+	}
+	subject := fmt.Sprintf("the bounds of horizontal pod autoscaler %s/%s", state.Pin.Namespace, state.Pin.Name)
+	warning := extcommon.VerifyRestored(ctx, subject, hpaBounds(state.Pin.MinReplicas, state.Pin.MaxReplicas), func(ctx context.Context) (string, error) {
+		hpa, err := k8s.Clientset().AutoscalingV2().HorizontalPodAutoscalers(state.Pin.Namespace).Get(ctx, state.Pin.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return hpaBounds(hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas), nil
+	})
+	if warning != nil {
+		return &action_kit_api.StopResult{
+			Messages: extutil.Ptr([]action_kit_api.Message{*warning}),
+		}, nil
+	}
+	return nil, nil
+}
+
+// hpaBounds formats min and max replicas for the restore verification, e.g. "2-10" or "unset-10".
+func hpaBounds(minReplicas *int32, maxReplicas int32) string {
+	if minReplicas == nil {
+		return fmt.Sprintf("unset-%d", maxReplicas)
+	}
+	return fmt.Sprintf("%d-%d", *minReplicas, maxReplicas)
+}
+
+// restoreHorizontalPodAutoscaler reverts the autoscaler to the bounds recorded in the pin.
+func restoreHorizontalPodAutoscaler(ctx context.Context, k8s *client.Client, pin *HpaPin) error {
+	if pin == nil {
+		return nil
+	}
+
+	hpas := k8s.Clientset().AutoscalingV2().HorizontalPodAutoscalers(pin.Namespace)
+	hpa, err := hpas.Get(ctx, pin.Name, metav1.GetOptions{})
+	if err != nil {
+		return extension_kit.ToError(fmt.Sprintf("Failed to fetch horizontal pod autoscaler %s/%s.", pin.Namespace, pin.Name), err)
+	}
+
+	hpa.Spec.MinReplicas = pin.MinReplicas
+	hpa.Spec.MaxReplicas = pin.MaxReplicas
+	if _, err := hpas.Update(ctx, hpa, metav1.UpdateOptions{}); err != nil {
+		return extension_kit.ToError(fmt.Sprintf("Failed to restore horizontal pod autoscaler %s/%s.", pin.Namespace, pin.Name), err)
+	}
+	log.Info().Msgf("Restored horizontal pod autoscaler %s/%s.", pin.Namespace, pin.Name)
+	return nil
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"testing"
+)
+
+func TestThrottleHpaPrepareCapturesOriginalBounds(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).WithHorizontalPodAutoscalers(hpaFor("checkout", extutil.Ptr(int32(2)), 10)).Build()
+	state := NewThrottleHpaAction().NewEmptyState()
+
+	// When
+	_, err := prepareThrottleHpaInternal(k8s, &state, throttleHpaPrepareRequest("checkout"))
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, &HpaPin{Namespace: "shop", Name: "checkout-hpa", MinReplicas: extutil.Ptr(int32(2)), MaxReplicas: 10}, state.Pin)
+}
+
+func TestThrottleHpaPrepareRejectsDeploymentWithoutHpa(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).WithHorizontalPodAutoscalers(hpaFor("cart", nil, 3)).Build()
+	state := NewThrottleHpaAction().NewEmptyState()
+
+	// When
+	_, err := prepareThrottleHpaInternal(k8s, &state, throttleHpaPrepareRequest("checkout"))
+
+	// Then
+	require.EqualError(t, err, "Deployment shop/checkout is not managed by a horizontal pod autoscaler.")
+}
+
+func TestThrottleHpaPrepareChecksBlastRadiusOfScaledDownPods(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 40
+	defer func() { extconfig.Config.MaxBlastRadiusPercent = 100 }()
+	k8s := testsupport.NewClientBuilder(t).
+		WithHorizontalPodAutoscalers(hpaFor("checkout", extutil.Ptr(int32(2)), 10)).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+			},
+		}).
+		WithPods(
+			imageConsistencyTestPod("checkout-1", map[string]string{"app": "checkout"}, "checkout"),
+			imageConsistencyTestPod("checkout-2", map[string]string{"app": "checkout"}, "checkout"),
+			imageConsistencyTestPod("checkout-3", map[string]string{"app": "checkout"}, "checkout"),
+			imageConsistencyTestPod("cart-1", map[string]string{"app": "cart"}, "cart"),
+		).
+		Build()
+	state := NewThrottleHpaAction().NewEmptyState()
+
+	// When
+	_, err := prepareThrottleHpaInternal(k8s, &state, throttleHpaPrepareRequest("checkout"))
+
+	// Then
+	require.EqualError(t, err, "Attack would affect 2 of 4 pods (50%), which exceeds the max. blast radius of 40%.")
+}
+
+func TestThrottleHpaThrottlesAndRestoresBounds(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithHorizontalPodAutoscalers(hpaFor("checkout", extutil.Ptr(int32(2)), 10))
+	k8s := builder.Build()
+	state := ThrottleHpaState{
+		Namespace:     "shop",
+		Deployment:    "checkout",
+		Pin:           &HpaPin{Namespace: "shop", Name: "checkout-hpa", MinReplicas: extutil.Ptr(int32(2)), MaxReplicas: 10},
+		VerifyRestore: true,
+	}
+
+	// When
+	_, err := startThrottleHpaInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	throttled, err := builder.Clientset.AutoscalingV2().HorizontalPodAutoscalers("shop").Get(context.Background(), "checkout-hpa", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), *throttled.Spec.MinReplicas)
+	assert.Equal(t, int32(1), throttled.Spec.MaxReplicas)
+
+	// When
+	result, err := stopThrottleHpaInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	restored, err := builder.Clientset.AutoscalingV2().HorizontalPodAutoscalers("shop").Get(context.Background(), "checkout-hpa", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *restored.Spec.MinReplicas)
+	assert.Equal(t, int32(10), restored.Spec.MaxReplicas)
+}
+
+func TestThrottleHpaRestoreWarnsIfOverwritten(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithHorizontalPodAutoscalers(hpaFor("checkout", extutil.Ptr(int32(2)), 10))
+	k8s := builder.Build()
+	state := ThrottleHpaState{
+		Namespace:     "shop",
+		Deployment:    "checkout",
+		Pin:           &HpaPin{Namespace: "shop", Name: "checkout-hpa", MinReplicas: extutil.Ptr(int32(2)), MaxReplicas: 10},
+		VerifyRestore: true,
+	}
+	_, err := startThrottleHpaInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+	// A GitOps controller re-applies the throttled bounds right after the restore.
+	overwritten, err := builder.Clientset.AutoscalingV2().HorizontalPodAutoscalers("shop").Get(context.Background(), "checkout-hpa", metav1.GetOptions{})
+	require.NoError(t, err)
+	builder.Clientset.PrependReactor("get", "horizontalpodautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, overwritten.DeepCopy(), nil
+	})
+
+	// When
+	result, err := stopThrottleHpaInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "Restored the bounds of horizontal pod autoscaler shop/checkout-hpa to \"2-10\", but it is \"1-1\" now. It was probably overwritten by another controller.", (*result.Messages)[0].Message)
+	require.Equal(t, action_kit_api.Warn, *(*result.Messages)[0].Level)
+}
+
+func throttleHpaPrepareRequest(deployment string) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 60000,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {deployment},
+			},
+		}),
+	}
+}
+
+func hpaFor(deployment string, minReplicas *int32, maxReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment + "-hpa",
+			Namespace: "shop",
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deployment,
+			},
+			MinReplicas: minReplicas,
+			MaxReplicas: maxReplicas,
+		},
+	}
+}
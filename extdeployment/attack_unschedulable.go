@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// unschedulableCpuMarginMillis is added to the allocatable cpu of the largest node, so no node can fit the pod.
+const unschedulableCpuMarginMillis = 1000
+
+type UnschedulableAction struct {
+}
+
+type UnschedulableState struct {
+	Cluster    string
+	Namespace  string
+	Deployment string
+	Container  string
+	// CpuRequest is the injected request, the original request and limit are empty if they weren't set.
+	CpuRequest         string
+	OriginalCpuRequest string
+	OriginalCpuLimit   string
+	VerifyRestore      bool
+}
+
+type UnschedulableConfig struct {
+	Container             string
+	VerifyRestore         bool
+	MaxBlastRadiusPercent *int
+}
+
+func NewUnschedulableAction() action_kit_sdk.Action[UnschedulableState] {
+	return UnschedulableAction{}
+}
+
+var _ action_kit_sdk.Action[UnschedulableState] = (*UnschedulableAction)(nil)
+var _ action_kit_sdk.ActionWithStop[UnschedulableState] = (*UnschedulableAction)(nil)
+
+func (f UnschedulableAction) NewEmptyState() UnschedulableState {
+	return UnschedulableState{}
+}
+
+func (f UnschedulableAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          unschedulableActionId,
+		Label:       "Unschedulable Pods",
+		Description: "Raise the cpu request of a container of the deployment above the allocatable cpu of the largest node. New pods of the rollout stay pending, while the old pods keep running. The original request is restored at the end of the attack.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("state"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:         DeploymentTargetType,
+			SelectionTemplates: extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should new pods be unschedulable."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "container",
+				Label:       "Container",
+				Description: extutil.Ptr("The container to raise the cpu request for. Defaults to the first container of the pod template."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
+			extcommon.VerifyRestoreParameter(3),
+			extcommon.MaxBlastRadiusPercentParameter(4),
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f UnschedulableAction) Prepare(_ context.Context, state *UnschedulableState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareUnschedulableInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareUnschedulableInternal(k8s *client.Client, state *UnschedulableState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config UnschedulableConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Deployment %s not found", state.Deployment), nil)
+	}
+	// Pods replaced during the attack stay pending.
+	if err := extcommon.CheckBlastRadius("pods", len(k8s.PodsByDeployment(deployment)), len(k8s.Pods()), config.MaxBlastRadiusPercent); err != nil {
+		return nil, err
+	}
+	container := templateContainer(deployment.Spec.Template.Spec.Containers, config.Container)
+	if container == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Container %q not found in deployment %s", config.Container, state.Deployment), nil)
+	}
+	state.Container = container.Name
+	state.VerifyRestore = config.VerifyRestore
+	if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+		state.OriginalCpuRequest = cpu.String()
+	}
+	if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+		state.OriginalCpuLimit = cpu.String()
+	}
+
+	maxNodeCpu := k8s.MaxNodeAllocatableCpu()
+	if maxNodeCpu == 0 {
+		return nil, extension_kit.ToError("No node reports allocatable cpu.", nil)
+	}
+	state.CpuRequest = resource.NewMilliQuantity(maxNodeCpu+unschedulableCpuMarginMillis, resource.DecimalSI).String()
+
+	if warning := extcommon.ManagedWorkloadWarning("Deployment", deployment.ObjectMeta); warning != nil {
+		return &action_kit_api.PrepareResult{
+			Messages: extutil.Ptr([]action_kit_api.Message{*warning}),
+		}, nil
+	}
+	return nil, nil
+}
+
+func templateContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i, container := range containers {
+		if name == "" || container.Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
+
+func (f UnschedulableAction) Start(ctx context.Context, state *UnschedulableState) (*action_kit_api.StartResult, error) {
+	return startUnschedulableInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func startUnschedulableInternal(ctx context.Context, k8s *client.Client, state *UnschedulableState) (*action_kit_api.StartResult, error) {
+	// The request must not exceed the limit, so an existing cpu limit is raised as well.
+	limit := ""
+	if state.OriginalCpuLimit != "" {
+		limit = state.CpuRequest
+	}
+	if err := patchContainerCpu(ctx, k8s, state, state.CpuRequest, limit); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to raise the cpu request of deployment %s/%s.", state.Namespace, state.Deployment), err)
+	}
+	log.Info().Msgf("Raised cpu request of container %s of deployment %s/%s to %s", state.Container, state.Namespace, state.Deployment, state.CpuRequest)
+	return nil, nil
+}
+
+func (f UnschedulableAction) Stop(ctx context.Context, state *UnschedulableState) (*action_kit_api.StopResult, error) {
+	return stopUnschedulableInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func stopUnschedulableInternal(ctx context.Context, k8s *client.Client, state *UnschedulableState) (*action_kit_api.StopResult, error) {
+	if err := patchContainerCpu(ctx, k8s, state, state.OriginalCpuRequest, state.OriginalCpuLimit); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore the cpu request of deployment %s/%s.", state.Namespace, state.Deployment), err)
+	}
+	log.Info().Msgf("Restored cpu request of container %s of deployment %s/%s", state.Container, state.Namespace, state.Deployment)
+
+	if !state.VerifyRestore {
+		return nil, nil
+	}
+	subject := fmt.Sprintf("the cpu request of container %s of deployment %s/%s", state.Container, state.Namespace, state.Deployment)
+	warning := extcommon.VerifyRestored(ctx, subject, cpuOrUnset(state.OriginalCpuRequest), func(ctx context.Context) (string, error) {
+		deployment, err := k8s.Clientset().AppsV1().Deployments(state.Namespace).Get(ctx, state.Deployment, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		container := templateContainer(deployment.Spec.Template.Spec.Containers, state.Container)
+		if container == nil {
+			return "", fmt.Errorf("container %s not found", state.Container)
+		}
+		if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+			return cpu.String(), nil
+		}
+		return cpuOrUnset(""), nil
+	})
+	if warning != nil {
+		return &action_kit_api.StopResult{
+			Messages: extutil.Ptr([]action_kit_api.Message{*warning}),
+		}, nil
+	}
+	return nil, nil
+}
+
+func cpuOrUnset(cpu string) string {
+	if cpu == "" {
+		return "unset"
+	}
+	return cpu
+}
+
+// patchContainerCpu sets the cpu request and limit of the container. Empty values remove the request or limit.
+func patchContainerCpu(ctx context.Context, k8s *client.Client, state *UnschedulableState, request string, limit string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []map[string]interface{}{
+						{
+							"name": state.Container,
+							"resources": map[string]interface{}{
+								"requests": map[string]interface{}{"cpu": nilIfEmpty(request)},
+								"limits":   map[string]interface{}{"cpu": nilIfEmpty(limit)},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = k8s.Clientset().AppsV1().Deployments(state.Namespace).Patch(ctx, state.Deployment, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// nilIfEmpty turns empty values into null, which removes the key in a strategic merge patch.
+func nilIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"testing"
+)
+
+func TestUnschedulablePrepareExceedsLargestNode(t *testing.T) {
+	// Given
+	k8sclient := createUnschedulableTestBuilder(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+	}).Build()
+	state := NewUnschedulableAction().NewEmptyState()
+
+	// When
+	_, err := prepareUnschedulableInternal(k8sclient, &state, unschedulablePrepareRequest(""))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "app", state.Container)
+	assert.Equal(t, "250m", state.OriginalCpuRequest)
+	assert.Equal(t, "", state.OriginalCpuLimit)
+	assert.Equal(t, "9", state.CpuRequest)
+}
+
+func TestUnschedulablePrepareRejectsUnknownContainer(t *testing.T) {
+	// Given
+	k8sclient := createUnschedulableTestBuilder(t, corev1.ResourceRequirements{}).Build()
+	state := NewUnschedulableAction().NewEmptyState()
+
+	// When
+	_, err := prepareUnschedulableInternal(k8sclient, &state, unschedulablePrepareRequest("unknown"))
+
+	// Then
+	require.ErrorContains(t, err, "Container \"unknown\" not found")
+}
+
+func TestUnschedulablePrepareChecksBlastRadiusOfPods(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 60
+	defer func() { extconfig.Config.MaxBlastRadiusPercent = 100 }()
+	k8sclient := createUnschedulableTestBuilder(t, corev1.ResourceRequirements{}).
+		WithPods(
+			imageConsistencyTestPod("checkout-1", map[string]string{"app": "checkout"}, "checkout"),
+			imageConsistencyTestPod("checkout-2", map[string]string{"app": "checkout"}, "checkout"),
+			imageConsistencyTestPod("cart-1", map[string]string{"app": "cart"}, "cart"),
+		).
+		Build()
+	state := NewUnschedulableAction().NewEmptyState()
+
+	// When
+	_, err := prepareUnschedulableInternal(k8sclient, &state, unschedulablePrepareRequest(""))
+
+	// Then
+	require.EqualError(t, err, "Attack would affect 2 of 3 pods (67%), which exceeds the max. blast radius of 60%.")
+}
+
+func TestUnschedulablePatchesAndRestoresCpuRequest(t *testing.T) {
+	// Given
+	builder := createUnschedulableTestBuilder(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	})
+	k8sclient := builder.Build()
+	state := UnschedulableState{
+		Namespace:          "shop",
+		Deployment:         "checkout",
+		Container:          "app",
+		CpuRequest:         "9",
+		OriginalCpuRequest: "250m",
+		OriginalCpuLimit:   "1",
+	}
+
+	// When
+	_, err := startUnschedulableInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	resources := getContainerResources(t, builder)
+	assert.Equal(t, "9", resources.Requests.Cpu().String())
+	assert.Equal(t, "9", resources.Limits.Cpu().String())
+
+	// When
+	_, err = stopUnschedulableInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	resources = getContainerResources(t, builder)
+	assert.Equal(t, "250m", resources.Requests.Cpu().String())
+	assert.Equal(t, "1", resources.Limits.Cpu().String())
+}
+
+func TestUnschedulableRestoreRemovesInjectedRequest(t *testing.T) {
+	// Given
+	builder := createUnschedulableTestBuilder(t, corev1.ResourceRequirements{})
+	k8sclient := builder.Build()
+	state := UnschedulableState{
+		Namespace:  "shop",
+		Deployment: "checkout",
+		Container:  "app",
+		CpuRequest: "9",
+	}
+	_, err := startUnschedulableInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+	resources := getContainerResources(t, builder)
+	require.Equal(t, "9", resources.Requests.Cpu().String())
+
+	// When
+	_, err = stopUnschedulableInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	resources = getContainerResources(t, builder)
+	assert.NotContains(t, resources.Requests, corev1.ResourceCPU)
+	assert.NotContains(t, resources.Limits, corev1.ResourceCPU)
+}
+
+func TestUnschedulableRestoreWarnsIfOverwritten(t *testing.T) {
+	// Given
+	builder := createUnschedulableTestBuilder(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+	})
+	k8sclient := builder.Build()
+	state := UnschedulableState{
+		Namespace:          "shop",
+		Deployment:         "checkout",
+		Container:          "app",
+		CpuRequest:         "9",
+		OriginalCpuRequest: "250m",
+		VerifyRestore:      true,
+	}
+	_, err := startUnschedulableInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+	// A controller re-applies the attacked request right after the restore.
+	overwritten, err := builder.Clientset.AppsV1().Deployments("shop").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.NoError(t, err)
+	builder.Clientset.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, overwritten, nil
+	})
+
+	// When
+	result, err := stopUnschedulableInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "Restored the cpu request of container app of deployment shop/checkout to \"250m\", but it is \"9\" now. It was probably overwritten by another controller.", (*result.Messages)[0].Message)
+	require.Equal(t, action_kit_api.Warn, *(*result.Messages)[0].Level)
+}
+
+func TestUnschedulableRestoreVerificationPassesWithoutOverwrite(t *testing.T) {
+	// Given
+	builder := createUnschedulableTestBuilder(t, corev1.ResourceRequirements{})
+	k8sclient := builder.Build()
+	state := UnschedulableState{
+		Namespace:     "shop",
+		Deployment:    "checkout",
+		Container:     "app",
+		CpuRequest:    "9",
+		VerifyRestore: true,
+	}
+	_, err := startUnschedulableInternal(context.Background(), k8sclient, &state)
+	require.NoError(t, err)
+
+	// When
+	result, err := stopUnschedulableInternal(context.Background(), k8sclient, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func createUnschedulableTestBuilder(t *testing.T, resources corev1.ResourceRequirements) *testsupport.ClientBuilder {
+	small := testsupport.ReadyNode("worker-1")
+	small.Status.Allocatable = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}
+	large := testsupport.ReadyNode("worker-2")
+	large.Status.Allocatable = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}
+	return testsupport.NewClientBuilder(t).
+		WithNodes(small, large).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "app", Image: "checkout", Resources: resources},
+							{Name: "sidecar", Image: "envoy"},
+						},
+					},
+				},
+			},
+		})
+}
+
+func unschedulablePrepareRequest(container string) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":  60000,
+			"container": container,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}
+}
+
+func getContainerResources(t *testing.T, builder *testsupport.ClientBuilder) corev1.ResourceRequirements {
+	deployment, err := builder.Clientset.AppsV1().Deployments("shop").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.NoError(t, err)
+	return deployment.Spec.Template.Spec.Containers[0].Resources
+}
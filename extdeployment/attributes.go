@@ -43,6 +43,76 @@ func getAttributeDescriptions() discovery_kit_api.AttributeDescriptions {
 					Other: "deployment names",
 				},
 			},
+			{
+				Attribute: "k8s.statefulset",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "statefulset name",
+					Other: "statefulset names",
+				},
+			},
+			{
+				Attribute: "k8s.cronjob",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "cronjob name",
+					Other: "cronjob names",
+				},
+			},
+			{
+				Attribute: "k8s.job",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "job name",
+					Other: "job names",
+				},
+			},
+			{
+				Attribute: "k8s.ingress",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "ingress name",
+					Other: "ingress names",
+				},
+			},
+			{
+				Attribute: "k8s.pvc",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "persistent volume claim name",
+					Other: "persistent volume claim names",
+				},
+			},
+			{
+				Attribute: "k8s.hpa",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "horizontal pod autoscaler name",
+					Other: "horizontal pod autoscaler names",
+				},
+			},
+			{
+				Attribute: "k8s.replicaset",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "replicaset name",
+					Other: "replicaset names",
+				},
+			},
+			{
+				Attribute: "k8s.service",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "service name",
+					Other: "service names",
+				},
+			},
+			{
+				Attribute: "k8s.pod.name",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "pod name",
+					Other: "pod names",
+				},
+			},
+			{
+				Attribute: "k8s.node.name",
+				Label: discovery_kit_api.PluralLabel{
+					One:   "node name",
+					Other: "node names",
+				},
+			},
 		},
 	}
 }
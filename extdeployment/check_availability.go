@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	"time"
+)
+
+type AvailabilityCheckAction struct {
+}
+
+type AvailabilityCheckState struct {
+	Cluster    string
+	Timeout    time.Time
+	Namespace  string
+	Deployment string
+}
+
+type AvailabilityCheckConfig struct {
+	Duration int
+}
+
+func NewAvailabilityCheckAction() action_kit_sdk.Action[AvailabilityCheckState] {
+	return AvailabilityCheckAction{}
+}
+
+var _ action_kit_sdk.Action[AvailabilityCheckState] = (*AvailabilityCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[AvailabilityCheckState] = (*AvailabilityCheckAction)(nil)
+
+func (f AvailabilityCheckAction) NewEmptyState() AvailabilityCheckState {
+	return AvailabilityCheckState{}
+}
+
+func (f AvailabilityCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          availabilityCheckActionId,
+		Label:       "Deployment Available",
+		Description: "Verify that the Available condition of the deployment stays true. This reflects the availability as judged by the deployment controller, based on the minimum available replicas.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the availability be checked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f AvailabilityCheckAction) Prepare(_ context.Context, state *AvailabilityCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config AvailabilityCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f AvailabilityCheckAction) Start(_ context.Context, _ *AvailabilityCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f AvailabilityCheckAction) Status(_ context.Context, state *AvailabilityCheckState) (*action_kit_api.StatusResult, error) {
+	return statusAvailabilityCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusAvailabilityCheckInternal(k8s *client.Client, state *AvailabilityCheckState) *action_kit_api.StatusResult {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	if available := availableCondition(deployment); available != nil && available.Status == corev1.ConditionFalse {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s is not available: %s", state.Deployment, available.Reason),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: timeNow().After(state.Timeout),
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestAvailabilityCheckSucceedsWhileDeploymentStaysAvailable(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).WithDeployments(deploymentWithAvailability(corev1.ConditionTrue, "MinimumReplicasAvailable")).Build()
+	state := AvailabilityCheckState{
+		Timeout:    time.Now().Add(-time.Second),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusAvailabilityCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestAvailabilityCheckFailsWhenDeploymentBecomesUnavailable(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithDeployments(deploymentWithAvailability(corev1.ConditionTrue, "MinimumReplicasAvailable"))
+	k8sclient := builder.Build()
+	state := AvailabilityCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+	result := statusAvailabilityCheckInternal(k8sclient, &state)
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+
+	// When
+	_, err := builder.Clientset.AppsV1().Deployments("shop").UpdateStatus(context.Background(), deploymentWithAvailability(corev1.ConditionFalse, "MinimumReplicasUnavailable"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// Then
+	assert.Eventually(t, func() bool {
+		result = statusAvailabilityCheckInternal(k8sclient, &state)
+		return result.Completed
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, "checkout is not available: MinimumReplicasUnavailable", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func deploymentWithAvailability(status corev1.ConditionStatus, reason string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: status, Reason: reason},
+			},
+		},
+	}
+}
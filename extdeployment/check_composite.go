@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extevents"
+	"github.com/steadybit/extension-kubernetes/extpod"
+	"regexp"
+	"time"
+)
+
+type CompositeCheckAction struct {
+}
+
+// CompositeCheckState embeds the states of the sub-checks, whose status functions are evaluated on every status call.
+type CompositeCheckState struct {
+	Cluster           string
+	Timeout           time.Time
+	PodCount          PodCountCheckState
+	PodCountSatisfied bool
+	StuckTermination  StuckTerminationCheckState
+	ContainersReady   PodContainersReadyCheckState
+	ReadinessGates    ReadinessGateCheckState
+	Restarts          extpod.RestartCountCheckState
+	EventRate         EventRateCheckState
+	WarningEvents     extevents.WarningEventsCheckState
+}
+
+type CompositeCheckConfig struct {
+	Duration          int
+	PodCountCheckMode string
+	Buffer            int
+	MaxRestarts       int32
+	IgnoredReasons    []string
+}
+
+func NewCompositeCheckAction() action_kit_sdk.Action[CompositeCheckState] {
+	return CompositeCheckAction{}
+}
+
+var _ action_kit_sdk.Action[CompositeCheckState] = (*CompositeCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[CompositeCheckState] = (*CompositeCheckAction)(nil)
+
+func (f CompositeCheckAction) NewEmptyState() CompositeCheckState {
+	return CompositeCheckState{}
+}
+
+func (f CompositeCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          compositeCheckActionId,
+		Label:       "Deployment Health",
+		Description: "Verify the overall health of the deployment by combining the pod count, stuck pod termination, pod containers ready, readiness gates, pod restarts, warning event rate and warning events checks. Fails as soon as one of them fails.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the deployment be checked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "podCountCheckMode",
+				Label:        "Pod count",
+				Description:  extutil.Ptr("How many pods are required within the duration."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr(extcommon.PodCountEqualsDesiredCount),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Options:      extutil.Ptr(extcommon.PodCountCheckModeOptions()),
+			},
+			{
+				Name:         "buffer",
+				Label:        "Termination buffer",
+				Description:  extutil.Ptr("How long may a pod exceed its termination grace period before it is considered stuck."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(true),
+				Advanced:     extutil.Ptr(true),
+			},
+			{
+				Name:         "maxRestarts",
+				Label:        "Max. restarts",
+				Description:  extutil.Ptr("How many container restarts are tolerated across all pods of the deployment."),
+				Type:         action_kit_api.Integer,
+				DefaultValue: extutil.Ptr("0"),
+				Order:        extutil.Ptr(4),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "baseline",
+				Label:        "Event baseline",
+				Description:  extutil.Ptr("The period before the check which is used to measure the baseline rate of warning events in the namespace."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("10m"),
+				Order:        extutil.Ptr(5),
+				Required:     extutil.Ptr(true),
+				Advanced:     extutil.Ptr(true),
+			},
+			{
+				Name:         "multiplier",
+				Label:        "Event rate multiplier",
+				Description:  extutil.Ptr("By how much may the rate of warning events in the namespace exceed the baseline rate."),
+				Type:         action_kit_api.Integer,
+				DefaultValue: extutil.Ptr("2"),
+				Order:        extutil.Ptr(6),
+				Required:     extutil.Ptr(true),
+				Advanced:     extutil.Ptr(true),
+			},
+			{
+				Name:        "ignoredReasons",
+				Label:       "Ignored event reasons",
+				Description: extutil.Ptr("Warning events of the deployment, its replica sets and pods with these reasons are expected and ignored, e.g. `FailedScheduling`."),
+				Type:        action_kit_api.StringArray,
+				Order:       extutil.Ptr(7),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f CompositeCheckAction) Prepare(_ context.Context, state *CompositeCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareCompositeCheckInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareCompositeCheckInternal(k8s *client.Client, state *CompositeCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config CompositeCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	namespace := request.Target.Attributes["k8s.namespace"][0]
+	deployment := request.Target.Attributes["k8s.deployment"][0]
+
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.PodCount = PodCountCheckState{
+		Timeout:           state.Timeout,
+		PodCountCheckMode: config.PodCountCheckMode,
+		Namespace:         namespace,
+		Deployment:        deployment,
+	}
+	state.StuckTermination = StuckTerminationCheckState{
+		Timeout:    state.Timeout,
+		Buffer:     time.Millisecond * time.Duration(config.Buffer),
+		Namespace:  namespace,
+		Deployment: deployment,
+	}
+	state.ContainersReady = PodContainersReadyCheckState{
+		Timeout:    state.Timeout,
+		Namespace:  namespace,
+		Deployment: deployment,
+	}
+	state.ReadinessGates = ReadinessGateCheckState{
+		Timeout:    state.Timeout,
+		Namespace:  namespace,
+		Deployment: deployment,
+	}
+	restarts, err := extpod.NewDeploymentRestartCountCheckState(k8s, namespace, deployment, config.MaxRestarts, state.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	state.Restarts = restarts
+	// The event rate check reads duration, baseline and multiplier from the same config.
+	if _, err := prepareEventRateCheckInternal(k8s, &state.EventRate, request); err != nil {
+		return nil, err
+	}
+	state.WarningEvents = extevents.WarningEventsCheckState{
+		Start:          timeNow(),
+		Timeout:        state.Timeout,
+		Namespace:      namespace,
+		InvolvedObject: deploymentInvolvedObjects(deployment),
+		IgnoredReasons: config.IgnoredReasons,
+	}
+	return nil, nil
+}
+
+// deploymentInvolvedObjects matches the involved objects of events of the deployment, its replica sets and pods.
+func deploymentInvolvedObjects(deployment string) string {
+	name := regexp.QuoteMeta(deployment)
+	return fmt.Sprintf("^(deployment/%s|replicaset/%s-[a-z0-9]+|pod/%s-[a-z0-9]+-[a-z0-9]+)$", name, name, name)
+}
+
+func (f CompositeCheckAction) Start(_ context.Context, state *CompositeCheckState) (*action_kit_api.StartResult, error) {
+	state.EventRate.Start = timeNow()
+	return nil, nil
+}
+
+func (f CompositeCheckAction) Status(_ context.Context, state *CompositeCheckState) (*action_kit_api.StatusResult, error) {
+	return statusCompositeCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusCompositeCheckInternal(k8s *client.Client, state *CompositeCheckState) *action_kit_api.StatusResult {
+	// The pod count check waits for the pod count to be reached and doesn't need to be evaluated afterwards.
+	if !state.PodCountSatisfied {
+		result := statusPodCountCheckInternal(k8s, &state.PodCount)
+		if result.Error != nil {
+			return failedSubCheck("Pod count", result.Error)
+		}
+		state.PodCountSatisfied = result.Completed
+	}
+
+	subChecks := []struct {
+		name   string
+		status func() *action_kit_api.StatusResult
+	}{
+		{"Stuck pod termination", func() *action_kit_api.StatusResult {
+			return statusStuckTerminationCheckInternal(k8s, &state.StuckTermination)
+		}},
+		{"Pod containers ready", func() *action_kit_api.StatusResult {
+			return statusPodContainersReadyCheckInternal(k8s, &state.ContainersReady)
+		}},
+		{"Readiness gates", func() *action_kit_api.StatusResult {
+			return statusReadinessGateCheckInternal(k8s, &state.ReadinessGates)
+		}},
+		{"Pod restarts", func() *action_kit_api.StatusResult {
+			return extpod.RestartCountCheckStatus(k8s, &state.Restarts)
+		}},
+		{"Warning event rate", func() *action_kit_api.StatusResult {
+			return statusEventRateCheckInternal(k8s, &state.EventRate)
+		}},
+		{"Warning events", func() *action_kit_api.StatusResult {
+			return extevents.WarningEventsCheckStatus(k8s, &state.WarningEvents)
+		}},
+	}
+	for _, subCheck := range subChecks {
+		if result := subCheck.status(); result.Error != nil {
+			return failedSubCheck(subCheck.name, result.Error)
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: timeNow().After(state.Timeout) && state.PodCountSatisfied,
+	}
+}
+
+func failedSubCheck(name string, err *action_kit_api.ActionKitError) *action_kit_api.StatusResult {
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Error: extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s check failed: %s", name, err.Title),
+			Status: err.Status,
+		}),
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extevents"
+	"github.com/steadybit/extension-kubernetes/extpod"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestCompositeCheckSucceedsWhenAllSubChecksPass(t *testing.T) {
+	// Given
+	k8sclient := createCompositeCheckTestClient(t, true)
+	state := compositeCheckState(time.Now().Add(time.Minute))
+
+	// When
+	result := statusCompositeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+	require.True(t, state.PodCountSatisfied)
+}
+
+func TestCompositeCheckCompletesAfterTimeout(t *testing.T) {
+	// Given
+	k8sclient := createCompositeCheckTestClient(t, true)
+	state := compositeCheckState(time.Now().Add(-time.Second))
+
+	// When
+	result := statusCompositeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestCompositeCheckReportsFailingSubCheck(t *testing.T) {
+	// Given
+	k8sclient := createCompositeCheckTestClient(t, false)
+	state := compositeCheckState(time.Now().Add(time.Minute))
+
+	// When
+	result := statusCompositeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Pod containers ready check failed: checkout has pods with containers not ready: checkout-1 (1/2)", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestCompositeCheckPrepareSetsUpSubChecks(t *testing.T) {
+	// Given
+	k8sclient := compositeCheckTestBuilder(t, true, 2).Build()
+	state := NewCompositeCheckAction().NewEmptyState()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":          30000,
+			"podCountCheckMode": extcommon.PodCountEqualsDesiredCount,
+			"buffer":            30000,
+			"maxRestarts":       1,
+			"baseline":          600000,
+			"multiplier":        3,
+			"ignoredReasons":    []string{"FailedScheduling"},
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}
+
+	// When
+	_, err := prepareCompositeCheckInternal(k8sclient, &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, int32(1), state.Restarts.MaxRestarts)
+	require.Equal(t, map[string]int32{"": 2}, state.Restarts.BaselineRestarts)
+	require.Equal(t, 3, state.EventRate.Multiplier)
+	require.Equal(t, "shop", state.EventRate.Namespace)
+	require.Equal(t, []string{"FailedScheduling"}, state.WarningEvents.IgnoredReasons)
+	require.Equal(t, "^(deployment/checkout|replicaset/checkout-[a-z0-9]+|pod/checkout-[a-z0-9]+-[a-z0-9]+)$", state.WarningEvents.InvolvedObject)
+}
+
+func TestCompositeCheckReportsRestarts(t *testing.T) {
+	// Given
+	builder := compositeCheckTestBuilder(t, true, 3)
+	k8sclient := builder.Build()
+	state := compositeCheckState(time.Now().Add(time.Minute))
+
+	// When
+	result := statusCompositeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Pod restarts check failed: Pods of checkout restarted 3 times, more than the allowed 0: checkout-1 (3)", result.Error.Title)
+}
+
+func TestCompositeCheckReportsWarningEventsOfDeployment(t *testing.T) {
+	// Given
+	builder := compositeCheckTestBuilder(t, true, 0).WithEvents(
+		compositeWarningEvent("Pod", "checkout-7d4b9c-x7k2p", "BackOff"),
+		compositeWarningEvent("Pod", "checkout-service-7d4b9c-x7k2p", "BackOff"),
+	)
+	k8sclient := builder.Build()
+	state := compositeCheckState(time.Now().Add(time.Minute))
+
+	// When
+	result := statusCompositeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Warning events check failed: 1 warning events occurred: pod/checkout-7d4b9c-x7k2p BackOff: Back-off restarting failed container", result.Error.Title)
+}
+
+func TestCompositeCheckIgnoresExpectedWarningEvents(t *testing.T) {
+	// Given
+	builder := compositeCheckTestBuilder(t, true, 0).WithEvents(compositeWarningEvent("Pod", "checkout-7d4b9c-x7k2p", "BackOff"))
+	k8sclient := builder.Build()
+	state := compositeCheckState(time.Now().Add(time.Minute))
+	state.WarningEvents.IgnoredReasons = []string{"BackOff"}
+
+	// When
+	result := statusCompositeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func compositeCheckState(timeout time.Time) CompositeCheckState {
+	return CompositeCheckState{
+		Timeout:          timeout,
+		PodCount:         PodCountCheckState{Timeout: timeout, PodCountCheckMode: extcommon.PodCountEqualsDesiredCount, Namespace: "shop", Deployment: "checkout"},
+		StuckTermination: StuckTerminationCheckState{Timeout: timeout, Buffer: 30 * time.Second, Namespace: "shop", Deployment: "checkout"},
+		ContainersReady:  PodContainersReadyCheckState{Timeout: timeout, Namespace: "shop", Deployment: "checkout"},
+		ReadinessGates:   ReadinessGateCheckState{Timeout: timeout, Namespace: "shop", Deployment: "checkout"},
+		Restarts:         extpod.RestartCountCheckState{Timeout: timeout, Namespace: "shop", Deployment: "checkout", BaselineRestarts: map[string]int32{}},
+		EventRate:        EventRateCheckState{Start: time.Now(), Duration: time.Minute, Namespace: "shop", BaselineRate: 1, Multiplier: 2},
+		WarningEvents:    extevents.WarningEventsCheckState{Start: time.Now().Add(-time.Second), Timeout: timeout, Namespace: "shop", InvolvedObject: deploymentInvolvedObjects("checkout")},
+	}
+}
+
+func createCompositeCheckTestClient(t *testing.T, sidecarReady bool) *client.Client {
+	return compositeCheckTestBuilder(t, sidecarReady, 0).Build()
+}
+
+func compositeCheckTestBuilder(t *testing.T, sidecarReady bool, restarts int32) *testsupport.ClientBuilder {
+	labels := map[string]string{"app": "checkout"}
+	return testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(int32(1)),
+				Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: labels}),
+			},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas: 1,
+			},
+		}).
+		WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-1", Namespace: "shop", Labels: labels},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", Ready: true, RestartCount: restarts},
+					{Name: "sidecar", Ready: sidecarReady},
+				},
+			},
+		})
+}
+
+func compositeWarningEvent(kind string, name string, reason string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name + "." + reason, Namespace: "shop"},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Namespace: "shop", Name: name},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        "Back-off restarting failed container",
+		LastTimestamp:  metav1.Time{Time: time.Now()},
+	}
+}
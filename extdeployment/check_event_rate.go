@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"time"
+)
+
+type EventRateCheckAction struct {
+}
+
+type EventRateCheckState struct {
+	Cluster   string
+	Start     time.Time
+	Duration  time.Duration
+	Namespace string
+	// BaselineRate is the number of warning events per minute in the namespace before the experiment.
+	BaselineRate float64
+	Multiplier   int
+}
+
+type EventRateCheckConfig struct {
+	Duration   int
+	Baseline   int
+	Multiplier int
+}
+
+func NewEventRateCheckAction() action_kit_sdk.Action[EventRateCheckState] {
+	return EventRateCheckAction{}
+}
+
+var _ action_kit_sdk.Action[EventRateCheckState] = (*EventRateCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[EventRateCheckState] = (*EventRateCheckAction)(nil)
+
+func (f EventRateCheckAction) NewEmptyState() EventRateCheckState {
+	return EventRateCheckState{}
+}
+
+func (f EventRateCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          eventRateCheckActionId,
+		Label:       "Warning Event Rate",
+		Description: "Verify that the rate of warning events in the namespace of the deployment doesn't increase beyond a multiple of the rate before the experiment.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the check measure the event rate."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "baseline",
+				Label:        "Baseline",
+				Description:  extutil.Ptr("The period before the check which is used to measure the baseline rate."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("10m"),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Advanced:     extutil.Ptr(true),
+			},
+			{
+				Name:         "multiplier",
+				Label:        "Multiplier",
+				Description:  extutil.Ptr("By how much may the rate of warning events exceed the baseline rate."),
+				Type:         action_kit_api.Integer,
+				DefaultValue: extutil.Ptr("2"),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f EventRateCheckAction) Prepare(_ context.Context, state *EventRateCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareEventRateCheckInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareEventRateCheckInternal(k8s *client.Client, state *EventRateCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config EventRateCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	baseline := time.Millisecond * time.Duration(config.Baseline)
+	if baseline <= 0 {
+		return nil, extension_kit.ToError("The baseline must be positive.", nil)
+	}
+	state.Duration = time.Millisecond * time.Duration(config.Duration)
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Multiplier = config.Multiplier
+
+	// A quiet namespace is assumed to have at least one event in the baseline, otherwise a single warning would fail the check.
+	count := len(k8s.WarningEventsInNamespace(state.Namespace, timeNow().Add(-baseline)))
+	if count == 0 {
+		count = 1
+	}
+	state.BaselineRate = float64(count) / baseline.Minutes()
+	return nil, nil
+}
+
+func (f EventRateCheckAction) Start(_ context.Context, state *EventRateCheckState) (*action_kit_api.StartResult, error) {
+	state.Start = timeNow()
+	return nil, nil
+}
+
+func (f EventRateCheckAction) Status(_ context.Context, state *EventRateCheckState) (*action_kit_api.StatusResult, error) {
+	return statusEventRateCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusEventRateCheckInternal(k8s *client.Client, state *EventRateCheckState) *action_kit_api.StatusResult {
+	now := timeNow()
+	count := len(k8s.WarningEventsInNamespace(state.Namespace, state.Start))
+
+	// The allowed number of events is known upfront, so the check fails as soon as it is exceeded instead of waiting for the end.
+	allowed := state.BaselineRate * float64(state.Multiplier) * state.Duration.Minutes()
+	if float64(count) > allowed {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Namespace %s had %d warning events, more than %d times the baseline of %.1f per minute.", state.Namespace, count, state.Multiplier, state.BaselineRate),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: now.After(state.Start.Add(state.Duration)),
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestEventRateCheckMeasuresBaselineOfWarningEventsInNamespace(t *testing.T) {
+	// Given
+	now := time.Now()
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithEvents(
+			warningEvent("shop", "a", now.Add(-5*time.Minute)),
+			warningEvent("shop", "b", now.Add(-3*time.Minute)),
+			warningEvent("shop", "too-old", now.Add(-20*time.Minute)),
+			warningEvent("other", "c", now.Add(-time.Minute)),
+			normalEvent("shop", "d", now.Add(-time.Minute)),
+		).
+		Build()
+	state := NewEventRateCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareEventRateCheckInternal(k8sclient, &state, eventRateRequest())
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, 0.2, state.BaselineRate)
+	require.Equal(t, time.Minute, state.Duration)
+	require.Equal(t, 2, state.Multiplier)
+}
+
+func TestEventRateCheckAssumesOneEventForQuietBaseline(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	state := NewEventRateCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareEventRateCheckInternal(k8sclient, &state, eventRateRequest())
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, 0.1, state.BaselineRate)
+}
+
+func TestEventRateCheckPassesForStableRate(t *testing.T) {
+	// Given
+	start := time.Now()
+	builder := testsupport.NewClientBuilder(t)
+	k8sclient := builder.Build()
+	state := eventRateTestState(start)
+	_, err := builder.Clientset.CoreV1().Events("shop").Create(context.Background(), warningEvent("shop", "a", start.Add(time.Second)), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(k8sclient.WarningEventsInNamespace("shop", start)) == 1 }, time.Second, 10*time.Millisecond)
+
+	// When
+	timeNow = func() time.Time { return start.Add(2 * time.Minute) }
+	defer func() { timeNow = time.Now }()
+	result := statusEventRateCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestEventRateCheckFailsForSpikingRate(t *testing.T) {
+	// Given
+	start := time.Now()
+	builder := testsupport.NewClientBuilder(t)
+	k8sclient := builder.Build()
+	state := eventRateTestState(start)
+	for i := 0; i < 3; i++ {
+		_, err := builder.Clientset.CoreV1().Events("shop").Create(context.Background(), warningEvent("shop", fmt.Sprintf("spike-%d", i), start.Add(time.Second)), metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return len(k8sclient.WarningEventsInNamespace("shop", start)) == 3 }, time.Second, 10*time.Millisecond)
+
+	// When
+	result := statusEventRateCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Namespace shop had 3 warning events, more than 2 times the baseline of 1.0 per minute.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func eventRateRequest() action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":   1000 * 60,
+			"baseline":   1000 * 60 * 10,
+			"multiplier": 2,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}
+}
+
+func eventRateTestState(start time.Time) EventRateCheckState {
+	return EventRateCheckState{
+		Start:        start,
+		Duration:     time.Minute,
+		Namespace:    "shop",
+		BaselineRate: 1,
+		Multiplier:   2,
+	}
+}
+
+func warningEvent(namespace string, name string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:    metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:          corev1.EventTypeWarning,
+		LastTimestamp: metav1.NewTime(lastSeen),
+	}
+}
+
+func normalEvent(namespace string, name string, lastSeen time.Time) *corev1.Event {
+	event := warningEvent(namespace, name, lastSeen)
+	event.Type = corev1.EventTypeNormal
+	return event
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"k8s.io/utils/strings/slices"
+	"strings"
+	"time"
+)
+
+type EvictionCheckAction struct {
+}
+
+type EvictionCheckState struct {
+	Cluster    string
+	Timeout    time.Time
+	Namespace  string
+	Deployment string
+	// PreviouslyEvicted holds pods which were already evicted when the check was started.
+	PreviouslyEvicted []string
+}
+
+type EvictionCheckConfig struct {
+	Duration int
+}
+
+func NewEvictionCheckAction() action_kit_sdk.Action[EvictionCheckState] {
+	return EvictionCheckAction{}
+}
+
+var _ action_kit_sdk.Action[EvictionCheckState] = (*EvictionCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[EvictionCheckState] = (*EvictionCheckAction)(nil)
+
+func (f EvictionCheckAction) NewEmptyState() EvictionCheckState {
+	return EvictionCheckState{}
+}
+
+func (f EvictionCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          evictionCheckActionId,
+		Label:       "Pod Evictions",
+		Description: "Verify that no pod of the deployment is evicted by the kubelet, e.g. due to memory or disk pressure on the node.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the check watch for evictions."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f EvictionCheckAction) Prepare(_ context.Context, state *EvictionCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config EvictionCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f EvictionCheckAction) Start(_ context.Context, state *EvictionCheckState) (*action_kit_api.StartResult, error) {
+	startEvictionCheckInternal(client.ForCluster(state.Cluster), state)
+	return nil, nil
+}
+
+func startEvictionCheckInternal(k8s *client.Client, state *EvictionCheckState) {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return
+	}
+	for _, pod := range k8s.PodsByDeployment(deployment) {
+		if client.IsEvicted(pod) {
+			state.PreviouslyEvicted = append(state.PreviouslyEvicted, pod.Name)
+		}
+	}
+}
+
+func (f EvictionCheckAction) Status(_ context.Context, state *EvictionCheckState) (*action_kit_api.StatusResult, error) {
+	return statusEvictionCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusEvictionCheckInternal(k8s *client.Client, state *EvictionCheckState) *action_kit_api.StatusResult {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var evicted []string
+	for _, pod := range k8s.PodsByDeployment(deployment) {
+		if !client.IsEvicted(pod) || slices.Contains(state.PreviouslyEvicted, pod.Name) {
+			continue
+		}
+		if pod.Status.Message != "" {
+			evicted = append(evicted, fmt.Sprintf("%s on %s (%s)", pod.Name, pod.Spec.NodeName, pod.Status.Message))
+		} else {
+			evicted = append(evicted, fmt.Sprintf("%s on %s", pod.Name, pod.Spec.NodeName))
+		}
+	}
+
+	if len(evicted) > 0 {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has evicted pods: %s", state.Deployment, strings.Join(evicted, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: timeNow().After(state.Timeout),
+	}
+}
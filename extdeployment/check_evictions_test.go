@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestEvictionCheckFailsForEvictedPod(t *testing.T) {
+	// Given
+	k8sclient := createEvictionTestClient(t, evictedPod("checkout-1"))
+	state := EvictionCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusEvictionCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout has evicted pods: checkout-1 on worker-1 (The node was low on resource: memory.)", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestEvictionCheckIgnoresPodsEvictedBeforeStart(t *testing.T) {
+	// Given
+	k8sclient := createEvictionTestClient(t, evictedPod("checkout-1"))
+	state := EvictionCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+	startEvictionCheckInternal(k8sclient, &state)
+
+	// When
+	result := statusEvictionCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Equal(t, []string{"checkout-1"}, state.PreviouslyEvicted)
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func evictedPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{"app": "checkout"}},
+		Spec:       corev1.PodSpec{NodeName: "worker-1"},
+		Status: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+	}
+}
+
+func createEvictionTestClient(t *testing.T, pods ...*corev1.Pod) *client.Client {
+	return testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}}),
+			},
+		}).
+		WithPods(pods...).
+		Build()
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ImageConsistencyCheckAction struct {
+}
+
+type ImageConsistencyCheckState struct {
+	Cluster    string
+	Timeout    time.Time
+	Namespace  string
+	Deployment string
+}
+
+type ImageConsistencyCheckConfig struct {
+	Duration int
+}
+
+func NewImageConsistencyCheckAction() action_kit_sdk.Action[ImageConsistencyCheckState] {
+	return ImageConsistencyCheckAction{}
+}
+
+var _ action_kit_sdk.Action[ImageConsistencyCheckState] = (*ImageConsistencyCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ImageConsistencyCheckState] = (*ImageConsistencyCheckAction)(nil)
+
+func (f ImageConsistencyCheckAction) NewEmptyState() ImageConsistencyCheckState {
+	return ImageConsistencyCheckState{}
+}
+
+func (f ImageConsistencyCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          imageConsistencyCheckActionId,
+		Label:       "Image Consistency",
+		Description: "Verify that all pods of the deployment run the same image per container. Mixed images indicate an incomplete rollout.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the images be checked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f ImageConsistencyCheckAction) Prepare(_ context.Context, state *ImageConsistencyCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config ImageConsistencyCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f ImageConsistencyCheckAction) Start(_ context.Context, _ *ImageConsistencyCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f ImageConsistencyCheckAction) Status(_ context.Context, state *ImageConsistencyCheckState) (*action_kit_api.StatusResult, error) {
+	return statusImageConsistencyCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusImageConsistencyCheckInternal(k8s *client.Client, state *ImageConsistencyCheckState) *action_kit_api.StatusResult {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	imagesByContainer := map[string]map[string]bool{}
+	for _, pod := range k8s.PodsByDeployment(deployment) {
+		// Terminating pods are expected to run the image of the previous revision.
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Image == "" {
+				continue
+			}
+			if imagesByContainer[status.Name] == nil {
+				imagesByContainer[status.Name] = map[string]bool{}
+			}
+			imagesByContainer[status.Name][status.Image] = true
+		}
+	}
+
+	var mixed []string
+	for container, images := range imagesByContainer {
+		if len(images) > 1 {
+			mixed = append(mixed, fmt.Sprintf("%s (%s)", container, strings.Join(sortedKeys(images), ", ")))
+		}
+	}
+
+	if len(mixed) > 0 {
+		sort.Strings(mixed)
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has pods running different images: %s", state.Deployment, strings.Join(mixed, "; ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: timeNow().After(state.Timeout),
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestImageConsistencyCheckSucceedsForConsistentImages(t *testing.T) {
+	// Given
+	k8sclient := createImageConsistencyTestClient(t, "shop/checkout:1.0")
+	state := ImageConsistencyCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusImageConsistencyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestImageConsistencyCheckFailsForMixedImages(t *testing.T) {
+	// Given
+	k8sclient := createImageConsistencyTestClient(t, "shop/checkout:1.1")
+	state := ImageConsistencyCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusImageConsistencyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout has pods running different images: app (shop/checkout:1.0, shop/checkout:1.1)", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestImageConsistencyCheckIgnoresTerminatingPods(t *testing.T) {
+	// Given
+	labels := map[string]string{"app": "checkout"}
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: labels}),
+			},
+		}).
+		WithPods(imageConsistencyTestPod("checkout-1", labels, "shop/checkout:1.1"), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "checkout-0",
+				Namespace:         "shop",
+				Labels:            labels,
+				DeletionTimestamp: extutil.Ptr(metav1.Now()),
+				Finalizers:        []string{"kubernetes"},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Image: "shop/checkout:1.0"}},
+			},
+		}).
+		Build()
+	state := ImageConsistencyCheckState{
+		Timeout:    time.Now().Add(-time.Second),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusImageConsistencyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func createImageConsistencyTestClient(t *testing.T, secondImage string) *client.Client {
+	labels := map[string]string{"app": "checkout"}
+	return testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: labels}),
+			},
+		}).
+		WithPods(
+			imageConsistencyTestPod("checkout-1", labels, "shop/checkout:1.0"),
+			imageConsistencyTestPod("checkout-2", labels, secondImage),
+		).
+		Build()
+}
+
+func imageConsistencyTestPod(name string, labels map[string]string, image string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: labels},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Image: image},
+				{Name: "sidecar", Image: "envoy:1.28"},
+			},
+		},
+	}
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	appsv1 "k8s.io/api/apps/v1"
+	"strconv"
+	"time"
+)
+
+type NoUnexpectedRolloutCheckAction struct {
+}
+
+type NoUnexpectedRolloutCheckState struct {
+	Cluster    string
+	Timeout    time.Time
+	Namespace  string
+	Deployment string
+	// PodTemplateHash identifies the newest replicaset when the check was prepared, empty if there was none.
+	PodTemplateHash string
+}
+
+type NoUnexpectedRolloutCheckConfig struct {
+	Duration int
+}
+
+func NewNoUnexpectedRolloutCheckAction() action_kit_sdk.Action[NoUnexpectedRolloutCheckState] {
+	return NoUnexpectedRolloutCheckAction{}
+}
+
+var _ action_kit_sdk.Action[NoUnexpectedRolloutCheckState] = (*NoUnexpectedRolloutCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[NoUnexpectedRolloutCheckState] = (*NoUnexpectedRolloutCheckAction)(nil)
+
+func (f NoUnexpectedRolloutCheckAction) NewEmptyState() NoUnexpectedRolloutCheckState {
+	return NoUnexpectedRolloutCheckState{}
+}
+
+func (f NoUnexpectedRolloutCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          noUnexpectedRolloutCheckActionId,
+		Label:       "No Unexpected Rollout",
+		Description: "Verify that the deployment is not rolled out during the experiment, e.g. by a deploy pipeline, which would tamper with the results.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the check watch for new replicasets."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f NoUnexpectedRolloutCheckAction) Prepare(_ context.Context, state *NoUnexpectedRolloutCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareNoUnexpectedRolloutCheckInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareNoUnexpectedRolloutCheckInternal(k8s *client.Client, state *NoUnexpectedRolloutCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config NoUnexpectedRolloutCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Deployment %s not found", state.Deployment), nil)
+	}
+	if newest := newestReplicaSet(k8s.ReplicaSetsByDeployment(deployment)); newest != nil {
+		state.PodTemplateHash = newest.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	}
+	return nil, nil
+}
+
+func (f NoUnexpectedRolloutCheckAction) Start(_ context.Context, _ *NoUnexpectedRolloutCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f NoUnexpectedRolloutCheckAction) Status(_ context.Context, state *NoUnexpectedRolloutCheckState) (*action_kit_api.StatusResult, error) {
+	return statusNoUnexpectedRolloutCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusNoUnexpectedRolloutCheckInternal(k8s *client.Client, state *NoUnexpectedRolloutCheckState) *action_kit_api.StatusResult {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	newest := newestReplicaSet(k8s.ReplicaSetsByDeployment(deployment))
+	if newest != nil && newest.Labels[appsv1.DefaultDeploymentUniqueLabelKey] != state.PodTemplateHash {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s was rolled out during the check: replicaset %s (revision %s) appeared.", state.Deployment, newest.Name, newest.Annotations[revisionAnnotation]),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: timeNow().After(state.Timeout),
+	}
+}
+
+// newestReplicaSet returns the replicaset with the highest revision, or nil if there are none.
+func newestReplicaSet(replicaSets []*appsv1.ReplicaSet) *appsv1.ReplicaSet {
+	var newest *appsv1.ReplicaSet
+	newestRevision := int64(-1)
+	for _, replicaSet := range replicaSets {
+		revision, err := strconv.ParseInt(replicaSet.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		if revision > newestRevision {
+			newest = replicaSet
+			newestRevision = revision
+		}
+	}
+	return newest
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+	"time"
+)
+
+func TestNoUnexpectedRolloutCheckSnapshotsNewestReplicaSet(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithDeployments(unexpectedRolloutDeployment()).
+		WithReplicaSets(
+			ownedReplicaSet("checkout-5c4b", "5c4b", "1"),
+			ownedReplicaSet("checkout-7d9f", "7d9f", "2"),
+		).
+		Build()
+	var state NoUnexpectedRolloutCheckState
+
+	// When
+	_, err := prepareNoUnexpectedRolloutCheckInternal(k8sclient, &state, noUnexpectedRolloutRequest())
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "7d9f", state.PodTemplateHash)
+}
+
+func TestNoUnexpectedRolloutCheckPassesWithoutNewReplicaSet(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithDeployments(unexpectedRolloutDeployment()).
+		WithReplicaSets(ownedReplicaSet("checkout-7d9f", "7d9f", "2")).
+		Build()
+	var state NoUnexpectedRolloutCheckState
+	_, err := prepareNoUnexpectedRolloutCheckInternal(k8sclient, &state, noUnexpectedRolloutRequest())
+	require.NoError(t, err)
+
+	// When
+	timeNow = func() time.Time { return state.Timeout.Add(time.Second) }
+	defer func() { timeNow = time.Now }()
+	result := statusNoUnexpectedRolloutCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestNoUnexpectedRolloutCheckFailsWhenNewReplicaSetAppears(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).
+		WithDeployments(unexpectedRolloutDeployment()).
+		WithReplicaSets(ownedReplicaSet("checkout-7d9f", "7d9f", "2"))
+	k8sclient := builder.Build()
+	var state NoUnexpectedRolloutCheckState
+	_, err := prepareNoUnexpectedRolloutCheckInternal(k8sclient, &state, noUnexpectedRolloutRequest())
+	require.NoError(t, err)
+
+	result := statusNoUnexpectedRolloutCheckInternal(k8sclient, &state)
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+
+	// When
+	_, err = builder.Clientset.AppsV1().ReplicaSets("shop").Create(context.Background(), ownedReplicaSet("checkout-9a1e", "9a1e", "3"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return statusNoUnexpectedRolloutCheckInternal(k8sclient, &state).Completed
+	}, time.Second, 10*time.Millisecond)
+	result = statusNoUnexpectedRolloutCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Equal(t, "checkout was rolled out during the check: replicaset checkout-9a1e (revision 3) appeared.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestNoUnexpectedRolloutCheckIgnoresReplicaSetsOfOtherDeployments(t *testing.T) {
+	// Given
+	foreign := ownedReplicaSet("checkout-canary-1b2c", "1b2c", "5")
+	foreign.OwnerReferences[0].UID = "other-uid"
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithDeployments(unexpectedRolloutDeployment()).
+		WithReplicaSets(ownedReplicaSet("checkout-7d9f", "7d9f", "2"), foreign).
+		Build()
+	state := NoUnexpectedRolloutCheckState{
+		Timeout:         time.Now().Add(time.Minute),
+		Namespace:       "shop",
+		Deployment:      "checkout",
+		PodTemplateHash: "7d9f",
+	}
+
+	// When
+	result := statusNoUnexpectedRolloutCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestNoUnexpectedRolloutCheckDeploymentNotFound(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	var state NoUnexpectedRolloutCheckState
+
+	// When
+	_, err := prepareNoUnexpectedRolloutCheckInternal(k8sclient, &state, noUnexpectedRolloutRequest())
+
+	// Then
+	require.EqualError(t, err, "Deployment checkout not found")
+}
+
+func noUnexpectedRolloutRequest() action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 1000 * 60,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}
+}
+
+func unexpectedRolloutDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "checkout",
+			Namespace: "shop",
+			UID:       "checkout-uid",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}}),
+		},
+	}
+}
+
+func ownedReplicaSet(name string, podTemplateHash string, revision string) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "shop",
+			Labels:      map[string]string{"app": "checkout", appsv1.DefaultDeploymentUniqueLabelKey: podTemplateHash},
+			Annotations: map[string]string{revisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Deployment", Name: "checkout", UID: types.UID("checkout-uid"), Controller: extutil.Ptr(true)},
+			},
+		},
+	}
+}
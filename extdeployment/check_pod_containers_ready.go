@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"strings"
+	"time"
+)
+
+type PodContainersReadyCheckAction struct {
+}
+
+type PodContainersReadyCheckState struct {
+	Cluster    string
+	Timeout    time.Time
+	Namespace  string
+	Deployment string
+}
+
+type PodContainersReadyCheckConfig struct {
+	Duration int
+}
+
+func NewPodContainersReadyCheckAction() action_kit_sdk.Action[PodContainersReadyCheckState] {
+	return PodContainersReadyCheckAction{}
+}
+
+var _ action_kit_sdk.Action[PodContainersReadyCheckState] = (*PodContainersReadyCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[PodContainersReadyCheckState] = (*PodContainersReadyCheckAction)(nil)
+
+func (f PodContainersReadyCheckAction) NewEmptyState() PodContainersReadyCheckState {
+	return PodContainersReadyCheckState{}
+}
+
+func (f PodContainersReadyCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          podContainersReadyCheckActionId,
+		Label:       "Pod Containers Ready",
+		Description: "Verify that all containers of all pods of the deployment are ready. Catches pods reported as e.g. 1/2 ready, which is easily missed when only looking at running pods.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the containers be checked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f PodContainersReadyCheckAction) Prepare(_ context.Context, state *PodContainersReadyCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config PodContainersReadyCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f PodContainersReadyCheckAction) Start(_ context.Context, _ *PodContainersReadyCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f PodContainersReadyCheckAction) Status(_ context.Context, state *PodContainersReadyCheckState) (*action_kit_api.StatusResult, error) {
+	return statusPodContainersReadyCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusPodContainersReadyCheckInternal(k8s *client.Client, state *PodContainersReadyCheckState) *action_kit_api.StatusResult {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var notReady []string
+	for _, pod := range k8s.PodsByDeployment(deployment) {
+		// Containers of terminating pods are expected to shut down.
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if ready, total := client.ContainersReady(pod); ready < total {
+			notReady = append(notReady, fmt.Sprintf("%s (%d/%d)", pod.Name, ready, total))
+		}
+	}
+
+	if len(notReady) > 0 {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has pods with containers not ready: %s", state.Deployment, strings.Join(notReady, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: timeNow().After(state.Timeout),
+	}
+}
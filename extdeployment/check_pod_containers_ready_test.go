@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestPodContainersReadyCheckSucceedsForFullyReadyPods(t *testing.T) {
+	// Given
+	k8sclient := createPodContainersReadyTestClient(t, true)
+	state := PodContainersReadyCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusPodContainersReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestPodContainersReadyCheckFailsForPartiallyReadyPods(t *testing.T) {
+	// Given
+	k8sclient := createPodContainersReadyTestClient(t, false)
+	state := PodContainersReadyCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusPodContainersReadyCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout has pods with containers not ready: checkout-1 (1/2)", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func createPodContainersReadyTestClient(t *testing.T, sidecarReady bool) *client.Client {
+	labels := map[string]string{"app": "checkout"}
+	return testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: labels}),
+			},
+		}).
+		WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-1", Namespace: "shop", Labels: labels},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", Ready: true},
+					{Name: "sidecar", Ready: sidecarReady},
+				},
+			},
+		}, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-2", Namespace: "shop", Labels: labels},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", Ready: true},
+				},
+			},
+		}).
+		Build()
+}
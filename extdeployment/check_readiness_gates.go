@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"strings"
+	"time"
+)
+
+type ReadinessGateCheckAction struct {
+}
+
+type ReadinessGateCheckState struct {
+	Cluster    string
+	Timeout    time.Time
+	Namespace  string
+	Deployment string
+}
+
+type ReadinessGateCheckConfig struct {
+	Duration int
+}
+
+func NewReadinessGateCheckAction() action_kit_sdk.Action[ReadinessGateCheckState] {
+	return ReadinessGateCheckAction{}
+}
+
+var _ action_kit_sdk.Action[ReadinessGateCheckState] = (*ReadinessGateCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ReadinessGateCheckState] = (*ReadinessGateCheckAction)(nil)
+
+func (f ReadinessGateCheckAction) NewEmptyState() ReadinessGateCheckState {
+	return ReadinessGateCheckState{}
+}
+
+func (f ReadinessGateCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          readinessGateCheckActionId,
+		Label:       "Readiness Gates",
+		Description: "Verify that the readiness gates of all pods of the deployment are met, e.g. that the pods are registered at a load balancer.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the readiness gates be checked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f ReadinessGateCheckAction) Prepare(_ context.Context, state *ReadinessGateCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config ReadinessGateCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f ReadinessGateCheckAction) Start(_ context.Context, _ *ReadinessGateCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f ReadinessGateCheckAction) Status(_ context.Context, state *ReadinessGateCheckState) (*action_kit_api.StatusResult, error) {
+	return statusReadinessGateCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusReadinessGateCheckInternal(k8s *client.Client, state *ReadinessGateCheckState) *action_kit_api.StatusResult {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var unmet []string
+	for _, pod := range k8s.PodsByDeployment(deployment) {
+		// Terminating pods are expected to be deregistered.
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if gates := client.UnmetReadinessGates(pod); len(gates) > 0 {
+			unmet = append(unmet, fmt.Sprintf("%s (%s)", pod.Name, strings.Join(gates, ", ")))
+		}
+	}
+
+	if len(unmet) > 0 {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has pods with unmet readiness gates: %s", state.Deployment, strings.Join(unmet, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: timeNow().After(state.Timeout),
+	}
+}
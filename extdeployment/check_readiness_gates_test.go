@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+const loadBalancerGate = corev1.PodConditionType("target-health.elbv2.k8s.aws/checkout")
+
+func TestReadinessGateCheckSucceedsForMetGates(t *testing.T) {
+	// Given
+	k8sclient := createReadinessGateTestClient(t, corev1.ConditionTrue)
+	state := ReadinessGateCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusReadinessGateCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestReadinessGateCheckFailsForUnmetGates(t *testing.T) {
+	// Given
+	k8sclient := createReadinessGateTestClient(t, corev1.ConditionFalse)
+	state := ReadinessGateCheckState{
+		Timeout:    time.Now().Add(time.Minute),
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusReadinessGateCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout has pods with unmet readiness gates: checkout-1 (target-health.elbv2.k8s.aws/checkout)", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func createReadinessGateTestClient(t *testing.T, gateStatus corev1.ConditionStatus) *client.Client {
+	labels := map[string]string{"app": "checkout"}
+	return testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: labels}),
+			},
+		}).
+		WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-1", Namespace: "shop", Labels: labels},
+			Spec: corev1.PodSpec{
+				ReadinessGates: []corev1.PodReadinessGate{{ConditionType: loadBalancerGate}},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: loadBalancerGate, Status: gateStatus}},
+			},
+		}, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-2", Namespace: "shop", Labels: labels},
+		}).
+		Build()
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+// revisionAnnotation is maintained by the deployment controller on deployments and their replicasets.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+type RolloutProgressCheckAction struct {
+}
+
+type RolloutProgressCheckState struct {
+	Cluster    string
+	Timeout    time.Time
+	Namespace  string
+	Deployment string
+}
+
+type RolloutProgressCheckConfig struct {
+	Duration int
+}
+
+func NewRolloutProgressCheckAction() action_kit_sdk.Action[RolloutProgressCheckState] {
+	return RolloutProgressCheckAction{}
+}
+
+var _ action_kit_sdk.Action[RolloutProgressCheckState] = (*RolloutProgressCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[RolloutProgressCheckState] = (*RolloutProgressCheckAction)(nil)
+
+func (f RolloutProgressCheckAction) NewEmptyState() RolloutProgressCheckState {
+	return RolloutProgressCheckState{}
+}
+
+func (f RolloutProgressCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          rolloutProgressCheckActionId,
+		Label:       "Rollout Progress",
+		Description: "Verify that the rollout of the deployment settles within the timeout, i.e. all replicas are updated, the latest generation is observed and no pods of old replicasets remain.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Timeout",
+				Description:  extutil.Ptr("How long should the check wait for the rollout to settle."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("5m"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f RolloutProgressCheckAction) Prepare(_ context.Context, state *RolloutProgressCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config RolloutProgressCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f RolloutProgressCheckAction) Start(_ context.Context, _ *RolloutProgressCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f RolloutProgressCheckAction) Status(_ context.Context, state *RolloutProgressCheckState) (*action_kit_api.StatusResult, error) {
+	return statusRolloutProgressCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusRolloutProgressCheckInternal(k8s *client.Client, state *RolloutProgressCheckState) *action_kit_api.StatusResult {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var pending []string
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		pending = append(pending, fmt.Sprintf("generation %d not observed yet", deployment.Generation))
+	}
+	if desired := desiredReplicas(deployment); deployment.Status.UpdatedReplicas != desired {
+		pending = append(pending, fmt.Sprintf("%d of %d replicas updated", deployment.Status.UpdatedReplicas, desired))
+	}
+	if old := oldReplicaSetsWithPods(k8s, deployment); len(old) > 0 {
+		pending = append(pending, fmt.Sprintf("old replicasets remain: %s", strings.Join(old, ", ")))
+	}
+
+	if len(pending) == 0 {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+		}
+	}
+
+	if timeNow().After(state.Timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Rollout of %s did not settle: %s (%s).", state.Deployment, strings.Join(pending, ", "), rolloutConditions(deployment)),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: false,
+	}
+}
+
+// oldReplicaSetsWithPods returns the sorted names of the replicasets owning pods of the deployment, which don't belong to its current revision.
+func oldReplicaSetsWithPods(k8s *client.Client, deployment *appsv1.Deployment) []string {
+	revision := deployment.Annotations[revisionAnnotation]
+	old := make(map[string]bool)
+	for _, pod := range k8s.PodsByDeployment(deployment) {
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil || owner.Kind != "ReplicaSet" {
+			continue
+		}
+		replicaSet := k8s.ReplicaSetByNamespaceAndName(pod.Namespace, owner.Name)
+		if replicaSet != nil && replicaSet.Annotations[revisionAnnotation] != revision {
+			old[replicaSet.Name] = true
+		}
+	}
+
+	var names []string
+	for name := range old {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rolloutConditions formats the progressing and available conditions of the deployment, e.g. "Progressing=False (ProgressDeadlineExceeded)".
+func rolloutConditions(deployment *appsv1.Deployment) string {
+	var conditions []string
+	for _, conditionType := range []appsv1.DeploymentConditionType{appsv1.DeploymentProgressing, appsv1.DeploymentAvailable} {
+		condition := deploymentCondition(deployment, conditionType)
+		if condition == nil {
+			conditions = append(conditions, fmt.Sprintf("%s=Unknown", conditionType))
+		} else if condition.Message != "" {
+			conditions = append(conditions, fmt.Sprintf("%s=%s (%s: %s)", conditionType, condition.Status, condition.Reason, condition.Message))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("%s=%s (%s)", conditionType, condition.Status, condition.Reason))
+		}
+	}
+	return strings.Join(conditions, ", ")
+}
+
+func deploymentCondition(deployment *appsv1.Deployment, conditionType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i, condition := range deployment.Status.Conditions {
+		if condition.Type == conditionType {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+	return nil
+}
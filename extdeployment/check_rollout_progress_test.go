@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestRolloutProgressCheckCompletesForSettledRollout(t *testing.T) {
+	// Given
+	k8sclient := createRolloutProgressTestClient(t, 2, appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2},
+		replicaSetPod("checkout-new-1", "checkout-new"),
+		replicaSetPod("checkout-new-2", "checkout-new"),
+	)
+	state := rolloutProgressTestState(time.Now().Add(time.Minute))
+
+	// When
+	result := statusRolloutProgressCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestRolloutProgressCheckKeepsWaitingWhileOldReplicaSetHasPods(t *testing.T) {
+	// Given
+	k8sclient := createRolloutProgressTestClient(t, 2, appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2},
+		replicaSetPod("checkout-new-1", "checkout-new"),
+		replicaSetPod("checkout-old-1", "checkout-old"),
+	)
+	state := rolloutProgressTestState(time.Now().Add(time.Minute))
+
+	// When
+	result := statusRolloutProgressCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestRolloutProgressCheckFailsWithConditionsAfterTimeout(t *testing.T) {
+	// Given
+	k8sclient := createRolloutProgressTestClient(t, 2, appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		UpdatedReplicas:    1,
+		Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+			{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded", Message: "ReplicaSet \"checkout-new\" has timed out progressing."},
+		},
+	},
+		replicaSetPod("checkout-new-1", "checkout-new"),
+		replicaSetPod("checkout-old-1", "checkout-old"),
+	)
+	state := rolloutProgressTestState(time.Now().Add(-time.Second))
+
+	// When
+	result := statusRolloutProgressCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Rollout of checkout did not settle: generation 2 not observed yet, 1 of 2 replicas updated, old replicasets remain: checkout-old (Progressing=False (ProgressDeadlineExceeded: ReplicaSet \"checkout-new\" has timed out progressing.), Available=True (MinimumReplicasAvailable)).", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestRolloutProgressCheckDeploymentNotFound(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	state := rolloutProgressTestState(time.Now().Add(time.Minute))
+
+	// When
+	result := statusRolloutProgressCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Equal(t, "Deployment checkout not found", result.Error.Title)
+	require.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func rolloutProgressTestState(timeout time.Time) RolloutProgressCheckState {
+	return RolloutProgressCheckState{
+		Timeout:    timeout,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+}
+
+func replicaSetPod(name string, replicaSet string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "shop",
+			Labels:    map[string]string{"app": "checkout"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: replicaSet, Controller: extutil.Ptr(true)},
+			},
+		},
+	}
+}
+
+func createRolloutProgressTestClient(t *testing.T, replicas int32, status appsv1.DeploymentStatus, pods ...*corev1.Pod) *client.Client {
+	return testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "checkout",
+				Namespace:   "shop",
+				Generation:  2,
+				Annotations: map[string]string{revisionAnnotation: "2"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(replicas),
+				Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}}),
+			},
+			Status: status,
+		}).
+		WithReplicaSets(
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "checkout-old", Namespace: "shop", Annotations: map[string]string{revisionAnnotation: "1"}}},
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "checkout-new", Namespace: "shop", Annotations: map[string]string{revisionAnnotation: "2"}}},
+		).
+		WithPods(pods...).
+		Build()
+}
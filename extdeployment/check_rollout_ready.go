@@ -12,6 +12,8 @@ import (
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extconversion"
 	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"os/exec"
 	"strings"
 	"time"
@@ -79,6 +81,9 @@ func (f CheckDeploymentRolloutStatusAction) Describe() action_kit_api.ActionDesc
 }
 
 func (f CheckDeploymentRolloutStatusAction) Prepare(_ context.Context, state *CheckDeploymentRolloutStatusState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
 	var config CheckDeploymentRolloutConfig
 	if err := extconversion.Convert(request.Config, &config); err != nil {
 		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
@@ -88,7 +93,6 @@ func (f CheckDeploymentRolloutStatusAction) Prepare(_ context.Context, state *Ch
 	if config.Duration != 0 {
 		timeoutEnd = extutil.Ptr(time.Now().Add(time.Duration(int(time.Millisecond) * config.Duration)).Unix())
 	}
-	state.Cluster = request.Target.Attributes["k8s.cluster-name"][0]
 	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
 	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
 	state.TimeoutEnd = timeoutEnd
@@ -110,13 +114,14 @@ func (f CheckDeploymentRolloutStatusAction) Status(_ context.Context, state *Che
 		}), nil
 	}
 
-	cmd := exec.Command("kubectl",
+	args := append(client.ForCluster(state.Cluster).KubectlContextArgs(),
 		"rollout",
 		"status",
 		"--watch=false",
 		"--namespace",
 		state.Namespace,
 		fmt.Sprintf("deployment/%s", state.Deployment))
+	cmd := exec.Command("kubectl", args...)
 	cmdOut, cmdErr := cmd.CombinedOutput()
 	if cmdErr != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to execute rollout status check: %s", cmdOut), cmdErr)
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	appsv1 "k8s.io/api/apps/v1"
+	"time"
+)
+
+// defaultProgressDeadlineSeconds is applied by the API server when a deployment doesn't specify one.
+const defaultProgressDeadlineSeconds = int32(600)
+
+type RolloutTimeCheckAction struct {
+}
+
+type RolloutTimeCheckState struct {
+	Cluster    string
+	Start      time.Time
+	Budget     time.Duration
+	Namespace  string
+	Deployment string
+}
+
+type RolloutTimeCheckConfig struct {
+	Duration int
+}
+
+func NewRolloutTimeCheckAction() action_kit_sdk.Action[RolloutTimeCheckState] {
+	return RolloutTimeCheckAction{}
+}
+
+var _ action_kit_sdk.Action[RolloutTimeCheckState] = (*RolloutTimeCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[RolloutTimeCheckState] = (*RolloutTimeCheckAction)(nil)
+
+func (f RolloutTimeCheckAction) NewEmptyState() RolloutTimeCheckState {
+	return RolloutTimeCheckState{}
+}
+
+func (f RolloutTimeCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          rolloutTimeCheckActionId,
+		Label:       "Deployment Rollout Time",
+		Description: "Verify that a rollout of the deployment completes within a time budget. The check succeeds once all replicas are updated and available.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find deployment by cluster, namespace and deployment"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.deployment=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Budget",
+				Description:  extutil.Ptr("How long may the rollout take. Must be shorter than the progress deadline of the deployment."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("2m"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f RolloutTimeCheckAction) Prepare(_ context.Context, state *RolloutTimeCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareRolloutTimeCheckInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareRolloutTimeCheckInternal(k8s *client.Client, state *RolloutTimeCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config RolloutTimeCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Budget = time.Millisecond * time.Duration(config.Duration)
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Deployment %s not found", state.Deployment), nil)
+	}
+	progressDeadline := time.Duration(defaultProgressDeadlineSeconds) * time.Second
+	if deployment.Spec.ProgressDeadlineSeconds != nil {
+		progressDeadline = time.Duration(*deployment.Spec.ProgressDeadlineSeconds) * time.Second
+	}
+	if state.Budget >= progressDeadline {
+		return nil, extension_kit.ToError(fmt.Sprintf("Budget %s must be shorter than the progress deadline %s of deployment %s.", state.Budget, progressDeadline, state.Deployment), nil)
+	}
+	return nil, nil
+}
+
+func (f RolloutTimeCheckAction) Start(_ context.Context, state *RolloutTimeCheckState) (*action_kit_api.StartResult, error) {
+	state.Start = timeNow()
+	return nil, nil
+}
+
+func (f RolloutTimeCheckAction) Status(_ context.Context, state *RolloutTimeCheckState) (*action_kit_api.StatusResult, error) {
+	return statusRolloutTimeCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusRolloutTimeCheckInternal(k8s *client.Client, state *RolloutTimeCheckState) *action_kit_api.StatusResult {
+	elapsed := timeNow().Sub(state.Start).Round(time.Millisecond)
+
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	if isRolloutComplete(deployment) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Messages: extutil.Ptr([]action_kit_api.Message{
+				{
+					Message: fmt.Sprintf("Rollout of %s completed after %s.", state.Deployment, elapsed),
+					Level:   extutil.Ptr(action_kit_api.Info),
+				},
+			}),
+		}
+	}
+
+	if elapsed > state.Budget {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Rollout of %s did not complete within %s (elapsed %s, %d of %d replicas updated, %d available).", state.Deployment, state.Budget, elapsed, deployment.Status.UpdatedReplicas, desiredReplicas(deployment), deployment.Status.AvailableReplicas),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: false,
+	}
+}
+
+// isRolloutComplete follows `kubectl rollout status`: the controller observed the latest generation, all desired
+// replicas are updated and available, and no replicas of the previous revision are left.
+func isRolloutComplete(deployment *appsv1.Deployment) bool {
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas >= desiredReplicas(deployment) &&
+		status.Replicas <= status.UpdatedReplicas &&
+		status.AvailableReplicas >= status.UpdatedReplicas
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func TestRolloutTimeCheckPrepareRejectsBudgetExceedingProgressDeadline(t *testing.T) {
+	// Given
+	k8sclient := createRolloutTimeTestClient(t, 1, 0, appsv1.DeploymentStatus{})
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 1000 * 60,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}
+	state := NewRolloutTimeCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareRolloutTimeCheckInternal(k8sclient, &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "must be shorter than the progress deadline")
+}
+
+func TestRolloutTimeCheckCompletesWithinBudget(t *testing.T) {
+	// Given
+	k8sclient := createRolloutTimeTestClient(t, 1, 2, appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           2,
+		UpdatedReplicas:    2,
+		AvailableReplicas:  2,
+	})
+	start := time.Now()
+	timeNow = func() time.Time { return start.Add(5 * time.Second) }
+	defer func() { timeNow = time.Now }()
+	state := RolloutTimeCheckState{
+		Start:      start,
+		Budget:     10 * time.Second,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusRolloutTimeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+	require.Equal(t, "Rollout of checkout completed after 5s.", (*result.Messages)[0].Message)
+}
+
+func TestRolloutTimeCheckFailsWhenBudgetIsExceeded(t *testing.T) {
+	// Given
+	k8sclient := createRolloutTimeTestClient(t, 1, 3, appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           3,
+		UpdatedReplicas:    1,
+		AvailableReplicas:  2,
+	})
+	start := time.Now()
+	timeNow = func() time.Time { return start.Add(15 * time.Second) }
+	defer func() { timeNow = time.Now }()
+	state := RolloutTimeCheckState{
+		Start:      start,
+		Budget:     10 * time.Second,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusRolloutTimeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Rollout of checkout did not complete within 10s (elapsed 15s, 1 of 3 replicas updated, 2 available).", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestRolloutTimeCheckKeepsWaitingWithinBudget(t *testing.T) {
+	// Given
+	k8sclient := createRolloutTimeTestClient(t, 1, 3, appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           3,
+		UpdatedReplicas:    1,
+		AvailableReplicas:  2,
+	})
+	state := RolloutTimeCheckState{
+		Start:      time.Now(),
+		Budget:     10 * time.Second,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusRolloutTimeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestRolloutTimeCheckKeepsWaitingForNewGeneration(t *testing.T) {
+	// Given
+	k8sclient := createRolloutTimeTestClient(t, 2, 2, appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           2,
+		UpdatedReplicas:    2,
+		AvailableReplicas:  2,
+	})
+	state := RolloutTimeCheckState{
+		Start:      time.Now(),
+		Budget:     10 * time.Second,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusRolloutTimeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestRolloutTimeCheckKeepsWaitingForOldReplicas(t *testing.T) {
+	// Given
+	k8sclient := createRolloutTimeTestClient(t, 1, 2, appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           3,
+		UpdatedReplicas:    2,
+		AvailableReplicas:  3,
+	})
+	state := RolloutTimeCheckState{
+		Start:      time.Now(),
+		Budget:     10 * time.Second,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusRolloutTimeCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func createRolloutTimeTestClient(t *testing.T, generation int64, replicas int32, status appsv1.DeploymentStatus) *client.Client {
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.
+		AppsV1().
+		Deployments("shop").
+		Create(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "checkout",
+				Namespace:  "shop",
+				Generation: generation,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas:                extutil.Ptr(replicas),
+				ProgressDeadlineSeconds: extutil.Ptr(int32(30)),
+			},
+			Status: status,
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	return client.CreateClient(clientset, stopCh, "", 0, nil)
+}
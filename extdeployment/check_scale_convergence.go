@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	appsv1 "k8s.io/api/apps/v1"
+	"time"
+)
+
+type ScaleConvergenceCheckAction struct {
+}
+
+type ScaleConvergenceCheckState struct {
+	Cluster    string
+	Start      time.Time
+	Budget     time.Duration
+	Namespace  string
+	Deployment string
+}
+
+type ScaleConvergenceCheckConfig struct {
+	Duration int
+}
+
+func NewScaleConvergenceCheckAction() action_kit_sdk.Action[ScaleConvergenceCheckState] {
+	return ScaleConvergenceCheckAction{}
+}
+
+var _ action_kit_sdk.Action[ScaleConvergenceCheckState] = (*ScaleConvergenceCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ScaleConvergenceCheckState] = (*ScaleConvergenceCheckAction)(nil)
+
+func (f ScaleConvergenceCheckAction) NewEmptyState() ScaleConvergenceCheckState {
+	return ScaleConvergenceCheckState{}
+}
+
+func (f ScaleConvergenceCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          scaleConvergenceCheckActionId,
+		Label:       "Scale Convergence",
+		Description: "Verify that the deployment reaches its desired replica count within a time budget after it was scaled, i.e. that the cluster is able to schedule and start the additional pods.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Budget",
+				Description:  extutil.Ptr("How long may it take until all desired replicas are ready."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("2m"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f ScaleConvergenceCheckAction) Prepare(_ context.Context, state *ScaleConvergenceCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config ScaleConvergenceCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Budget = time.Millisecond * time.Duration(config.Duration)
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f ScaleConvergenceCheckAction) Start(_ context.Context, state *ScaleConvergenceCheckState) (*action_kit_api.StartResult, error) {
+	state.Start = timeNow()
+	return nil, nil
+}
+
+func (f ScaleConvergenceCheckAction) Status(_ context.Context, state *ScaleConvergenceCheckState) (*action_kit_api.StatusResult, error) {
+	return statusScaleConvergenceCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusScaleConvergenceCheckInternal(k8s *client.Client, state *ScaleConvergenceCheckState) *action_kit_api.StatusResult {
+	elapsed := timeNow().Sub(state.Start).Round(time.Millisecond)
+
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	desired := desiredReplicas(deployment)
+	if isScaleConverged(deployment) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Messages: extutil.Ptr([]action_kit_api.Message{
+				{
+					Message: fmt.Sprintf("%s converged to %d replicas after %s.", state.Deployment, desired, elapsed),
+					Level:   extutil.Ptr(action_kit_api.Info),
+				},
+			}),
+		}
+	}
+
+	if elapsed > state.Budget {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s did not converge to %d replicas within %s (%d replicas, %d ready).", state.Deployment, desired, state.Budget, deployment.Status.Replicas, deployment.Status.ReadyReplicas),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: false,
+	}
+}
+
+// desiredReplicas applies the default of the API server for deployments without replicas.
+func desiredReplicas(deployment *appsv1.Deployment) int32 {
+	if deployment.Spec.Replicas == nil {
+		return 1
+	}
+	return *deployment.Spec.Replicas
+}
+
+func isScaleConverged(deployment *appsv1.Deployment) bool {
+	desired := desiredReplicas(deployment)
+	return deployment.Status.Replicas == desired && deployment.Status.ReadyReplicas == desired
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestScaleConvergenceCheckCompletesWhenConverged(t *testing.T) {
+	// Given
+	k8sclient := createScaleConvergenceTestClient(t, appsv1.DeploymentStatus{Replicas: 5, ReadyReplicas: 5})
+	start := time.Now()
+	timeNow = func() time.Time { return start.Add(12 * time.Second) }
+	defer func() { timeNow = time.Now }()
+	state := ScaleConvergenceCheckState{
+		Start:      start,
+		Budget:     time.Minute,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusScaleConvergenceCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+	require.Equal(t, "checkout converged to 5 replicas after 12s.", (*result.Messages)[0].Message)
+}
+
+func TestScaleConvergenceCheckFailsWhenNotConvergedWithinBudget(t *testing.T) {
+	// Given
+	k8sclient := createScaleConvergenceTestClient(t, appsv1.DeploymentStatus{Replicas: 5, ReadyReplicas: 3})
+	start := time.Now()
+	timeNow = func() time.Time { return start.Add(90 * time.Second) }
+	defer func() { timeNow = time.Now }()
+	state := ScaleConvergenceCheckState{
+		Start:      start,
+		Budget:     time.Minute,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusScaleConvergenceCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout did not converge to 5 replicas within 1m0s (5 replicas, 3 ready).", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestScaleConvergenceCheckKeepsWaitingWithinBudget(t *testing.T) {
+	// Given
+	k8sclient := createScaleConvergenceTestClient(t, appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 3})
+	state := ScaleConvergenceCheckState{
+		Start:      time.Now(),
+		Budget:     time.Minute,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusScaleConvergenceCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func createScaleConvergenceTestClient(t *testing.T, status appsv1.DeploymentStatus) *client.Client {
+	return testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(int32(5)),
+			},
+			Status: status,
+		}).
+		Build()
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type ServiceAccountTokenCheckAction struct {
+}
+
+type ServiceAccountTokenCheckState struct {
+	Cluster    string
+	Namespace  string
+	Deployment string
+}
+
+func NewServiceAccountTokenCheckAction() action_kit_sdk.Action[ServiceAccountTokenCheckState] {
+	return ServiceAccountTokenCheckAction{}
+}
+
+var _ action_kit_sdk.Action[ServiceAccountTokenCheckState] = (*ServiceAccountTokenCheckAction)(nil)
+
+func (f ServiceAccountTokenCheckAction) NewEmptyState() ServiceAccountTokenCheckState {
+	return ServiceAccountTokenCheckState{}
+}
+
+func (f ServiceAccountTokenCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          serviceAccountTokenCheckActionId,
+		Label:       "Service Account Token Mounted",
+		Description: "Verify that the pods of the deployment get a service account token mounted, which is required to access the Kubernetes API from within the pods.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInstantaneous,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{},
+		Prepare:    action_kit_api.MutatingEndpointReference{},
+		Start:      action_kit_api.MutatingEndpointReference{},
+	}
+}
+
+func (f ServiceAccountTokenCheckAction) Prepare(_ context.Context, state *ServiceAccountTokenCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f ServiceAccountTokenCheckAction) Start(_ context.Context, state *ServiceAccountTokenCheckState) (*action_kit_api.StartResult, error) {
+	return startServiceAccountTokenCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func startServiceAccountTokenCheckInternal(k8s *client.Client, state *ServiceAccountTokenCheckState) *action_kit_api.StartResult {
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StartResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	spec := deployment.Spec.Template.Spec
+	serviceAccountName := spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	serviceAccount := k8s.ServiceAccountByNamespaceAndName(state.Namespace, serviceAccountName)
+	if serviceAccount == nil {
+		return &action_kit_api.StartResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Service account %s of %s not found.", serviceAccountName, state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	if mounted, source := isServiceAccountTokenMounted(spec, serviceAccount); !mounted {
+		return &action_kit_api.StartResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s doesn't mount the token of service account %s, automounting is disabled by the %s.", state.Deployment, serviceAccountName, source),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{
+			{
+				Message: fmt.Sprintf("%s mounts the token of service account %s.", state.Deployment, serviceAccountName),
+				Level:   extutil.Ptr(action_kit_api.Info),
+			},
+		}),
+	}
+}
+
+// isServiceAccountTokenMounted applies the precedence of the API server: the setting of the pod wins over the one
+// of the service account, both default to true. Also returns which of them decided.
+func isServiceAccountTokenMounted(spec corev1.PodSpec, serviceAccount *corev1.ServiceAccount) (bool, string) {
+	if spec.AutomountServiceAccountToken != nil {
+		return *spec.AutomountServiceAccountToken, "pod template"
+	}
+	if serviceAccount.AutomountServiceAccountToken != nil {
+		return *serviceAccount.AutomountServiceAccountToken, "service account"
+	}
+	return true, "default"
+}
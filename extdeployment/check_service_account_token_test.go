@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestServiceAccountTokenCheckSucceedsWhenEnabledByPodTemplate(t *testing.T) {
+	// Given
+	k8sclient := createServiceAccountTokenTestClient(t, extutil.Ptr(false), extutil.Ptr(true))
+	state := ServiceAccountTokenCheckState{Namespace: "shop", Deployment: "checkout"}
+
+	// When
+	result := startServiceAccountTokenCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Nil(t, result.Error)
+	require.Equal(t, "checkout mounts the token of service account checkout.", (*result.Messages)[0].Message)
+}
+
+func TestServiceAccountTokenCheckFailsWhenDisabledByServiceAccount(t *testing.T) {
+	// Given
+	k8sclient := createServiceAccountTokenTestClient(t, extutil.Ptr(false), nil)
+	state := ServiceAccountTokenCheckState{Namespace: "shop", Deployment: "checkout"}
+
+	// When
+	result := startServiceAccountTokenCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Equal(t, "checkout doesn't mount the token of service account checkout, automounting is disabled by the service account.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestServiceAccountTokenCheckFailsWhenDisabledByPodTemplate(t *testing.T) {
+	// Given
+	k8sclient := createServiceAccountTokenTestClient(t, extutil.Ptr(true), extutil.Ptr(false))
+	state := ServiceAccountTokenCheckState{Namespace: "shop", Deployment: "checkout"}
+
+	// When
+	result := startServiceAccountTokenCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Equal(t, "checkout doesn't mount the token of service account checkout, automounting is disabled by the pod template.", result.Error.Title)
+}
+
+func createServiceAccountTokenTestClient(t *testing.T, serviceAccountAutomount *bool, podAutomount *bool) *client.Client {
+	return testsupport.NewClientBuilder(t).
+		WithServiceAccounts(&corev1.ServiceAccount{
+			ObjectMeta:                   metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			AutomountServiceAccountToken: serviceAccountAutomount,
+		}).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						ServiceAccountName:           "checkout",
+						AutomountServiceAccountToken: podAutomount,
+					},
+				},
+			},
+		}).
+		Build()
+}
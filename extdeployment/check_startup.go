@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	"strings"
+	"time"
+)
+
+type StartupCheckAction struct {
+}
+
+type StartupCheckState struct {
+	Cluster    string
+	Start      time.Time
+	Budget     time.Duration
+	Namespace  string
+	Deployment string
+}
+
+type StartupCheckConfig struct {
+	Duration int
+}
+
+func NewStartupCheckAction() action_kit_sdk.Action[StartupCheckState] {
+	return StartupCheckAction{}
+}
+
+var _ action_kit_sdk.Action[StartupCheckState] = (*StartupCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[StartupCheckState] = (*StartupCheckAction)(nil)
+
+func (f StartupCheckAction) NewEmptyState() StartupCheckState {
+	return StartupCheckState{}
+}
+
+func (f StartupCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          startupCheckActionId,
+		Label:       "Container Startup",
+		Description: "Verify that all containers of all pods of the deployment report to be started within a time budget. Catches failing startup probes independently of readiness.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Budget",
+				Description:  extutil.Ptr("How long may it take until all containers are started."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("2m"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f StartupCheckAction) Prepare(_ context.Context, state *StartupCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config StartupCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Budget = time.Millisecond * time.Duration(config.Duration)
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f StartupCheckAction) Start(_ context.Context, state *StartupCheckState) (*action_kit_api.StartResult, error) {
+	state.Start = timeNow()
+	return nil, nil
+}
+
+func (f StartupCheckAction) Status(_ context.Context, state *StartupCheckState) (*action_kit_api.StatusResult, error) {
+	return statusStartupCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusStartupCheckInternal(k8s *client.Client, state *StartupCheckState) *action_kit_api.StatusResult {
+	elapsed := timeNow().Sub(state.Start).Round(time.Millisecond)
+
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var notStarted []string
+	for _, pod := range k8s.PodsByDeployment(deployment) {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		notStarted = append(notStarted, notStartedContainers(pod)...)
+	}
+
+	if len(notStarted) == 0 {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Messages: extutil.Ptr([]action_kit_api.Message{
+				{
+					Message: fmt.Sprintf("All containers of %s started after %s.", state.Deployment, elapsed),
+					Level:   extutil.Ptr(action_kit_api.Info),
+				},
+			}),
+		}
+	}
+
+	if elapsed > state.Budget {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has containers not started within %s: %s", state.Deployment, state.Budget, strings.Join(notStarted, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: false,
+	}
+}
+
+// notStartedContainers returns pod/container for every container which isn't started yet. Pods without container
+// statuses haven't been started by the kubelet at all and are returned as a whole.
+func notStartedContainers(pod *corev1.Pod) []string {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return []string{pod.Name}
+	}
+	var result []string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Started == nil || !*status.Started {
+			result = append(result, fmt.Sprintf("%s/%s", pod.Name, status.Name))
+		}
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestStartupCheckCompletesWhenAllContainersStarted(t *testing.T) {
+	// Given
+	k8sclient := createStartupTestClient(t, extutil.Ptr(true))
+	start := time.Now()
+	timeNow = func() time.Time { return start.Add(8 * time.Second) }
+	defer func() { timeNow = time.Now }()
+	state := StartupCheckState{
+		Start:      start,
+		Budget:     time.Minute,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusStartupCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+	require.Equal(t, "All containers of checkout started after 8s.", (*result.Messages)[0].Message)
+}
+
+func TestStartupCheckFailsWhenContainersNotStartedWithinBudget(t *testing.T) {
+	// Given
+	k8sclient := createStartupTestClient(t, extutil.Ptr(false))
+	start := time.Now()
+	timeNow = func() time.Time { return start.Add(90 * time.Second) }
+	defer func() { timeNow = time.Now }()
+	state := StartupCheckState{
+		Start:      start,
+		Budget:     time.Minute,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusStartupCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout has containers not started within 1m0s: checkout-1/sidecar", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestStartupCheckKeepsWaitingForUnreportedStartWithinBudget(t *testing.T) {
+	// Given
+	k8sclient := createStartupTestClient(t, nil)
+	state := StartupCheckState{
+		Start:      time.Now(),
+		Budget:     time.Minute,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusStartupCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func createStartupTestClient(t *testing.T, sidecarStarted *bool) *client.Client {
+	labels := map[string]string{"app": "checkout"}
+	return testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: labels}),
+			},
+		}).
+		WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-1", Namespace: "shop", Labels: labels},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", Started: extutil.Ptr(true)},
+					{Name: "sidecar", Started: sidecarStarted},
+				},
+			},
+		}).
+		Build()
+}
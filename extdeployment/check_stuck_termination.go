@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"strings"
+	"time"
+)
+
+type StuckTerminationCheckAction struct {
+}
+
+type StuckTerminationCheckState struct {
+	Cluster    string
+	Timeout    time.Time
+	Buffer     time.Duration
+	Namespace  string
+	Deployment string
+}
+
+type StuckTerminationCheckConfig struct {
+	Duration int
+	Buffer   int
+}
+
+func NewStuckTerminationCheckAction() action_kit_sdk.Action[StuckTerminationCheckState] {
+	return StuckTerminationCheckAction{}
+}
+
+var _ action_kit_sdk.Action[StuckTerminationCheckState] = (*StuckTerminationCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[StuckTerminationCheckState] = (*StuckTerminationCheckAction)(nil)
+
+func (f StuckTerminationCheckAction) NewEmptyState() StuckTerminationCheckState {
+	return StuckTerminationCheckState{}
+}
+
+func (f StuckTerminationCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          stuckTerminationCheckActionId,
+		Label:       "Stuck Pod Termination",
+		Description: "Verify that no pod of the deployment is stuck terminating, e.g. due to a finalizer or an unresponsive kubelet.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find deployment by cluster, namespace and deployment"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.deployment=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the check watch for stuck pods."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "buffer",
+				Label:        "Buffer",
+				Description:  extutil.Ptr("How long may a pod exceed its termination grace period before it is considered stuck."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Advanced:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f StuckTerminationCheckAction) Prepare(_ context.Context, state *StuckTerminationCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config StuckTerminationCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = timeNow().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Buffer = time.Millisecond * time.Duration(config.Buffer)
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
+	return nil, nil
+}
+
+func (f StuckTerminationCheckAction) Start(_ context.Context, _ *StuckTerminationCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f StuckTerminationCheckAction) Status(_ context.Context, state *StuckTerminationCheckState) (*action_kit_api.StatusResult, error) {
+	return statusStuckTerminationCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusStuckTerminationCheckInternal(k8s *client.Client, state *StuckTerminationCheckState) *action_kit_api.StatusResult {
+	now := timeNow()
+
+	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+	if deployment == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var stuckPods []string
+	for _, pod := range k8s.PodsByDeployment(deployment) {
+		// The deletion timestamp is set to the time of the deletion request plus the grace period,
+		// i.e. the point in time at which the pod should be gone.
+		if pod.DeletionTimestamp != nil && now.After(pod.DeletionTimestamp.Add(state.Buffer)) {
+			stuckPods = append(stuckPods, pod.Name)
+		}
+	}
+
+	if len(stuckPods) > 0 {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has pods stuck terminating: %s", state.Deployment, strings.Join(stuckPods, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: now.After(state.Timeout),
+	}
+}
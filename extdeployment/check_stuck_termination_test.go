@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func TestStuckTerminationCheckFailsForPodStuckPastGracePeriod(t *testing.T) {
+	// Given
+	deletion := time.Now()
+	k8sclient := createStuckTerminationTestClient(t, deletion)
+	timeNow = func() time.Time { return deletion.Add(time.Minute) }
+	defer func() { timeNow = time.Now }()
+	state := StuckTerminationCheckState{
+		Timeout:    deletion.Add(time.Hour),
+		Buffer:     30 * time.Second,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusStuckTerminationCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout has pods stuck terminating: checkout-1", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestStuckTerminationCheckToleratesPodWithinBuffer(t *testing.T) {
+	// Given
+	deletion := time.Now()
+	k8sclient := createStuckTerminationTestClient(t, deletion)
+	timeNow = func() time.Time { return deletion.Add(10 * time.Second) }
+	defer func() { timeNow = time.Now }()
+	state := StuckTerminationCheckState{
+		Timeout:    deletion.Add(time.Hour),
+		Buffer:     30 * time.Second,
+		Namespace:  "shop",
+		Deployment: "checkout",
+	}
+
+	// When
+	result := statusStuckTerminationCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func createStuckTerminationTestClient(t *testing.T, deletionTimestamp time.Time) *client.Client {
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.
+		AppsV1().
+		Deployments("shop").
+		Create(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout",
+				Namespace: "shop",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: extutil.Ptr(metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app": "checkout",
+					},
+				}),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.
+		CoreV1().
+		Pods("shop").
+		Create(context.Background(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:                       "checkout-1",
+				Namespace:                  "shop",
+				Labels:                     map[string]string{"app": "checkout"},
+				DeletionTimestamp:          extutil.Ptr(metav1.NewTime(deletionTimestamp)),
+				DeletionGracePeriodSeconds: extutil.Ptr(int64(30)),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.
+		CoreV1().
+		Pods("shop").
+		Create(context.Background(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-2",
+				Namespace: "shop",
+				Labels:    map[string]string{"app": "checkout"},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	return client.CreateClient(clientset, stopCh, "", 0, nil)
+}
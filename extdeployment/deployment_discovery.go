@@ -10,11 +10,15 @@ import (
 	"github.com/steadybit/extension-kit/exthttp"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/utils/strings/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func RegisterDeploymentDiscoveryHandlers() {
@@ -32,7 +36,7 @@ func getDeploymentDiscoveryDescription() discovery_kit_api.DiscoveryDescription
 		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
 			Method:       "GET",
 			Path:         "/deployment/discovery/discovered-targets",
-			CallInterval: extutil.Ptr("1m"),
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
 		},
 	}
 }
@@ -61,7 +65,7 @@ func getDeploymentTargetDescription() discovery_kit_api.TargetDescription {
 }
 
 func getDiscoveredDeployments(w http.ResponseWriter, _ *http.Request, _ []byte) {
-	targets := getDiscoveredDeploymentTargets(client.K8S)
+	targets := extcommon.DiscoverAllClusters(getDiscoveredDeploymentTargets)
 	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
 }
 
@@ -82,16 +86,49 @@ func getDiscoveredDeploymentTargets(k8s *client.Client) []discovery_kit_api.Targ
 
 	targets := make([]discovery_kit_api.Target, len(filteredDeployments))
 	for i, d := range filteredDeployments {
-		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, d.Namespace, d.Name)
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), d.Namespace, d.Name)
 		attributes := map[string][]string{
 			"k8s.namespace":    {d.Namespace},
 			"k8s.deployment":   {d.Name},
-			"k8s.cluster-name": {extconfig.Config.ClusterName},
+			"k8s.cluster-name": {k8s.ClusterName()},
 			"k8s.distribution": {k8s.Distribution},
+			// Compared to the desired replicas, this shows how far a rollout has progressed.
+			"k8s.deployment.updated-replicas": {strconv.Itoa(int(d.Status.UpdatedReplicas))},
+		}
+
+		if !d.CreationTimestamp.IsZero() {
+			attributes["k8s.deployment.created"] = []string{d.CreationTimestamp.UTC().Format(time.RFC3339)}
+			attributes["k8s.deployment.age-seconds"] = []string{strconv.FormatInt(int64(timeNow().Sub(d.CreationTimestamp.Time).Seconds()), 10)}
+		}
+
+		if d.Spec.Selector != nil {
+			// Renders matchLabels and matchExpressions the same way as kubectl, e.g. "app=shop,tier in (backend,frontend)".
+			attributes["k8s.deployment.selector"] = []string{metav1.FormatLabelSelector(d.Spec.Selector)}
+		}
+
+		if managedBy := extcommon.ManagedBy(d.ObjectMeta); managedBy != "" {
+			attributes["k8s.deployment.managed-by"] = []string{managedBy}
+		}
+
+		// The API server defaults missing replicas to 1.
+		if d.Spec.Replicas == nil || *d.Spec.Replicas == 1 {
+			attributes["k8s.deployment.single-replica"] = []string{"true"}
+		}
+
+		if available := availableCondition(d); available != nil {
+			attributes["k8s.deployment.available"] = []string{strconv.FormatBool(available.Status == corev1.ConditionTrue)}
+			if available.Reason != "" {
+				attributes["k8s.deployment.available-reason"] = []string{available.Reason}
+			}
+		}
+
+		if hpa := k8s.HorizontalPodAutoscalerByScaleTarget(d.Namespace, "Deployment", d.Name); hpa != nil {
+			attributes["k8s.deployment.has-hpa"] = []string{"true"}
+			attributes["k8s.deployment.hpa-name"] = []string{hpa.Name}
 		}
 
 		for key, value := range d.ObjectMeta.Labels {
-			if !slices.Contains(extconfig.Config.LabelFilter, key) {
+			if !extconfig.IsLabelFiltered(key) {
 				attributes[fmt.Sprintf("k8s.deployment.label.%v", key)] = []string{value}
 				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
 			}
@@ -121,6 +158,10 @@ func getDiscoveredDeploymentTargets(k8s *client.Client) []discovery_kit_api.Targ
 			}
 		}
 
+		extcommon.AddNamespaceAttributes(k8s, d.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesDeployment)
+
 		targets[i] = discovery_kit_api.Target{
 			Id:         targetName,
 			TargetType: DeploymentTargetType,
@@ -184,3 +225,13 @@ func getContainerToDeploymentEnrichmentRule() discovery_kit_api.TargetEnrichment
 		},
 	}
 }
+
+// availableCondition returns the Available condition of the deployment, nil if the controller didn't report it yet.
+func availableCondition(deployment *appsv1.Deployment) *appsv1.DeploymentCondition {
+	for i, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+	return nil
+}
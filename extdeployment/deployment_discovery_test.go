@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -102,15 +103,18 @@ func Test_getDiscoveredDeployments(t *testing.T) {
 	assert.Equal(t, "shop", target.Label)
 	assert.Equal(t, DeploymentTargetType, target.TargetType)
 	assert.Equal(t, map[string][]string{
-		"k8s.namespace":                  {"default"},
-		"k8s.deployment":                 {"shop"},
-		"k8s.deployment.label.best-city": {"Kevelaer"},
-		"k8s.label.best-city":            {"Kevelaer"},
-		"k8s.cluster-name":               {"development"},
-		"k8s.pod.name":                   {"shop-pod"},
-		"k8s.container.id":               {"crio://abcdef"},
-		"k8s.container.id.stripped":      {"abcdef"},
-		"k8s.distribution":               {"kubernetes"},
+		"k8s.namespace":                   {"default"},
+		"k8s.deployment":                  {"shop"},
+		"k8s.deployment.label.best-city":  {"Kevelaer"},
+		"k8s.deployment.selector":         {"best-city=kevelaer"},
+		"k8s.deployment.single-replica":   {"true"},
+		"k8s.deployment.updated-replicas": {"0"},
+		"k8s.label.best-city":             {"Kevelaer"},
+		"k8s.cluster-name":                {"development"},
+		"k8s.pod.name":                    {"shop-pod"},
+		"k8s.container.id":                {"crio://abcdef"},
+		"k8s.container.id.stripped":       {"abcdef"},
+		"k8s.distribution":                {"kubernetes"},
 	}, target.Attributes)
 }
 
@@ -194,13 +198,16 @@ func Test_getDiscoveredDeployments_ignore_empty_container_ids(t *testing.T) {
 	assert.Equal(t, "shop", target.Label)
 	assert.Equal(t, DeploymentTargetType, target.TargetType)
 	assert.Equal(t, map[string][]string{
-		"k8s.namespace":                  {"default"},
-		"k8s.deployment":                 {"shop"},
-		"k8s.deployment.label.best-city": {"Kevelaer"},
-		"k8s.label.best-city":            {"Kevelaer"},
-		"k8s.cluster-name":               {"development"},
-		"k8s.pod.name":                   {"shop-pod"},
-		"k8s.distribution":               {"kubernetes"},
+		"k8s.namespace":                   {"default"},
+		"k8s.deployment":                  {"shop"},
+		"k8s.deployment.label.best-city":  {"Kevelaer"},
+		"k8s.deployment.selector":         {"best-city=kevelaer"},
+		"k8s.deployment.single-replica":   {"true"},
+		"k8s.deployment.updated-replicas": {"0"},
+		"k8s.label.best-city":             {"Kevelaer"},
+		"k8s.cluster-name":                {"development"},
+		"k8s.pod.name":                    {"shop-pod"},
+		"k8s.distribution":                {"kubernetes"},
 	}, target.Attributes)
 }
 
@@ -339,8 +346,386 @@ func Test_getDiscoveredDeploymentsShouldNotIgnoreLabeledDeploymentsIfExcludesDis
 	require.Len(t, targets, 2)
 }
 
+func Test_getDiscoveredDeploymentsShouldReportCreationTimeAndAge(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	created := time.Date(2023, time.September, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return created.Add(90 * time.Minute) }
+	defer func() { timeNow = time.Now }()
+
+	_, err := clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "shop",
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: extutil.Ptr(metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"best-city": "kevelaer",
+					},
+				}),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredDeploymentTargets(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"2023-09-01T12:00:00Z"}, targets[0].Attributes["k8s.deployment.created"])
+	assert.Equal(t, []string{"5400"}, targets[0].Attributes["k8s.deployment.age-seconds"])
+}
+
+func Test_getDiscoveredDeploymentsShouldOmitNamespaceDisplayNameOnKubernetes(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	_, err := clientset.CoreV1().
+		Namespaces().
+		Create(context.Background(), &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default",
+				Annotations: map[string]string{
+					"openshift.io/display-name": "Default",
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredDeploymentTargets(client)) == 1 && client.NamespaceByName("default") != nil
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.NotContains(t, targets[0].Attributes, "k8s.namespace.display-name")
+}
+
+func Test_getDiscoveredDeploymentsShouldReportSelector(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	_, err := clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "shop"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      "tier",
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   []string{"backend", "frontend"},
+						},
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredDeploymentTargets(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"app=shop,tier in (backend,frontend)"}, targets[0].Attributes["k8s.deployment.selector"])
+}
+
+func Test_getDiscoveredDeploymentsShouldReportUpdatedReplicas(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	_, err := clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(int32(3)),
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas:        3,
+				UpdatedReplicas: 2,
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredDeploymentTargets(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"2"}, targets[0].Attributes["k8s.deployment.updated-replicas"])
+}
+
+func Test_getDiscoveredDeploymentsShouldFlagSingleReplica(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	_, err := clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(int32(1)),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredDeploymentTargets(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"true"}, targets[0].Attributes["k8s.deployment.single-replica"])
+}
+
+func Test_getDiscoveredDeploymentsShouldNotFlagMultipleReplicasAsSingleReplica(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	_, err := clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(int32(3)),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredDeploymentTargets(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.NotContains(t, targets[0].Attributes, "k8s.deployment.single-replica")
+}
+
+func Test_getDiscoveredDeploymentsShouldReportGitOpsManager(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	_, err := clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+				Annotations: map[string]string{
+					"argocd.argoproj.io/tracking-id": "shop:apps/Deployment:default/shop",
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredDeploymentTargets(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"argocd"}, targets[0].Attributes["k8s.deployment.managed-by"])
+}
+
+func Test_getDiscoveredDeploymentsShouldReportHpa(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	_, err := clientset.
+		AutoscalingV2().
+		HorizontalPodAutoscalers("default").
+		Create(context.Background(), &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-hpa",
+				Namespace: "default",
+			},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "shop",
+				},
+				MaxReplicas: 5,
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		targets := getDiscoveredDeploymentTargets(client)
+		return len(targets) == 1 && len(targets[0].Attributes["k8s.deployment.hpa-name"]) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"true"}, targets[0].Attributes["k8s.deployment.has-hpa"])
+	assert.Equal(t, []string{"shop-hpa"}, targets[0].Attributes["k8s.deployment.hpa-name"])
+}
+
+func Test_getDiscoveredDeploymentsShouldReportAvailableCondition(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+
+	_, err := clientset.
+		AppsV1().
+		Deployments("default").
+		Create(context.Background(), &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+					{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse, Reason: "MinimumReplicasUnavailable"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// When
+	assert.Eventually(t, func() bool {
+		return len(getDiscoveredDeploymentTargets(client)) == 1
+	}, time.Second, 100*time.Millisecond)
+
+	// Then
+	targets := getDiscoveredDeploymentTargets(client)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"false"}, targets[0].Attributes["k8s.deployment.available"])
+	assert.Equal(t, []string{"MinimumReplicasUnavailable"}, targets[0].Attributes["k8s.deployment.available-reason"])
+}
+
 func getTestClient(stopCh <-chan struct{}) (*client.Client, kubernetes.Interface) {
 	clientset := testclient.NewSimpleClientset()
-	client := client.CreateClient(clientset, stopCh, "")
+	client := client.CreateClient(clientset, stopCh, "", 0, nil)
 	return client, clientset
 }
@@ -13,19 +13,15 @@ import (
 	"github.com/steadybit/extension-kit/extconversion"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"time"
 )
 
-const (
-	podCountMin1                 = "podCountMin1"
-	podCountEqualsDesiredCount   = "podCountEqualsDesiredCount"
-	podCountLessThanDesiredCount = "podCountLessThanDesiredCount"
-)
-
 type PodCountCheckAction struct {
 }
 
 type PodCountCheckState struct {
+	Cluster           string
 	Timeout           time.Time
 	PodCountCheckMode string
 	Namespace         string
@@ -60,13 +56,7 @@ func (f PodCountCheckAction) Describe() action_kit_api.ActionDescription {
 		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
 			TargetType:          DeploymentTargetType,
 			QuantityRestriction: extutil.Ptr(action_kit_api.All),
-			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
-				{
-					Label:       "default",
-					Description: extutil.Ptr("Find deployment by cluster, namespace and deployment"),
-					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.deployment=\"\"",
-				},
-			}),
+			SelectionTemplates:  extutil.Ptr(deploymentSelectionTemplates()),
 		}),
 		Parameters: []action_kit_api.ActionParameter{
 			{
@@ -86,20 +76,7 @@ func (f PodCountCheckAction) Describe() action_kit_api.ActionDescription {
 				DefaultValue: extutil.Ptr("podCountEqualsDesiredCount"),
 				Order:        extutil.Ptr(2),
 				Required:     extutil.Ptr(true),
-				Options: extutil.Ptr([]action_kit_api.ParameterOption{
-					action_kit_api.ExplicitParameterOption{
-						Label: "ready count > 0",
-						Value: podCountMin1,
-					},
-					action_kit_api.ExplicitParameterOption{
-						Label: "ready count = desired count",
-						Value: podCountEqualsDesiredCount,
-					},
-					action_kit_api.ExplicitParameterOption{
-						Label: "ready count < desired count",
-						Value: podCountLessThanDesiredCount,
-					},
-				}),
+				Options:      extutil.Ptr(extcommon.PodCountCheckModeOptions()),
 			},
 		},
 		Prepare: action_kit_api.MutatingEndpointReference{},
@@ -111,6 +88,9 @@ func (f PodCountCheckAction) Describe() action_kit_api.ActionDescription {
 }
 
 func (f PodCountCheckAction) Prepare(_ context.Context, state *PodCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
 	var config PodCountCheckConfig
 	if err := extconversion.Convert(request.Config, &config); err != nil {
 		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
@@ -127,12 +107,10 @@ func (f PodCountCheckAction) Start(_ context.Context, _ *PodCountCheckState) (*a
 }
 
 func (f PodCountCheckAction) Status(_ context.Context, state *PodCountCheckState) (*action_kit_api.StatusResult, error) {
-	return statusPodCountCheckInternal(client.K8S, state), nil
+	return statusPodCountCheckInternal(client.ForCluster(state.Cluster), state), nil
 }
 
 func statusPodCountCheckInternal(k8s *client.Client, state *PodCountCheckState) *action_kit_api.StatusResult {
-	now := time.Now()
-
 	deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
 	if deployment == nil {
 		return &action_kit_api.StatusResult{
@@ -143,46 +121,5 @@ func statusPodCountCheckInternal(k8s *client.Client, state *PodCountCheckState)
 		}
 	}
 
-	readyCount := deployment.Status.ReadyReplicas
-	desiredCount := int32(0)
-	if deployment.Spec.Replicas != nil {
-		desiredCount = *deployment.Spec.Replicas
-	} else if state.PodCountCheckMode == podCountEqualsDesiredCount || state.PodCountCheckMode == podCountLessThanDesiredCount {
-		return &action_kit_api.StatusResult{
-			Error: extutil.Ptr(action_kit_api.ActionKitError{
-				Title:  fmt.Sprintf("Deployment %s has no desired count.", state.Deployment),
-				Status: extutil.Ptr(action_kit_api.Errored),
-			}),
-		}
-	}
-
-	var checkError *action_kit_api.ActionKitError
-	if state.PodCountCheckMode == podCountMin1 && readyCount < 1 {
-		checkError = extutil.Ptr(action_kit_api.ActionKitError{
-			Title:  fmt.Sprintf("%s has no ready pods.", state.Deployment),
-			Status: extutil.Ptr(action_kit_api.Failed),
-		})
-	} else if state.PodCountCheckMode == podCountEqualsDesiredCount && readyCount != desiredCount {
-		checkError = extutil.Ptr(action_kit_api.ActionKitError{
-			Title:  fmt.Sprintf("%s has only %d of desired %d pods ready.", state.Deployment, readyCount, desiredCount),
-			Status: extutil.Ptr(action_kit_api.Failed),
-		})
-	} else if state.PodCountCheckMode == podCountLessThanDesiredCount && readyCount == desiredCount {
-		checkError = extutil.Ptr(action_kit_api.ActionKitError{
-			Title:  fmt.Sprintf("%s has all %d desired pods ready.", state.Deployment, desiredCount),
-			Status: extutil.Ptr(action_kit_api.Failed),
-		})
-	}
-
-	if now.After(state.Timeout) {
-		return &action_kit_api.StatusResult{
-			Completed: true,
-			Error:     checkError,
-		}
-	} else {
-		return &action_kit_api.StatusResult{
-			Completed: checkError == nil,
-		}
-	}
-
+	return extcommon.PodCountStatus("Deployment", state.Deployment, state.PodCountCheckMode, deployment.Status.ReadyReplicas, deployment.Spec.Replicas, state.Timeout)
 }
@@ -69,7 +69,7 @@ func TestStatusCheckDeploymentNotFound(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusPodCountCheckInternal(k8sclient, &state)
@@ -110,7 +110,7 @@ func TestStatusCheckPodCountMin1Success(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusPodCountCheckInternal(k8sclient, &state)
@@ -151,7 +151,7 @@ func TestStatusCheckPodCountMin1Fail(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusPodCountCheckInternal(k8sclient, &state)
@@ -192,7 +192,7 @@ func TestStatusCheckPodCountEqualsDesiredCountSuccess(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusPodCountCheckInternal(k8sclient, &state)
@@ -233,7 +233,7 @@ func TestStatusCheckPodCountEqualsDesiredCountFail(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusPodCountCheckInternal(k8sclient, &state)
@@ -274,7 +274,7 @@ func TestStatusCheckPodCountLessThanDesiredCountSuccess(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusPodCountCheckInternal(k8sclient, &state)
@@ -315,7 +315,7 @@ func TestStatusCheckPodCountLessThanDesiredCountFail(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusPodCountCheckInternal(k8sclient, &state)
@@ -324,3 +324,32 @@ func TestStatusCheckPodCountLessThanDesiredCountFail(t *testing.T) {
 	require.True(t, result.Completed)
 	require.Equal(t, "checkout has all 2 desired pods ready.", result.Error.Title)
 }
+
+func TestDescribeEmitsOpenShiftSelectionTemplate(t *testing.T) {
+	// Given
+	client.K8S = &client.Client{Distribution: "openshift"}
+	defer func() { client.K8S = nil }()
+
+	// When
+	description := NewPodCountCheckAction().Describe()
+
+	// Then
+	templates := *description.TargetSelection.SelectionTemplates
+	require.Len(t, templates, 2)
+	require.Equal(t, "openshift", templates[1].Label)
+	require.Equal(t, "k8s.distribution=\"openshift\" AND k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.deployment=\"\"", templates[1].Query)
+}
+
+func TestDescribeEmitsDefaultSelectionTemplateOnly(t *testing.T) {
+	// Given
+	client.K8S = &client.Client{Distribution: "kubernetes"}
+	defer func() { client.K8S = nil }()
+
+	// When
+	description := NewPodCountCheckAction().Describe()
+
+	// Then
+	templates := *description.TargetSelection.SelectionTemplates
+	require.Len(t, templates, 1)
+	require.Equal(t, "default", templates[0].Label)
+}
@@ -14,7 +14,7 @@ import (
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extcluster"
-	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	appsv1 "k8s.io/api/apps/v1"
 	"time"
 )
@@ -23,6 +23,7 @@ type PodCountMetricsAction struct {
 }
 
 type PodCountMetricsState struct {
+	Cluster     string
 	End         time.Time
 	LastMetrics map[string]int32
 }
@@ -89,6 +90,9 @@ func (f PodCountMetricsAction) Describe() action_kit_api.ActionDescription {
 }
 
 func (f PodCountMetricsAction) Prepare(_ context.Context, state *PodCountMetricsState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
 	var config PodCountMetricsConfig
 	if err := extconversion.Convert(request.Config, &config); err != nil {
 		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
@@ -103,7 +107,7 @@ func (f PodCountMetricsAction) Start(_ context.Context, _ *PodCountMetricsState)
 }
 
 func (f PodCountMetricsAction) Status(_ context.Context, state *PodCountMetricsState) (*action_kit_api.StatusResult, error) {
-	return statusPodCountMetricsInternal(client.K8S, state), nil
+	return statusPodCountMetricsInternal(client.ForCluster(state.Cluster), state), nil
 }
 
 func statusPodCountMetricsInternal(k8s *client.Client, state *PodCountMetricsState) *action_kit_api.StatusResult {
@@ -112,7 +116,7 @@ func statusPodCountMetricsInternal(k8s *client.Client, state *PodCountMetricsSta
 	var metrics []action_kit_api.Metric
 	for _, d := range k8s.Deployments() {
 		if hasChanges(d, state) {
-			for _, m := range toMetrics(d, now) {
+			for _, m := range toMetrics(k8s.ClusterName(), d, now) {
 				state.LastMetrics[getMetricKey(d, *m.Name)] = int32(m.Value)
 				metrics = append(metrics, m)
 			}
@@ -147,13 +151,13 @@ func getMetricKey(deployment *appsv1.Deployment, metric string) string {
 	return fmt.Sprintf("%s-%s/%s", metric, deployment.Namespace, deployment.Name)
 }
 
-func toMetrics(deployment *appsv1.Deployment, now time.Time) []action_kit_api.Metric {
+func toMetrics(clusterName string, deployment *appsv1.Deployment, now time.Time) []action_kit_api.Metric {
 	metrics := make([]action_kit_api.Metric, 4)
 
 	metrics[0] = action_kit_api.Metric{
 		Name: extutil.Ptr("replicas_desired_count"),
 		Metric: map[string]string{
-			"k8s.cluster-name": extconfig.Config.ClusterName,
+			"k8s.cluster-name": clusterName,
 			"k8s.namespace":    deployment.Namespace,
 			"k8s.deployment":   deployment.Name,
 		},
@@ -163,7 +167,7 @@ func toMetrics(deployment *appsv1.Deployment, now time.Time) []action_kit_api.Me
 	metrics[1] = action_kit_api.Metric{
 		Name: extutil.Ptr("replicas_current_count"),
 		Metric: map[string]string{
-			"k8s.cluster-name": extconfig.Config.ClusterName,
+			"k8s.cluster-name": clusterName,
 			"k8s.namespace":    deployment.Namespace,
 			"k8s.deployment":   deployment.Name,
 		},
@@ -173,7 +177,7 @@ func toMetrics(deployment *appsv1.Deployment, now time.Time) []action_kit_api.Me
 	metrics[2] = action_kit_api.Metric{
 		Name: extutil.Ptr("replicas_ready_count"),
 		Metric: map[string]string{
-			"k8s.cluster-name": extconfig.Config.ClusterName,
+			"k8s.cluster-name": clusterName,
 			"k8s.namespace":    deployment.Namespace,
 			"k8s.deployment":   deployment.Name,
 		},
@@ -183,7 +187,7 @@ func toMetrics(deployment *appsv1.Deployment, now time.Time) []action_kit_api.Me
 	metrics[3] = action_kit_api.Metric{
 		Name: extutil.Ptr("replicas_available_count"),
 		Metric: map[string]string{
-			"k8s.cluster-name": extconfig.Config.ClusterName,
+			"k8s.cluster-name": clusterName,
 			"k8s.namespace":    deployment.Namespace,
 			"k8s.deployment":   deployment.Name,
 		},
@@ -76,7 +76,7 @@ func TestStatusReturnsMetrics(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	client := client.CreateClient(clientset, stopCh, "")
+	client := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusPodCountMetricsInternal(client, &state)
@@ -91,7 +91,6 @@ func TestStatusReturnsMetrics(t *testing.T) {
 func TestCreateMetrics(t *testing.T) {
 	// Given
 	now := time.Now()
-	extconfig.Config.ClusterName = "development"
 	desiredCount := int32(5)
 	currentCount := int32(3)
 	availableCount := int32(2)
@@ -112,7 +111,7 @@ func TestCreateMetrics(t *testing.T) {
 	}
 
 	// When
-	metrics := toMetrics(&deployment, now)
+	metrics := toMetrics("development", &deployment, now)
 
 	// Then
 	for _, metric := range metrics {
@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extevents
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/strings/slices"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxReportedWarningEvents limits the events listed in the error of the check.
+const maxReportedWarningEvents = 5
+
+type WarningEventsCheckAction struct {
+}
+
+type WarningEventsCheckState struct {
+	Cluster string
+	// Start is recorded during prepare, so only events of the experiment are counted.
+	Start          time.Time
+	Timeout        time.Time
+	Namespace      string
+	InvolvedObject string
+	Reasons        string
+	IgnoredReasons []string
+}
+
+type WarningEventsCheckConfig struct {
+	Duration       int
+	Namespace      string
+	InvolvedObject string
+	Reasons        string
+	IgnoredReasons []string
+}
+
+func NewWarningEventsCheckAction() action_kit_sdk.Action[WarningEventsCheckState] {
+	return WarningEventsCheckAction{}
+}
+
+var _ action_kit_sdk.Action[WarningEventsCheckState] = (*WarningEventsCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[WarningEventsCheckState] = (*WarningEventsCheckAction)(nil)
+
+func (f WarningEventsCheckAction) NewEmptyState() WarningEventsCheckState {
+	return WarningEventsCheckState{}
+}
+
+func (f WarningEventsCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          warningEventsCheckActionId,
+		Label:       "No Warning Events",
+		Description: "Verify that no warning events occur during the check, optionally limited to a namespace, involved objects and reasons.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(logIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the check watch for warning events."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "namespace",
+				Label:       "Namespace",
+				Description: extutil.Ptr("Only consider events in this namespace. All namespaces are considered if empty."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(false),
+			},
+			{
+				Name:        "involvedObject",
+				Label:       "Involved object",
+				Description: extutil.Ptr("Only consider events of objects matching this regular expression, e.g. `deployment/shop|pod/shop-.*`."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(3),
+				Required:    extutil.Ptr(false),
+			},
+			{
+				Name:        "reasons",
+				Label:       "Reasons",
+				Description: extutil.Ptr("Only consider events with a reason matching this regular expression, e.g. `Unhealthy|BackOff|Failed`."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(4),
+				Required:    extutil.Ptr(false),
+			},
+			{
+				Name:        "ignoredReasons",
+				Label:       "Ignored reasons",
+				Description: extutil.Ptr("Events with these reasons are expected and ignored, e.g. `FailedScheduling`."),
+				Type:        action_kit_api.StringArray,
+				Order:       extutil.Ptr(5),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f WarningEventsCheckAction) Prepare(_ context.Context, state *WarningEventsCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareWarningEventsCheckInternal(state, request)
+}
+
+func prepareWarningEventsCheckInternal(state *WarningEventsCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config WarningEventsCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	for _, expression := range []string{config.InvolvedObject, config.Reasons} {
+		if _, err := regexp.Compile(expression); err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Invalid regular expression %q.", expression), err)
+		}
+	}
+
+	state.Start = time.Now()
+	state.Timeout = state.Start.Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = config.Namespace
+	state.InvolvedObject = config.InvolvedObject
+	state.Reasons = config.Reasons
+	state.IgnoredReasons = config.IgnoredReasons
+	return nil, nil
+}
+
+func (f WarningEventsCheckAction) Start(_ context.Context, _ *WarningEventsCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f WarningEventsCheckAction) Status(_ context.Context, state *WarningEventsCheckState) (*action_kit_api.StatusResult, error) {
+	return statusWarningEventsCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+// WarningEventsCheckStatus evaluates the check, e.g. as a sub-check of the deployment health check. The expressions of
+// the state need to be valid.
+func WarningEventsCheckStatus(k8s *client.Client, state *WarningEventsCheckState) *action_kit_api.StatusResult {
+	return statusWarningEventsCheckInternal(k8s, state)
+}
+
+func statusWarningEventsCheckInternal(k8s *client.Client, state *WarningEventsCheckState) *action_kit_api.StatusResult {
+	// The expressions were validated during prepare.
+	involvedObject := regexp.MustCompile(state.InvolvedObject)
+	reasons := regexp.MustCompile(state.Reasons)
+
+	var warnings []string
+	for _, event := range *k8s.Events(state.Start) {
+		if event.Type != corev1.EventTypeWarning || slices.Contains(state.IgnoredReasons, event.Reason) {
+			continue
+		}
+		if state.Namespace != "" && event.Namespace != state.Namespace {
+			continue
+		}
+		object := involvedObjectName(event)
+		if !involvedObject.MatchString(object) || !reasons.MatchString(event.Reason) {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("%s %s: %s", object, event.Reason, event.Message))
+	}
+
+	if len(warnings) > 0 {
+		reported := warnings
+		if len(reported) > maxReportedWarningEvents {
+			reported = reported[:maxReportedWarningEvents]
+		}
+		title := fmt.Sprintf("%d warning events occurred: %s", len(warnings), strings.Join(reported, "; "))
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  title,
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.Timeout),
+	}
+}
+
+// involvedObjectName renders the involved object like the event logs, e.g. pod/shop-7d4b9c-x7k2p.
+func involvedObjectName(event corev1.Event) string {
+	return strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extevents
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestWarningEventsCheckPrepareRecordsStart(t *testing.T) {
+	// Given
+	state := NewWarningEventsCheckAction().NewEmptyState()
+	before := time.Now()
+
+	// When
+	_, err := prepareWarningEventsCheckInternal(&state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":       60000,
+			"reasons":        "Unhealthy|BackOff",
+			"ignoredReasons": []interface{}{"FailedScheduling"},
+		},
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.False(t, state.Start.Before(before))
+	require.Equal(t, state.Start.Add(time.Minute), state.Timeout)
+	require.Equal(t, []string{"FailedScheduling"}, state.IgnoredReasons)
+}
+
+func TestWarningEventsCheckPrepareRejectsInvalidExpression(t *testing.T) {
+	// Given
+	state := NewWarningEventsCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareWarningEventsCheckInternal(&state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 60000,
+			"reasons":  "BackOff(",
+		},
+	})
+
+	// Then
+	require.ErrorContains(t, err, "Invalid regular expression \"BackOff(\".")
+}
+
+func TestWarningEventsCheckFailsOnNewWarningEvent(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).WithEvents(
+		warningEvent("shop-1", "BackOff", time.Now()),
+		warningEvent("shop-2", "Unhealthy", time.Now().Add(-time.Hour)),
+	).Build()
+	state := WarningEventsCheckState{Start: time.Now().Add(-time.Minute), Timeout: time.Now().Add(time.Minute)}
+
+	// When
+	result := statusWarningEventsCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "1 warning events occurred: pod/shop-1 BackOff: Back-off restarting failed container", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestWarningEventsCheckIgnoresFilteredEvents(t *testing.T) {
+	// Given
+	normal := warningEvent("shop-3", "Pulled", time.Now())
+	normal.Type = corev1.EventTypeNormal
+	k8s := testsupport.NewClientBuilder(t).WithEvents(
+		warningEvent("shop-1", "FailedScheduling", time.Now()),
+		warningEvent("cart-1", "BackOff", time.Now()),
+		warningEvent("shop-2", "FailedMount", time.Now()),
+		normal,
+	).Build()
+	state := WarningEventsCheckState{
+		Start:          time.Now().Add(-time.Minute),
+		Timeout:        time.Now().Add(-time.Second),
+		Namespace:      "shop",
+		InvolvedObject: "pod/shop-.*",
+		Reasons:        "Unhealthy|BackOff|Failed",
+		IgnoredReasons: []string{"FailedScheduling", "FailedMount"},
+	}
+
+	// When
+	result := statusWarningEventsCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func warningEvent(pod string, reason string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: pod + "." + reason, Namespace: "shop"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: pod},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        "Back-off restarting failed container",
+		LastTimestamp:  metav1.Time{Time: lastSeen},
+	}
+}
@@ -4,5 +4,6 @@
 package extevents
 
 const (
-	logIcon = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M15.7072%203C15.7942%202.99995%2015.8778%203.03393%2015.9401%203.09467L18.566%205.65743C18.5979%205.68848%2018.6232%205.7256%2018.6405%205.76659C18.6577%205.80754%2018.6666%205.85164%2018.6667%205.8961V20.6667C18.6667%2020.7551%2018.6315%2020.8399%2018.569%2020.9024C18.5065%2020.9649%2018.4217%2021%2018.3333%2021H5.33333C5.24493%2021%205.16014%2020.9649%205.09763%2020.9024C5.03512%2020.8399%205%2020.7551%205%2020.6667V3.33333C5%203.24493%205.03512%203.16014%205.09763%203.09763C5.16014%203.03512%205.24493%203%205.33333%203L15.7072%203ZM17.3363%201.66267C16.9004%201.23761%2016.3155%200.999803%2015.7067%201H5.33333C4.71449%201%204.121%201.24583%203.68342%201.68342C3.24583%202.121%203%202.7145%203%203.33333V20.6667C3%2021.2855%203.24583%2021.879%203.68342%2022.3166C4.121%2022.7542%204.71449%2023%205.33333%2023H18.3333C18.9522%2023%2019.5457%2022.7542%2019.9832%2022.3166C20.4208%2021.879%2020.6667%2021.2855%2020.6667%2020.6667V5.8959C20.6666%205.5845%2020.6043%205.27625%2020.4832%204.98932C20.3623%204.70259%2020.1848%204.44251%2019.962%204.22524L17.3363%201.66267ZM8.04004%206.66669C7.48775%206.66669%207.04004%207.1144%207.04004%207.66669C7.04004%208.21897%207.48775%208.66669%208.04004%208.66669H15.7067C16.259%208.66669%2016.7067%208.21897%2016.7067%207.66669C16.7067%207.1144%2016.259%206.66669%2015.7067%206.66669H8.04004ZM7.04004%2011.6667C7.04004%2011.1144%207.48775%2010.6667%208.04004%2010.6667H15.7067C16.259%2010.6667%2016.7067%2011.1144%2016.7067%2011.6667C16.7067%2012.219%2016.259%2012.6667%2015.7067%2012.6667H8.04004C7.48775%2012.6667%207.04004%2012.219%207.04004%2011.6667ZM8.04004%2014.6667C7.48775%2014.6667%207.04004%2015.1144%207.04004%2015.6667C7.04004%2016.219%207.48775%2016.6667%208.04004%2016.6667H11.3734C11.9257%2016.6667%2012.3734%2016.219%2012.3734%2015.6667C12.3734%2015.1144%2011.9257%2014.6667%2011.3734%2014.6667H8.04004Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+	warningEventsCheckActionId = "com.steadybit.extension_kubernetes.warning-events-check"
+	logIcon                    = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M15.7072%203C15.7942%202.99995%2015.8778%203.03393%2015.9401%203.09467L18.566%205.65743C18.5979%205.68848%2018.6232%205.7256%2018.6405%205.76659C18.6577%205.80754%2018.6666%205.85164%2018.6667%205.8961V20.6667C18.6667%2020.7551%2018.6315%2020.8399%2018.569%2020.9024C18.5065%2020.9649%2018.4217%2021%2018.3333%2021H5.33333C5.24493%2021%205.16014%2020.9649%205.09763%2020.9024C5.03512%2020.8399%205%2020.7551%205%2020.6667V3.33333C5%203.24493%205.03512%203.16014%205.09763%203.09763C5.16014%203.03512%205.24493%203%205.33333%203L15.7072%203ZM17.3363%201.66267C16.9004%201.23761%2016.3155%200.999803%2015.7067%201H5.33333C4.71449%201%204.121%201.24583%203.68342%201.68342C3.24583%202.121%203%202.7145%203%203.33333V20.6667C3%2021.2855%203.24583%2021.879%203.68342%2022.3166C4.121%2022.7542%204.71449%2023%205.33333%2023H18.3333C18.9522%2023%2019.5457%2022.7542%2019.9832%2022.3166C20.4208%2021.879%2020.6667%2021.2855%2020.6667%2020.6667V5.8959C20.6666%205.5845%2020.6043%205.27625%2020.4832%204.98932C20.3623%204.70259%2020.1848%204.44251%2019.962%204.22524L17.3363%201.66267ZM8.04004%206.66669C7.48775%206.66669%207.04004%207.1144%207.04004%207.66669C7.04004%208.21897%207.48775%208.66669%208.04004%208.66669H15.7067C16.259%208.66669%2016.7067%208.21897%2016.7067%207.66669C16.7067%207.1144%2016.259%206.66669%2015.7067%206.66669H8.04004ZM7.04004%2011.6667C7.04004%2011.1144%207.48775%2010.6667%208.04004%2010.6667H15.7067C16.259%2010.6667%2016.7067%2011.1144%2016.7067%2011.6667C16.7067%2012.219%2016.259%2012.6667%2015.7067%2012.6667H8.04004C7.48775%2012.6667%207.04004%2012.219%207.04004%2011.6667ZM8.04004%2014.6667C7.48775%2014.6667%207.04004%2015.1144%207.04004%2015.6667C7.04004%2016.219%207.48775%2016.6667%208.04004%2016.6667H11.3734C11.9257%2016.6667%2012.3734%2016.219%2012.3734%2015.6667C12.3734%2015.1144%2011.9257%2014.6667%2011.3734%2014.6667H8.04004Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
 )
@@ -14,6 +14,7 @@ import (
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	corev1 "k8s.io/api/core/v1"
 	"os"
 	"strings"
@@ -26,6 +27,7 @@ type K8sEventsAction struct {
 }
 
 type K8sEventsState struct {
+	Cluster       string `json:"cluster"`
 	LastEventTime *int64 `json:"lastEventTime"`
 	TimeoutEnd    *int64 `json:"timeoutEnd"`
 }
@@ -93,6 +95,9 @@ func (f K8sEventsAction) Describe() action_kit_api.ActionDescription {
 }
 
 func (f K8sEventsAction) Prepare(_ context.Context, state *K8sEventsState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
 	var config K8sEventsConfig
 	if err := extconversion.Convert(request.Config, &config); err != nil {
 		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
@@ -113,7 +118,7 @@ func (f K8sEventsAction) Start(_ context.Context, state *K8sEventsState) (*actio
 }
 
 func (f K8sEventsAction) Status(_ context.Context, state *K8sEventsState) (*action_kit_api.StatusResult, error) {
-	return statusInternal(client.K8S, state), nil
+	return statusInternal(client.ForCluster(state.Cluster), state), nil
 }
 
 func statusInternal(k8s *client.Client, state *K8sEventsState) *action_kit_api.StatusResult {
@@ -145,7 +150,7 @@ func getMessages(k8s *client.Client, state *K8sEventsState) *action_kit_api.Mess
 }
 
 func (f K8sEventsAction) Stop(_ context.Context, state *K8sEventsState) (*action_kit_api.StopResult, error) {
-	return stopInternal(client.K8S, state), nil
+	return stopInternal(client.ForCluster(state.Cluster), state), nil
 }
 
 func stopInternal(k8s *client.Client, state *K8sEventsState) *action_kit_api.StopResult {
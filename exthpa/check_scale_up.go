@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package exthpa
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"time"
+)
+
+type ScaleUpCheckAction struct {
+}
+
+type ScaleUpCheckState struct {
+	Cluster          string
+	Timeout          time.Time
+	Namespace        string
+	Name             string
+	ExpectedReplicas int32
+}
+
+type ScaleUpCheckConfig struct {
+	Duration         int
+	ExpectedReplicas int32
+}
+
+func NewScaleUpCheckAction() action_kit_sdk.Action[ScaleUpCheckState] {
+	return ScaleUpCheckAction{}
+}
+
+var _ action_kit_sdk.Action[ScaleUpCheckState] = (*ScaleUpCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ScaleUpCheckState] = (*ScaleUpCheckAction)(nil)
+
+func (f ScaleUpCheckAction) NewEmptyState() ScaleUpCheckState {
+	return ScaleUpCheckState{}
+}
+
+func (f ScaleUpCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          hpaScaleUpCheckActionId,
+		Label:       "Autoscaler Scale Up",
+		Description: "Verify that the horizontal pod autoscaler scales up within the timeout, e.g. while load is applied to its scale target.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(hpaIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          HorizontalPodAutoscalerTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find horizontal pod autoscaler by cluster, namespace and name"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.hpa=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Timeout",
+				Description:  extutil.Ptr("How long should the check wait for the autoscaler to scale up."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("5m"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "expectedReplicas",
+				Label:       "Expected replicas",
+				Description: extutil.Ptr("How many replicas the autoscaler should scale up to at least. Defaults to one more than at the start of the check."),
+				Type:        action_kit_api.Integer,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f ScaleUpCheckAction) Prepare(_ context.Context, state *ScaleUpCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareScaleUpCheckInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareScaleUpCheckInternal(k8s *client.Client, state *ScaleUpCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config ScaleUpCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Name = request.Target.Attributes["k8s.hpa"][0]
+
+	hpa := k8s.HorizontalPodAutoscalerByNamespaceAndName(state.Namespace, state.Name)
+	if hpa == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Horizontal pod autoscaler %s not found", state.Name), nil)
+	}
+	state.ExpectedReplicas = config.ExpectedReplicas
+	if state.ExpectedReplicas <= 0 {
+		state.ExpectedReplicas = hpa.Status.CurrentReplicas + 1
+	}
+	if state.ExpectedReplicas > hpa.Spec.MaxReplicas {
+		return nil, extension_kit.ToError(fmt.Sprintf("Horizontal pod autoscaler %s can't scale up to %d replicas, its max. replicas are %d.", state.Name, state.ExpectedReplicas, hpa.Spec.MaxReplicas), nil)
+	}
+	return nil, nil
+}
+
+func (f ScaleUpCheckAction) Start(_ context.Context, _ *ScaleUpCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f ScaleUpCheckAction) Status(_ context.Context, state *ScaleUpCheckState) (*action_kit_api.StatusResult, error) {
+	return statusScaleUpCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusScaleUpCheckInternal(k8s *client.Client, state *ScaleUpCheckState) *action_kit_api.StatusResult {
+	hpa := k8s.HorizontalPodAutoscalerByNamespaceAndName(state.Namespace, state.Name)
+	if hpa == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Horizontal pod autoscaler %s not found", state.Name),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	if hpa.Status.CurrentReplicas >= state.ExpectedReplicas {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+		}
+	}
+
+	if time.Now().After(state.Timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s did not scale up to %d replicas: %d current and %d desired replicas (min. %d, max. %d).", state.Name, state.ExpectedReplicas, hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas, minReplicas(hpa), hpa.Spec.MaxReplicas),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: false,
+	}
+}
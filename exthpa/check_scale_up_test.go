@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package exthpa
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestScaleUpCheckExpectsOneMoreReplicaByDefault(t *testing.T) {
+	// Given
+	k8sclient := createScaleUpTestClient(t, 2, 2)
+	var state ScaleUpCheckState
+
+	// When
+	_, err := prepareScaleUpCheckInternal(k8sclient, &state, scaleUpRequest(0))
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, int32(3), state.ExpectedReplicas)
+}
+
+func TestScaleUpCheckRejectsExpectedReplicasAboveMax(t *testing.T) {
+	// Given
+	k8sclient := createScaleUpTestClient(t, 2, 2)
+	var state ScaleUpCheckState
+
+	// When
+	_, err := prepareScaleUpCheckInternal(k8sclient, &state, scaleUpRequest(11))
+
+	// Then
+	require.EqualError(t, err, "Horizontal pod autoscaler checkout can't scale up to 11 replicas, its max. replicas are 10.")
+}
+
+func TestScaleUpCheckCompletesWhenScaledUp(t *testing.T) {
+	// Given
+	k8sclient := createScaleUpTestClient(t, 4, 4)
+	state := scaleUpTestState(time.Now().Add(time.Minute), 3)
+
+	// When
+	result := statusScaleUpCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestScaleUpCheckKeepsWaitingWhileScalingUp(t *testing.T) {
+	// Given
+	k8sclient := createScaleUpTestClient(t, 2, 4)
+	state := scaleUpTestState(time.Now().Add(time.Minute), 3)
+
+	// When
+	result := statusScaleUpCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestScaleUpCheckFailsAfterTimeout(t *testing.T) {
+	// Given
+	k8sclient := createScaleUpTestClient(t, 2, 2)
+	state := scaleUpTestState(time.Now().Add(-time.Second), 3)
+
+	// When
+	result := statusScaleUpCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout did not scale up to 3 replicas: 2 current and 2 desired replicas (min. 2, max. 10).", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestScaleUpCheckAutoscalerNotFound(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	state := scaleUpTestState(time.Now().Add(time.Minute), 3)
+
+	// When
+	result := statusScaleUpCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Equal(t, "Horizontal pod autoscaler checkout not found", result.Error.Title)
+	require.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func scaleUpRequest(expectedReplicas int) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":         1000 * 60,
+			"expectedReplicas": expectedReplicas,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace": {"shop"},
+				"k8s.hpa":       {"checkout"},
+			},
+		}),
+	}
+}
+
+func scaleUpTestState(timeout time.Time, expectedReplicas int32) ScaleUpCheckState {
+	return ScaleUpCheckState{
+		Timeout:          timeout,
+		Namespace:        "shop",
+		Name:             "checkout",
+		ExpectedReplicas: expectedReplicas,
+	}
+}
+
+func createScaleUpTestClient(t *testing.T, currentReplicas int32, desiredReplicas int32) *client.Client {
+	return testsupport.NewClientBuilder(t).
+		WithHorizontalPodAutoscalers(&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "checkout"},
+				MinReplicas:    extutil.Ptr(int32(2)),
+				MaxReplicas:    10,
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: currentReplicas,
+				DesiredReplicas: desiredReplicas,
+			},
+		}).
+		Build()
+}
@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package exthpa
+
+const (
+	HorizontalPodAutoscalerTargetType = "com.steadybit.extension_kubernetes.kubernetes-horizontal-pod-autoscaler"
+	hpaIcon                           = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M3%2013C3%2012.45%203.45%2012%204%2012H9C9.55%2012%2010%2012.45%2010%2013V20C10%2020.55%209.55%2021%209%2021H4C3.45%2021%203%2020.55%203%2020V13ZM5%2014V19H8V14H5ZM12%209C12%208.45%2012.45%208%2013%208H20C20.55%208%2021%208.45%2021%209V20C21%2020.55%2020.55%2021%2020%2021H13C12.45%2021%2012%2020.55%2012%2020V9ZM14%2010V19H19V10H14ZM6.5%203.29L9.71%206.5L8.29%207.91L7.5%207.12V10H5.5V7.12L4.71%207.91L3.29%206.5L6.5%203.29Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+	hpaScaleUpCheckActionId           = "com.steadybit.extension_kubernetes.hpa-scale-up-check"
+)
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package exthpa
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"net/http"
+)
+
+func RegisterHorizontalPodAutoscalerDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/hpa/discovery", exthttp.GetterAsHandler(getHorizontalPodAutoscalerDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/hpa/discovery/target-description", exthttp.GetterAsHandler(getHorizontalPodAutoscalerTargetDescription))
+	exthttp.RegisterHttpHandler("/hpa/discovery/discovered-targets", getDiscoveredHorizontalPodAutoscalers)
+}
+
+func getHorizontalPodAutoscalerDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         HorizontalPodAutoscalerTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/hpa/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getHorizontalPodAutoscalerTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       HorizontalPodAutoscalerTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes HorizontalPodAutoscaler", Other: "Kubernetes HorizontalPodAutoscalers"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(hpaIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.hpa"},
+				{Attribute: "k8s.hpa.target-ref"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.hpa",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredHorizontalPodAutoscalers(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredHorizontalPodAutoscalerTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredHorizontalPodAutoscalerTargets(k8s *client.Client) []discovery_kit_api.Target {
+	hpas := k8s.HorizontalPodAutoscalers()
+
+	filteredHpas := make([]*autoscalingv2.HorizontalPodAutoscaler, 0, len(hpas))
+	if extconfig.Config.DisableDiscoveryExcludes {
+		filteredHpas = hpas
+	} else {
+		for _, hpa := range hpas {
+			if client.IsExcludedFromDiscovery(hpa.ObjectMeta) {
+				continue
+			}
+			filteredHpas = append(filteredHpas, hpa)
+		}
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredHpas))
+	for i, hpa := range filteredHpas {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), hpa.Namespace, hpa.Name)
+		attributes := extcommon.NewAttributes()
+		attributes.Set("k8s.namespace", hpa.Namespace)
+		attributes.Set("k8s.hpa", hpa.Name)
+		attributes.SetInt("k8s.hpa.min-replicas", int64(minReplicas(hpa)))
+		attributes.SetInt("k8s.hpa.max-replicas", int64(hpa.Spec.MaxReplicas))
+		attributes.SetInt("k8s.hpa.current-replicas", int64(hpa.Status.CurrentReplicas))
+		attributes.SetInt("k8s.hpa.desired-replicas", int64(hpa.Status.DesiredReplicas))
+		attributes.Set("k8s.hpa.target-ref", fmt.Sprintf("%s/%s", hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name))
+		attributes.Set("k8s.cluster-name", k8s.ClusterName())
+		attributes.Set("k8s.distribution", k8s.Distribution)
+
+		addScaleTargetAttributes(k8s, hpa, attributes)
+
+		for key, value := range hpa.ObjectMeta.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes.Set(fmt.Sprintf("k8s.hpa.label.%v", key), value)
+				attributes.Set(fmt.Sprintf("k8s.label.%v", key), value)
+			}
+		}
+
+		extcommon.AddNamespaceAttributes(k8s, hpa.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesHpa)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: HorizontalPodAutoscalerTargetType,
+			Label:      hpa.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
+
+// addScaleTargetAttributes correlates the autoscaler with the deployment or statefulset it scales, so that it can be
+// selected alongside them. Scale targets which don't exist (anymore) are skipped.
+func addScaleTargetAttributes(k8s *client.Client, hpa *autoscalingv2.HorizontalPodAutoscaler, attributes extcommon.Attributes) {
+	ref := hpa.Spec.ScaleTargetRef
+	switch ref.Kind {
+	case "Deployment":
+		if k8s.DeploymentByNamespaceAndName(hpa.Namespace, ref.Name) != nil {
+			attributes.Set("k8s.deployment", ref.Name)
+		}
+	case "StatefulSet":
+		if k8s.StatefulSetByNamespaceAndName(hpa.Namespace, ref.Name) != nil {
+			attributes.Set("k8s.statefulset", ref.Name)
+		}
+	}
+}
+
+// minReplicas returns the lower bound of the autoscaler, which defaults to 1.
+func minReplicas(hpa *autoscalingv2.HorizontalPodAutoscaler) int32 {
+	if hpa.Spec.MinReplicas == nil {
+		return 1
+	}
+	return *hpa.Spec.MinReplicas
+}
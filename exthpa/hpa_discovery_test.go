@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package exthpa
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getDiscoveredHorizontalPodAutoscalers(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	k8s := testsupport.NewClientBuilder(t).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		}).
+		WithHorizontalPodAutoscalers(&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout",
+				Namespace: "shop",
+				Labels: map[string]string{
+					"best-city":    "Kevelaer",
+					"secret-label": "secret-value",
+				},
+			},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "checkout", APIVersion: "apps/v1"},
+				MinReplicas:    extutil.Ptr(int32(2)),
+				MaxReplicas:    10,
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: 3,
+				DesiredReplicas: 4,
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredHorizontalPodAutoscalerTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/shop/checkout", target.Id)
+	assert.Equal(t, "checkout", target.Label)
+	assert.Equal(t, HorizontalPodAutoscalerTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":            {"shop"},
+		"k8s.hpa":                  {"checkout"},
+		"k8s.hpa.min-replicas":     {"2"},
+		"k8s.hpa.max-replicas":     {"10"},
+		"k8s.hpa.current-replicas": {"3"},
+		"k8s.hpa.desired-replicas": {"4"},
+		"k8s.hpa.target-ref":       {"Deployment/checkout"},
+		"k8s.deployment":           {"checkout"},
+		"k8s.cluster-name":         {"development"},
+		"k8s.distribution":         {"kubernetes"},
+		"k8s.hpa.label.best-city":  {"Kevelaer"},
+		"k8s.label.best-city":      {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredHorizontalPodAutoscalersShouldCorrelateStatefulSets(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithStatefulSets(&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+		}).
+		WithHorizontalPodAutoscalers(
+			&autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "StatefulSet", Name: "db"},
+					MaxReplicas:    3,
+				},
+			},
+			&autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "gone", Namespace: "shop"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "gone"},
+					MaxReplicas:    3,
+				},
+			},
+		).
+		Build()
+
+	// When
+	targets := getDiscoveredHorizontalPodAutoscalerTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 2)
+	byName := map[string]map[string][]string{}
+	for _, target := range targets {
+		byName[target.Label] = target.Attributes
+	}
+	assert.Equal(t, []string{"db"}, byName["db"]["k8s.statefulset"])
+	assert.Equal(t, []string{"1"}, byName["db"]["k8s.hpa.min-replicas"])
+	assert.Equal(t, []string{"Deployment/gone"}, byName["gone"]["k8s.hpa.target-ref"])
+	assert.NotContains(t, byName["gone"], "k8s.deployment")
+}
+
+func Test_getDiscoveredHorizontalPodAutoscalersShouldIgnoreExcludedAutoscalers(t *testing.T) {
+	// Given
+	extconfig.Config.DisableDiscoveryExcludes = false
+	k8s := testsupport.NewClientBuilder(t).
+		WithHorizontalPodAutoscalers(&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout",
+				Namespace: "shop",
+				Labels:    map[string]string{"steadybit.com/discovery-disabled": "true"},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredHorizontalPodAutoscalerTargets(k8s)
+
+	// Then
+	require.Empty(t, targets)
+}
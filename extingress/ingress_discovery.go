@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extingress
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	networkingv1 "k8s.io/api/networking/v1"
+	"net/http"
+	"sort"
+)
+
+func RegisterIngressDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/ingress/discovery", exthttp.GetterAsHandler(getIngressDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/ingress/discovery/target-description", exthttp.GetterAsHandler(getIngressTargetDescription))
+	exthttp.RegisterHttpHandler("/ingress/discovery/discovered-targets", getDiscoveredIngresses)
+}
+
+func getIngressDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         IngressTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/ingress/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getIngressTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       IngressTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes Ingress", Other: "Kubernetes Ingresses"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(ingressIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.ingress"},
+				{Attribute: "k8s.ingress.host"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.ingress",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredIngresses(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredIngressTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredIngressTargets(k8s *client.Client) []discovery_kit_api.Target {
+	ingresses := k8s.Ingresses()
+
+	filteredIngresses := make([]*networkingv1.Ingress, 0, len(ingresses))
+	if extconfig.Config.DisableDiscoveryExcludes {
+		filteredIngresses = ingresses
+	} else {
+		for _, i := range ingresses {
+			if client.IsExcludedFromDiscovery(i.ObjectMeta) {
+				continue
+			}
+			filteredIngresses = append(filteredIngresses, i)
+		}
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredIngresses))
+	for idx, i := range filteredIngresses {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), i.Namespace, i.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":    {i.Namespace},
+			"k8s.ingress":      {i.Name},
+			"k8s.cluster-name": {k8s.ClusterName()},
+			"k8s.distribution": {k8s.Distribution},
+		}
+
+		if class := ingressClass(i); class != "" {
+			attributes["k8s.ingress.class"] = []string{class}
+		}
+
+		hosts, paths, services := ingressRoutes(i)
+		if len(hosts) > 0 {
+			attributes["k8s.ingress.host"] = hosts
+		}
+		if len(paths) > 0 {
+			attributes["k8s.ingress.path"] = paths
+		}
+		if len(services) > 0 {
+			attributes["k8s.ingress.backend.service"] = services
+		}
+
+		for key, value := range i.ObjectMeta.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes[fmt.Sprintf("k8s.ingress.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		extcommon.AddNamespaceAttributes(k8s, i.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesIngress)
+
+		targets[idx] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: IngressTargetType,
+			Label:      i.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
+
+// ingressClass falls back to the deprecated annotation, which is still used by many ingresses.
+func ingressClass(i *networkingv1.Ingress) string {
+	if i.Spec.IngressClassName != nil {
+		return *i.Spec.IngressClassName
+	}
+	return i.Annotations["kubernetes.io/ingress.class"]
+}
+
+// ingressRoutes returns the sorted and distinct hosts, paths (as <host><path>) and backend services of the ingress,
+// including the default backend.
+func ingressRoutes(i *networkingv1.Ingress) (hosts []string, paths []string, services []string) {
+	hostSet := map[string]bool{}
+	pathSet := map[string]bool{}
+	serviceSet := map[string]bool{}
+	if i.Spec.DefaultBackend != nil && i.Spec.DefaultBackend.Service != nil {
+		serviceSet[i.Spec.DefaultBackend.Service.Name] = true
+	}
+	for _, rule := range i.Spec.Rules {
+		if rule.Host != "" {
+			hostSet[rule.Host] = true
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			pathSet[rule.Host+path.Path] = true
+			if path.Backend.Service != nil {
+				serviceSet[path.Backend.Service.Name] = true
+			}
+		}
+	}
+	return sortedKeys(hostSet), sortedKeys(pathSet), sortedKeys(serviceSet)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extingress
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getDiscoveredIngresses(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	k8s := testsupport.NewClientBuilder(t).
+		WithIngresses(&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+				Labels: map[string]string{
+					"best-city":    "Kevelaer",
+					"secret-label": "secret-value",
+				},
+			},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: extutil.Ptr("nginx"),
+				DefaultBackend:   serviceBackend("fallback"),
+				Rules: []networkingv1.IngressRule{
+					httpRule("shop.example.com", map[string]string{"/": "frontend", "/api": "checkout"}),
+					httpRule("admin.example.com", map[string]string{"/": "frontend"}),
+				},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredIngressTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/shop", target.Id)
+	assert.Equal(t, "shop", target.Label)
+	assert.Equal(t, IngressTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":               {"default"},
+		"k8s.ingress":                 {"shop"},
+		"k8s.cluster-name":            {"development"},
+		"k8s.distribution":            {"kubernetes"},
+		"k8s.ingress.class":           {"nginx"},
+		"k8s.ingress.host":            {"admin.example.com", "shop.example.com"},
+		"k8s.ingress.path":            {"admin.example.com/", "shop.example.com/", "shop.example.com/api"},
+		"k8s.ingress.backend.service": {"checkout", "fallback", "frontend"},
+		"k8s.ingress.label.best-city": {"Kevelaer"},
+		"k8s.label.best-city":         {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredIngressesShouldFallBackToClassAnnotation(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithIngresses(&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "shop",
+				Namespace:   "default",
+				Annotations: map[string]string{"kubernetes.io/ingress.class": "traefik"},
+			},
+			Spec: networkingv1.IngressSpec{
+				DefaultBackend: serviceBackend("frontend"),
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredIngressTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"traefik"}, targets[0].Attributes["k8s.ingress.class"])
+	assert.Equal(t, []string{"frontend"}, targets[0].Attributes["k8s.ingress.backend.service"])
+	assert.NotContains(t, targets[0].Attributes, "k8s.ingress.host")
+}
+
+func Test_getDiscoveredIngressesShouldIgnoreLabeledIngresses(t *testing.T) {
+	// Given
+	extconfig.Config.DisableDiscoveryExcludes = false
+	k8s := testsupport.NewClientBuilder(t).
+		WithIngresses(&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+				Labels: map[string]string{
+					"steadybit.com/discovery-disabled": "true",
+				},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredIngressTargets(k8s)
+
+	// Then
+	require.Empty(t, targets)
+}
+
+func serviceBackend(service string) *networkingv1.IngressBackend {
+	return &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: service, Port: networkingv1.ServiceBackendPort{Number: 80}},
+	}
+}
+
+func httpRule(host string, services map[string]string) networkingv1.IngressRule {
+	var paths []networkingv1.HTTPIngressPath
+	for path, service := range services {
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path:     path,
+			PathType: extutil.Ptr(networkingv1.PathTypePrefix),
+			Backend:  *serviceBackend(service),
+		})
+	}
+	return networkingv1.IngressRule{
+		Host: host,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+		},
+	}
+}
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extjob
+
+const (
+	JobTargetType = "com.steadybit.extension_kubernetes.kubernetes-job"
+	jobIcon       = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M6%203C4.34315%203%203%204.34315%203%206V18C3%2019.6569%204.34315%2021%206%2021H18C19.6569%2021%2021%2019.6569%2021%2018V6C21%204.34315%2019.6569%203%2018%203H6ZM5%206C5%205.44772%205.44772%205%206%205H18C18.5523%205%2019%205.44772%2019%206V18C19%2018.5523%2018.5523%2019%2018%2019H6C5.44772%2019%205%2018.5523%205%2018V6Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M16.7071%209.70711C17.0976%209.31658%2017.0976%208.68342%2016.7071%208.29289C16.3166%207.90237%2015.6834%207.90237%2015.2929%208.29289L10.5%2013.0858L8.70711%2011.2929C8.31658%2010.9024%207.68342%2010.9024%207.29289%2011.2929C6.90237%2011.6834%206.90237%2012.3166%207.29289%2012.7071L9.79289%2015.2071C10.1834%2015.5976%2010.8166%2015.5976%2011.2071%2015.2071L16.7071%209.70711Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+)
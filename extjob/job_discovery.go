@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extjob
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strconv"
+)
+
+func RegisterJobDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/job/discovery", exthttp.GetterAsHandler(getJobDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/job/discovery/target-description", exthttp.GetterAsHandler(getJobTargetDescription))
+	exthttp.RegisterHttpHandler("/job/discovery/discovered-targets", getDiscoveredJobs)
+}
+
+func getJobDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         JobTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/job/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getJobTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       JobTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes Job", Other: "Kubernetes Jobs"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(jobIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.job"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.job",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredJobs(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredJobTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+// getDiscoveredJobTargets also reports finished jobs. They stay discoverable until they are garbage-collected, e.g.
+// after ttlSecondsAfterFinished, so that checks can still verify the outcome of a job after it completed.
+func getDiscoveredJobTargets(k8s *client.Client) []discovery_kit_api.Target {
+	jobs := k8s.Jobs()
+
+	filteredJobs := make([]*batchv1.Job, 0, len(jobs))
+	if extconfig.Config.DisableDiscoveryExcludes {
+		filteredJobs = jobs
+	} else {
+		for _, j := range jobs {
+			if client.IsExcludedFromDiscovery(j.ObjectMeta) {
+				continue
+			}
+			filteredJobs = append(filteredJobs, j)
+		}
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredJobs))
+	for i, j := range filteredJobs {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), j.Namespace, j.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":     {j.Namespace},
+			"k8s.job":           {j.Name},
+			"k8s.cluster-name":  {k8s.ClusterName()},
+			"k8s.distribution":  {k8s.Distribution},
+			"k8s.job.active":    {strconv.Itoa(int(j.Status.Active))},
+			"k8s.job.succeeded": {strconv.Itoa(int(j.Status.Succeeded))},
+			"k8s.job.failed":    {strconv.Itoa(int(j.Status.Failed))},
+		}
+
+		if j.Spec.Completions != nil {
+			attributes["k8s.job.completions"] = []string{strconv.Itoa(int(*j.Spec.Completions))}
+		}
+		if j.Spec.Parallelism != nil {
+			attributes["k8s.job.parallelism"] = []string{strconv.Itoa(int(*j.Spec.Parallelism))}
+		}
+
+		if owner := metav1.GetControllerOf(j); owner != nil && owner.Kind == "CronJob" {
+			attributes["k8s.cronjob"] = []string{owner.Name}
+		}
+
+		for key, value := range j.ObjectMeta.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes[fmt.Sprintf("k8s.job.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		extcommon.AddNamespaceAttributes(k8s, j.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesJob)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: JobTargetType,
+			Label:      j.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
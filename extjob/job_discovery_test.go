@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extjob
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getDiscoveredJobs(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	k8s := testsupport.NewClientBuilder(t).
+		WithJobs(&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "import",
+				Namespace: "default",
+				Labels: map[string]string{
+					"best-city":    "Kevelaer",
+					"secret-label": "secret-value",
+				},
+			},
+			Spec: batchv1.JobSpec{
+				Completions: extutil.Ptr(int32(5)),
+				Parallelism: extutil.Ptr(int32(2)),
+			},
+			Status: batchv1.JobStatus{
+				Active:    2,
+				Succeeded: 2,
+				Failed:    1,
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredJobTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/import", target.Id)
+	assert.Equal(t, "import", target.Label)
+	assert.Equal(t, JobTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":           {"default"},
+		"k8s.job":                 {"import"},
+		"k8s.cluster-name":        {"development"},
+		"k8s.distribution":        {"kubernetes"},
+		"k8s.job.completions":     {"5"},
+		"k8s.job.parallelism":     {"2"},
+		"k8s.job.active":          {"2"},
+		"k8s.job.succeeded":       {"2"},
+		"k8s.job.failed":          {"1"},
+		"k8s.job.label.best-city": {"Kevelaer"},
+		"k8s.label.best-city":     {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredJobsShouldReportFinishedJobsUntilGarbageCollected(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithJobs(&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cleanup-28100",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "batch/v1", Kind: "CronJob", Name: "cleanup", Controller: extutil.Ptr(true)},
+				},
+			},
+			Spec: batchv1.JobSpec{
+				TTLSecondsAfterFinished: extutil.Ptr(int32(60)),
+			},
+			Status: batchv1.JobStatus{
+				Succeeded: 1,
+				Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+				},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredJobTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"1"}, targets[0].Attributes["k8s.job.succeeded"])
+	assert.Equal(t, []string{"cleanup"}, targets[0].Attributes["k8s.cronjob"])
+}
+
+func Test_getDiscoveredJobsShouldIgnoreLabeledJobs(t *testing.T) {
+	// Given
+	extconfig.Config.DisableDiscoveryExcludes = false
+	k8s := testsupport.NewClientBuilder(t).
+		WithJobs(&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "import",
+				Namespace: "default",
+				Labels: map[string]string{
+					"steadybit.com/discovery-disabled": "true",
+				},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredJobTargets(k8s)
+
+	// Then
+	require.Empty(t, targets)
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"time"
+)
+
+// reregistrationPollInterval is a variable so tests don't need to wait.
+var reregistrationPollInterval = 5 * time.Second
+
+type DeleteNodeObjectAction struct {
+}
+
+type DeleteNodeObjectState struct {
+	Cluster               string
+	Node                  string
+	DeletedUid            types.UID
+	ReregistrationTimeout time.Duration
+}
+
+type DeleteNodeObjectConfig struct {
+	Node                  string
+	Confirm               bool
+	ReregistrationTimeout int
+	MaxBlastRadiusPercent *int
+}
+
+func NewDeleteNodeObjectAction() action_kit_sdk.Action[DeleteNodeObjectState] {
+	return DeleteNodeObjectAction{}
+}
+
+var _ action_kit_sdk.Action[DeleteNodeObjectState] = (*DeleteNodeObjectAction)(nil)
+var _ action_kit_sdk.ActionWithStop[DeleteNodeObjectState] = (*DeleteNodeObjectAction)(nil)
+
+func (f DeleteNodeObjectAction) NewEmptyState() DeleteNodeObjectState {
+	return DeleteNodeObjectState{}
+}
+
+func (f DeleteNodeObjectAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          deleteNodeObjectActionId,
+		Label:       "Delete Node Object",
+		Description: "Delete the node object from the Kubernetes API without touching the underlying machine, simulating a node vanishing from the cluster. The kubelet is expected to register the node again.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(nodeCountCheckIcon),
+		Category:    extutil.Ptr("state"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long to wait before checking the node for re-registration."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "node",
+				Label:       "Node",
+				Description: extutil.Ptr("The name of the node object to delete."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(true),
+			},
+			{
+				Name:         "confirm",
+				Label:        "I understand that the node object is deleted",
+				Description:  extutil.Ptr("Pods on the node are garbage collected. Nodes which don't register themselves again (e.g. with a static node configuration) are lost."),
+				Type:         action_kit_api.Boolean,
+				DefaultValue: extutil.Ptr("false"),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "reregistrationTimeout",
+				Label:        "Re-registration timeout",
+				Description:  extutil.Ptr("How long to wait for the node to register again after the attack."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("5m"),
+				Order:        extutil.Ptr(4),
+				Required:     extutil.Ptr(true),
+				Advanced:     extutil.Ptr(true),
+			},
+			extcommon.MaxBlastRadiusPercentParameter(5),
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f DeleteNodeObjectAction) Prepare(_ context.Context, state *DeleteNodeObjectState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareDeleteNodeObjectInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareDeleteNodeObjectInternal(k8s *client.Client, state *DeleteNodeObjectState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config DeleteNodeObjectConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	if !config.Confirm {
+		return nil, extension_kit.ToError("Deleting a node object needs to be confirmed.", nil)
+	}
+
+	found := false
+	for _, node := range k8s.Nodes() {
+		if node.Name == config.Node {
+			found = true
+		}
+	}
+	if !found {
+		return nil, extension_kit.ToError(fmt.Sprintf("Node %s not found", config.Node), nil)
+	}
+	if err := extcommon.CheckBlastRadius("nodes", 1, len(k8s.Nodes()), config.MaxBlastRadiusPercent); err != nil {
+		return nil, err
+	}
+	// All pods of the node are affected, so a single heavily loaded node may exceed the blast radius on its own.
+	if err := extcommon.CheckBlastRadius("pods", len(k8s.PodsByNode(config.Node)), len(k8s.Pods()), config.MaxBlastRadiusPercent); err != nil {
+		return nil, err
+	}
+	// The pods of the deleted node are garbage collected, standalone ones are lost for good.
+	if err := extcommon.CheckStandalonePodDeletion(k8s.PodsByNode(config.Node)); err != nil {
+		return nil, err
+	}
+
+	state.Node = config.Node
+	state.ReregistrationTimeout = time.Millisecond * time.Duration(config.ReregistrationTimeout)
+	return nil, nil
+}
+
+func (f DeleteNodeObjectAction) Start(ctx context.Context, state *DeleteNodeObjectState) (*action_kit_api.StartResult, error) {
+	return startDeleteNodeObjectInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func startDeleteNodeObjectInternal(ctx context.Context, k8s *client.Client, state *DeleteNodeObjectState) (*action_kit_api.StartResult, error) {
+	node, err := k8s.Clientset().CoreV1().Nodes().Get(ctx, state.Node, metav1.GetOptions{})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get node %s.", state.Node), err)
+	}
+
+	log.Info().Msgf("Deleting node object %s", state.Node)
+	err = k8s.Clientset().CoreV1().Nodes().Delete(ctx, state.Node, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &node.UID},
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to delete node %s.", state.Node), err)
+	}
+	state.DeletedUid = node.UID
+	return nil, nil
+}
+
+func (f DeleteNodeObjectAction) Stop(ctx context.Context, state *DeleteNodeObjectState) (*action_kit_api.StopResult, error) {
+	return stopDeleteNodeObjectInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func stopDeleteNodeObjectInternal(ctx context.Context, k8s *client.Client, state *DeleteNodeObjectState) (*action_kit_api.StopResult, error) {
+	if state.DeletedUid == "" {
+		return nil, nil
+	}
+
+	// A re-registered node has the same name but a new uid.
+	err := wait.PollUntilContextTimeout(ctx, reregistrationPollInterval, state.ReregistrationTimeout, true, func(ctx context.Context) (bool, error) {
+		node, err := k8s.Clientset().CoreV1().Nodes().Get(ctx, state.Node, metav1.GetOptions{})
+		if k8sErrors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			log.Warn().Err(err).Msgf("Failed to get node %s.", state.Node)
+			return false, nil
+		}
+		return node.UID != state.DeletedUid, nil
+	})
+	if err != nil {
+		return &action_kit_api.StopResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Node %s didn't register again within %s.", state.Node, state.ReregistrationTimeout),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}, nil
+	}
+	log.Info().Msgf("Node %s registered again", state.Node)
+	return nil, nil
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+	"time"
+)
+
+func TestDeleteNodeObjectPrepareRequiresConfirmation(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).WithNodes(nodeWithUid("worker-1", "1")).Build()
+	state := NewDeleteNodeObjectAction().NewEmptyState()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"node":    "worker-1",
+			"confirm": false,
+		},
+	}
+
+	// When
+	_, err := prepareDeleteNodeObjectInternal(k8s, &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "needs to be confirmed")
+}
+
+func TestDeleteNodeObjectPrepareChecksBlastRadius(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 100
+	k8s := testsupport.NewClientBuilder(t).WithNodes(nodeWithUid("worker-1", "1"), nodeWithUid("worker-2", "2")).Build()
+	state := NewDeleteNodeObjectAction().NewEmptyState()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"node":                  "worker-1",
+			"confirm":               true,
+			"maxBlastRadiusPercent": 40,
+		},
+	}
+
+	// When
+	_, err := prepareDeleteNodeObjectInternal(k8s, &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "exceeds the max. blast radius of 40%")
+}
+
+func TestDeleteNodeObjectPrepareChecksBlastRadiusOfPods(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 60
+	defer func() { extconfig.Config.MaxBlastRadiusPercent = 100 }()
+	k8s := testsupport.NewClientBuilder(t).
+		WithNodes(nodeWithUid("worker-1", "1"), nodeWithUid("worker-2", "2")).
+		WithPods(podOnNode("cart", "worker-1"), podOnNode("checkout", "worker-1"), podOnNode("search", "worker-2")).
+		Build()
+	state := NewDeleteNodeObjectAction().NewEmptyState()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"node":    "worker-1",
+			"confirm": true,
+		},
+	}
+
+	// When
+	_, err := prepareDeleteNodeObjectInternal(k8s, &state, request)
+
+	// Then
+	require.EqualError(t, err, "Attack would affect 2 of 3 pods (67%), which exceeds the max. blast radius of 60%.")
+}
+
+func TestDeleteNodeObjectPrepareRefusesToLoseStandalonePods(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 100
+	k8s := testsupport.NewClientBuilder(t).
+		WithNodes(nodeWithUid("worker-1", "1")).
+		WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "worker-1"},
+		}).
+		Build()
+	state := NewDeleteNodeObjectAction().NewEmptyState()
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"node":    "worker-1",
+			"confirm": true,
+		},
+	}
+
+	// When
+	_, err := prepareDeleteNodeObjectInternal(k8s, &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Refusing to delete pods without a controller, they won't be recreated: default/debug.")
+
+	// When
+	extconfig.Config.AllowStandalonePodDeletion = true
+	defer func() { extconfig.Config.AllowStandalonePodDeletion = false }()
+	_, err = prepareDeleteNodeObjectInternal(k8s, &state, request)
+
+	// Then
+	require.NoError(t, err)
+}
+
+func TestDeleteNodeObjectStartDeletesNode(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithNodes(nodeWithUid("worker-1", "1"))
+	k8s := builder.Build()
+	state := DeleteNodeObjectState{Node: "worker-1"}
+
+	// When
+	_, err := startDeleteNodeObjectInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, types.UID("1"), state.DeletedUid)
+	_, err = builder.Clientset.CoreV1().Nodes().Get(context.Background(), "worker-1", metav1.GetOptions{})
+	require.True(t, k8sErrors.IsNotFound(err))
+}
+
+func TestDeleteNodeObjectStopWaitsForReregistration(t *testing.T) {
+	// Given
+	reregistrationPollInterval = 10 * time.Millisecond
+	defer func() { reregistrationPollInterval = 5 * time.Second }()
+	builder := testsupport.NewClientBuilder(t).WithNodes(nodeWithUid("worker-1", "1"))
+	k8s := builder.Build()
+	state := DeleteNodeObjectState{Node: "worker-1", ReregistrationTimeout: 5 * time.Second}
+	_, err := startDeleteNodeObjectInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = builder.Clientset.CoreV1().Nodes().Create(context.Background(), nodeWithUid("worker-1", "2"), metav1.CreateOptions{})
+	}()
+
+	// When
+	result, err := stopDeleteNodeObjectInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func TestDeleteNodeObjectStopFailsIfNodeDoesNotReregister(t *testing.T) {
+	// Given
+	reregistrationPollInterval = 10 * time.Millisecond
+	defer func() { reregistrationPollInterval = 5 * time.Second }()
+	k8s := testsupport.NewClientBuilder(t).WithNodes(nodeWithUid("worker-1", "1")).Build()
+	state := DeleteNodeObjectState{Node: "worker-1", ReregistrationTimeout: 100 * time.Millisecond}
+	_, err := startDeleteNodeObjectInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+
+	// When
+	result, err := stopDeleteNodeObjectInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "Node worker-1 didn't register again within 100ms.", result.Error.Title)
+}
+
+func nodeWithUid(name string, uid types.UID) *corev1.Node {
+	node := testsupport.ReadyNode(name)
+	node.UID = uid
+	return node
+}
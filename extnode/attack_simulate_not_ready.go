@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"time"
+)
+
+// simulatedNotReadyReason marks the ready condition as set by the attack rather than by the kubelet.
+const (
+	simulatedNotReadyReason  = "SteadybitSimulatedNotReady"
+	simulatedNotReadyMessage = "Node is reported as not ready by steadybit."
+)
+
+type SimulateNodeNotReadyAction struct {
+}
+
+type SimulateNodeNotReadyState struct {
+	Cluster         string
+	Node            string
+	OriginalReason  string
+	OriginalMessage string
+	VerifyRestore   bool
+}
+
+type SimulateNodeNotReadyConfig struct {
+	Node                  string
+	MaxBlastRadiusPercent *int
+	VerifyRestore         bool
+}
+
+func NewSimulateNodeNotReadyAction() action_kit_sdk.Action[SimulateNodeNotReadyState] {
+	return SimulateNodeNotReadyAction{}
+}
+
+var _ action_kit_sdk.Action[SimulateNodeNotReadyState] = (*SimulateNodeNotReadyAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[SimulateNodeNotReadyState] = (*SimulateNodeNotReadyAction)(nil)
+var _ action_kit_sdk.ActionWithStop[SimulateNodeNotReadyState] = (*SimulateNodeNotReadyAction)(nil)
+
+func (f SimulateNodeNotReadyAction) NewEmptyState() SimulateNodeNotReadyState {
+	return SimulateNodeNotReadyState{}
+}
+
+func (f SimulateNodeNotReadyAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          simulateNotReadyActionId,
+		Label:       "Simulate Node NotReady",
+		Description: "Report the node as not ready by patching its ready condition, without touching the kubelet. Kubernetes taints the node and evicts its pods as if the kubelet stopped responding. Requires permission to patch nodes/status.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(nodeCountCheckIcon),
+		Category:    extutil.Ptr("state"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the node be reported as not ready."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "node",
+				Label:       "Node",
+				Description: extutil.Ptr("The name of the node to report as not ready."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(true),
+			},
+			extcommon.MaxBlastRadiusPercentParameter(3),
+			extcommon.VerifyRestoreParameter(4),
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		// The kubelet reports the node as ready again with its next status update, so the condition is re-applied.
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f SimulateNodeNotReadyAction) Prepare(_ context.Context, state *SimulateNodeNotReadyState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareSimulateNodeNotReadyInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareSimulateNodeNotReadyInternal(k8s *client.Client, state *SimulateNodeNotReadyState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config SimulateNodeNotReadyConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+
+	node := k8s.NodeByName(config.Node)
+	if node == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Node %s not found", config.Node), nil)
+	}
+	ready := readyCondition(node)
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		return nil, extension_kit.ToError(fmt.Sprintf("Node %s is not ready.", config.Node), nil)
+	}
+	if err := extcommon.CheckBlastRadius("nodes", 1, len(k8s.Nodes()), config.MaxBlastRadiusPercent); err != nil {
+		return nil, err
+	}
+	// All pods of the node are affected, so a single heavily loaded node may exceed the blast radius on its own.
+	if err := extcommon.CheckBlastRadius("pods", len(k8s.PodsByNode(config.Node)), len(k8s.Pods()), config.MaxBlastRadiusPercent); err != nil {
+		return nil, err
+	}
+
+	state.Node = node.Name
+	state.OriginalReason = ready.Reason
+	state.OriginalMessage = ready.Message
+	state.VerifyRestore = config.VerifyRestore
+	return nil, nil
+}
+
+func (f SimulateNodeNotReadyAction) Start(ctx context.Context, state *SimulateNodeNotReadyState) (*action_kit_api.StartResult, error) {
+	return startSimulateNodeNotReadyInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func startSimulateNodeNotReadyInternal(ctx context.Context, k8s *client.Client, state *SimulateNodeNotReadyState) (*action_kit_api.StartResult, error) {
+	if err := patchReadyCondition(ctx, k8s, state.Node, corev1.ConditionFalse, simulatedNotReadyReason, simulatedNotReadyMessage); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to patch the ready condition of node %s.", state.Node), err)
+	}
+	log.Info().Msgf("Reported node %s as not ready", state.Node)
+	return nil, nil
+}
+
+func (f SimulateNodeNotReadyAction) Status(ctx context.Context, state *SimulateNodeNotReadyState) (*action_kit_api.StatusResult, error) {
+	return statusSimulateNodeNotReadyInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func statusSimulateNodeNotReadyInternal(ctx context.Context, k8s *client.Client, state *SimulateNodeNotReadyState) (*action_kit_api.StatusResult, error) {
+	node, err := k8s.Clientset().CoreV1().Nodes().Get(ctx, state.Node, metav1.GetOptions{})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get node %s.", state.Node), err)
+	}
+	if ready := readyCondition(node); ready != nil && ready.Status == corev1.ConditionFalse {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+
+	if err := patchReadyCondition(ctx, k8s, state.Node, corev1.ConditionFalse, simulatedNotReadyReason, simulatedNotReadyMessage); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to patch the ready condition of node %s.", state.Node), err)
+	}
+	log.Debug().Msgf("Reported node %s as not ready again after a status update of the kubelet", state.Node)
+	return &action_kit_api.StatusResult{Completed: false}, nil
+}
+
+func (f SimulateNodeNotReadyAction) Stop(ctx context.Context, state *SimulateNodeNotReadyState) (*action_kit_api.StopResult, error) {
+	return stopSimulateNodeNotReadyInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func stopSimulateNodeNotReadyInternal(ctx context.Context, k8s *client.Client, state *SimulateNodeNotReadyState) (*action_kit_api.StopResult, error) {
+	if state.Node == "" {
+		return nil, nil
+	}
+	if err := patchReadyCondition(ctx, k8s, state.Node, corev1.ConditionTrue, state.OriginalReason, state.OriginalMessage); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore the ready condition of node %s.", state.Node), err)
+	}
+	log.Info().Msgf("Restored ready condition of node %s", state.Node)
+
+	if !state.VerifyRestore {
+		return nil, nil
+	}
+	subject := fmt.Sprintf("the ready condition of node %s", state.Node)
+	warning := extcommon.VerifyRestored(ctx, subject, string(corev1.ConditionTrue), func(ctx context.Context) (string, error) {
+		node, err := k8s.Clientset().CoreV1().Nodes().Get(ctx, state.Node, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		if ready := readyCondition(node); ready != nil {
+			return string(ready.Status), nil
+		}
+		return "missing", nil
+	})
+	if warning != nil {
+		return &action_kit_api.StopResult{
+			Messages: extutil.Ptr([]action_kit_api.Message{*warning}),
+		}, nil
+	}
+	return nil, nil
+}
+
+// patchReadyCondition replaces the ready condition of the node through the status subresource. Conditions are merged
+// by type, other conditions are kept.
+func patchReadyCondition(ctx context.Context, k8s *client.Client, nodeName string, status corev1.ConditionStatus, reason string, message string) error {
+	now := metav1.NewTime(time.Now())
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []map[string]interface{}{
+				{
+					"type":               corev1.NodeReady,
+					"status":             status,
+					"reason":             reason,
+					"message":            message,
+					"lastHeartbeatTime":  now,
+					"lastTransitionTime": now,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = k8s.Clientset().CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "status")
+	return err
+}
+
+func readyCondition(node *corev1.Node) *corev1.NodeCondition {
+	for i, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"testing"
+)
+
+func TestSimulateNodeNotReadyPrepareStoresReadyCondition(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 100
+	node := testsupport.ReadyNode("worker-1")
+	node.Status.Conditions[0].Reason = "KubeletReady"
+	node.Status.Conditions[0].Message = "kubelet is posting ready status"
+	k8s := testsupport.NewClientBuilder(t).WithNodes(node, testsupport.ReadyNode("worker-2")).Build()
+	state := NewSimulateNodeNotReadyAction().NewEmptyState()
+
+	// When
+	_, err := prepareSimulateNodeNotReadyInternal(k8s, &state, simulateNodeNotReadyRequest("worker-1"))
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "worker-1", state.Node)
+	require.Equal(t, "KubeletReady", state.OriginalReason)
+	require.Equal(t, "kubelet is posting ready status", state.OriginalMessage)
+}
+
+func TestSimulateNodeNotReadyPrepareRejectsNodeNotReady(t *testing.T) {
+	// Given
+	node := testsupport.ReadyNode("worker-1")
+	node.Status.Conditions[0].Status = corev1.ConditionUnknown
+	k8s := testsupport.NewClientBuilder(t).WithNodes(node).Build()
+	state := NewSimulateNodeNotReadyAction().NewEmptyState()
+
+	// When
+	_, err := prepareSimulateNodeNotReadyInternal(k8s, &state, simulateNodeNotReadyRequest("worker-1"))
+
+	// Then
+	require.EqualError(t, err, "Node worker-1 is not ready.")
+}
+
+func TestSimulateNodeNotReadyPrepareChecksBlastRadiusOfPods(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 60
+	defer func() { extconfig.Config.MaxBlastRadiusPercent = 100 }()
+	k8s := testsupport.NewClientBuilder(t).
+		WithNodes(testsupport.ReadyNode("worker-1"), testsupport.ReadyNode("worker-2")).
+		WithPods(podOnNode("cart", "worker-1"), podOnNode("checkout", "worker-1"), podOnNode("payment", "worker-1"), podOnNode("search", "worker-2")).
+		Build()
+	state := NewSimulateNodeNotReadyAction().NewEmptyState()
+
+	// When
+	_, err := prepareSimulateNodeNotReadyInternal(k8s, &state, simulateNodeNotReadyRequest("worker-1"))
+
+	// Then
+	require.EqualError(t, err, "Attack would affect 3 of 4 pods (75%), which exceeds the max. blast radius of 60%.")
+}
+
+func TestSimulateNodeNotReadyPatchesAndRestoresReadyCondition(t *testing.T) {
+	// Given
+	node := testsupport.ReadyNode("worker-1")
+	node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse})
+	builder := testsupport.NewClientBuilder(t).WithNodes(node)
+	k8s := builder.Build()
+	state := SimulateNodeNotReadyState{Node: "worker-1", OriginalReason: "KubeletReady", VerifyRestore: true}
+
+	// When
+	_, err := startSimulateNodeNotReadyInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	ready, memoryPressure := nodeConditions(t, builder, "worker-1")
+	require.Equal(t, corev1.ConditionFalse, ready.Status)
+	require.Equal(t, simulatedNotReadyReason, ready.Reason)
+	require.Equal(t, corev1.ConditionFalse, memoryPressure.Status)
+
+	// When
+	result, err := stopSimulateNodeNotReadyInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	ready, memoryPressure = nodeConditions(t, builder, "worker-1")
+	require.Equal(t, corev1.ConditionTrue, ready.Status)
+	require.Equal(t, "KubeletReady", ready.Reason)
+	require.NotNil(t, memoryPressure)
+}
+
+func TestSimulateNodeNotReadyStatusReappliesConditionReportedByKubelet(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithNodes(testsupport.ReadyNode("worker-1"))
+	k8s := builder.Build()
+	state := SimulateNodeNotReadyState{Node: "worker-1"}
+
+	// When
+	result, err := statusSimulateNodeNotReadyInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.False(t, result.Completed)
+	ready, _ := nodeConditions(t, builder, "worker-1")
+	require.Equal(t, corev1.ConditionFalse, ready.Status)
+}
+
+func TestSimulateNodeNotReadyRestoreWarnsIfOverwritten(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithNodes(testsupport.ReadyNode("worker-1"))
+	k8s := builder.Build()
+	state := SimulateNodeNotReadyState{Node: "worker-1", OriginalReason: "KubeletReady", VerifyRestore: true}
+	_, err := startSimulateNodeNotReadyInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+	// The node controller reports the node as not ready again right after the restore.
+	overwritten, err := builder.Clientset.CoreV1().Nodes().Get(context.Background(), "worker-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	builder.Clientset.PrependReactor("get", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, overwritten.DeepCopy(), nil
+	})
+
+	// When
+	result, err := stopSimulateNodeNotReadyInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "Restored the ready condition of node worker-1 to \"True\", but it is \"False\" now. It was probably overwritten by another controller.", (*result.Messages)[0].Message)
+	require.Equal(t, action_kit_api.Warn, *(*result.Messages)[0].Level)
+}
+
+func simulateNodeNotReadyRequest(node string) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 60000,
+			"node":     node,
+		},
+	}
+}
+
+func nodeConditions(t *testing.T, builder *testsupport.ClientBuilder, name string) (ready *corev1.NodeCondition, memoryPressure *corev1.NodeCondition) {
+	node, err := builder.Clientset.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	for i, condition := range node.Status.Conditions {
+		switch condition.Type {
+		case corev1.NodeReady:
+			ready = &node.Status.Conditions[i]
+		case corev1.NodeMemoryPressure:
+			memoryPressure = &node.Status.Conditions[i]
+		}
+	}
+	require.NotNil(t, ready)
+	return ready, memoryPressure
+}
+
+func podOnNode(name string, node string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: node},
+	}
+}
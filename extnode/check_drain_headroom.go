@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type NodeDrainHeadroomCheckAction struct {
+}
+
+type NodeDrainHeadroomCheckState struct {
+	Cluster string
+	Node    string
+}
+
+type NodeDrainHeadroomCheckConfig struct {
+	Node string
+}
+
+func NewNodeDrainHeadroomCheckAction() action_kit_sdk.Action[NodeDrainHeadroomCheckState] {
+	return NodeDrainHeadroomCheckAction{}
+}
+
+var _ action_kit_sdk.Action[NodeDrainHeadroomCheckState] = (*NodeDrainHeadroomCheckAction)(nil)
+
+func (f NodeDrainHeadroomCheckAction) NewEmptyState() NodeDrainHeadroomCheckState {
+	return NodeDrainHeadroomCheckState{}
+}
+
+func (f NodeDrainHeadroomCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          drainHeadroomCheckActionId,
+		Label:       "Drain Headroom",
+		Description: "Verify that the remaining nodes have enough free pod slots, cpu and memory to reschedule the pods of a node before it is drained.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(nodeCountCheckIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInstantaneous,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:        "node",
+				Label:       "Node",
+				Description: extutil.Ptr("The name of the node which is going to be drained."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(1),
+				Required:    extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+	}
+}
+
+func (f NodeDrainHeadroomCheckAction) Prepare(_ context.Context, state *NodeDrainHeadroomCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config NodeDrainHeadroomCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Node = config.Node
+	return nil, nil
+}
+
+func (f NodeDrainHeadroomCheckAction) Start(_ context.Context, state *NodeDrainHeadroomCheckState) (*action_kit_api.StartResult, error) {
+	return startNodeDrainHeadroomCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func startNodeDrainHeadroomCheckInternal(k8s *client.Client, state *NodeDrainHeadroomCheckState) *action_kit_api.StartResult {
+	node := k8s.NodeByName(state.Node)
+	if node == nil {
+		return &action_kit_api.StartResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Node %s not found", state.Node),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	requiredSlots := podSlotsForRescheduling(k8s, node.Name)
+	freeSlots := freePodSlotsWithout(k8s, node)
+	required := requiredForRescheduling(k8s, node.Name)
+	spare := spareCapacityWithout(k8s, node)
+
+	// Nodes are limited to a fixed number of pods, so many small pods may not fit even though cpu and memory do.
+	if requiredSlots > freeSlots {
+		return &action_kit_api.StartResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Draining node %s requires %d pod slots, but only %d pod slots are free on the remaining nodes.", node.Name, requiredSlots, freeSlots),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+	if required.cpuMillis > spare.cpuMillis || required.memoryBytes > spare.memoryBytes {
+		return &action_kit_api.StartResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title: fmt.Sprintf("Draining node %s requires %s cpu and %s memory, but only %s cpu and %s memory are spare on the remaining nodes.",
+					node.Name, formatCpu(required.cpuMillis), formatMemory(required.memoryBytes), formatCpu(spare.cpuMillis), formatMemory(spare.memoryBytes)),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{
+			{
+				Message: fmt.Sprintf("Pods of node %s require %d pod slots, %s cpu and %s memory, %d pod slots, %s cpu and %s memory are free on the remaining nodes.",
+					node.Name, requiredSlots, formatCpu(required.cpuMillis), formatMemory(required.memoryBytes), freeSlots, formatCpu(spare.cpuMillis), formatMemory(spare.memoryBytes)),
+				Level: extutil.Ptr(action_kit_api.Info),
+			},
+		}),
+	}
+}
+
+// podSlotsForRescheduling counts the pods on the node that would be rescheduled elsewhere, skipping DaemonSet pods.
+func podSlotsForRescheduling(k8s *client.Client, nodeName string) int64 {
+	var result int64
+	for _, pod := range k8s.PodsByNode(nodeName) {
+		if isActive(pod) && !isDaemonSetPod(pod) {
+			result++
+		}
+	}
+	return result
+}
+
+// freePodSlotsWithout sums up the allocatable pods of all schedulable and ready nodes except the given one, minus the
+// pods already running on them. Nodes running more pods than allocatable don't reduce the slots of other nodes.
+func freePodSlotsWithout(k8s *client.Client, node *corev1.Node) int64 {
+	var result int64
+	for _, n := range k8s.SchedulableReadyNodes() {
+		if n.Name == node.Name {
+			continue
+		}
+		free := n.Status.Allocatable.Pods().Value()
+		for _, pod := range k8s.PodsByNode(n.Name) {
+			if isActive(pod) {
+				free--
+			}
+		}
+		if free > 0 {
+			result += free
+		}
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestNodeDrainHeadroomCheckSucceedsWithSufficientPodSlots(t *testing.T) {
+	// Given
+	k8sclient := createDrainHeadroomTestClient(t, "10")
+	state := NodeDrainHeadroomCheckState{Node: "node1"}
+
+	// When
+	result := startNodeDrainHeadroomCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Nil(t, result.Error)
+	require.Equal(t, "Pods of node node1 require 3 pod slots, 300m cpu and 300Mi memory, 9 pod slots, 1900m cpu and 3996Mi memory are free on the remaining nodes.", (*result.Messages)[0].Message)
+}
+
+func TestNodeDrainHeadroomCheckFailsWithInsufficientPodSlotsDespiteSpareResources(t *testing.T) {
+	// Given
+	k8sclient := createDrainHeadroomTestClient(t, "3")
+	state := NodeDrainHeadroomCheckState{Node: "node1"}
+
+	// When
+	result := startNodeDrainHeadroomCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Equal(t, "Draining node node1 requires 3 pod slots, but only 2 pod slots are free on the remaining nodes.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestNodeDrainHeadroomCheckErrorsForUnknownNode(t *testing.T) {
+	// Given
+	k8sclient := createDrainHeadroomTestClient(t, "10")
+	state := NodeDrainHeadroomCheckState{Node: "unknown"}
+
+	// When
+	result := startNodeDrainHeadroomCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func createDrainHeadroomTestClient(t *testing.T, node2Pods string) *client.Client {
+	var pods []*corev1.Pod
+	for i := 0; i < 3; i++ {
+		pods = append(pods, podWithRequests(fmt.Sprintf("app-%d", i), "node1", "100m", "100Mi", nil))
+	}
+	pods = append(pods,
+		podWithRequests("agent", "node1", "100m", "100Mi", []metav1.OwnerReference{
+			{Kind: "DaemonSet", Name: "agent", Controller: extutil.Ptr(true)},
+		}),
+		podWithRequests("existing", "node2", "100m", "100Mi", nil),
+	)
+	return testsupport.NewClientBuilder(t).
+		WithNodes(
+			nodeWithAllocatablePods("node1", "10"),
+			nodeWithAllocatablePods("node2", node2Pods),
+		).
+		WithPods(pods...).
+		Build()
+}
+
+func nodeWithAllocatablePods(name string, pods string) *corev1.Node {
+	node := nodeWithAllocatable(name, "2", "4Gi")
+	node.Status.Allocatable[corev1.ResourcePods] = resource.MustParse(pods)
+	return node
+}
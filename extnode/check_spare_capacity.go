@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SpareCapacityCheckAction struct {
+}
+
+type SpareCapacityCheckState struct {
+	Cluster string
+	Node    string
+}
+
+type SpareCapacityCheckConfig struct {
+	Node string
+}
+
+type resources struct {
+	cpuMillis   int64
+	memoryBytes int64
+}
+
+func NewSpareCapacityCheckAction() action_kit_sdk.Action[SpareCapacityCheckState] {
+	return SpareCapacityCheckAction{}
+}
+
+var _ action_kit_sdk.Action[SpareCapacityCheckState] = (*SpareCapacityCheckAction)(nil)
+
+func (f SpareCapacityCheckAction) NewEmptyState() SpareCapacityCheckState {
+	return SpareCapacityCheckState{}
+}
+
+func (f SpareCapacityCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          spareCapacityCheckActionId,
+		Label:       "Spare Capacity",
+		Description: "Verify that the remaining nodes have enough spare cpu and memory to reschedule the pods of a node before it is drained.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(nodeCountCheckIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInstantaneous,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:        "node",
+				Label:       "Node",
+				Description: extutil.Ptr("The name of the node which is going to be drained."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(1),
+				Required:    extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+	}
+}
+
+func (f SpareCapacityCheckAction) Prepare(_ context.Context, state *SpareCapacityCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config SpareCapacityCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Node = config.Node
+	return nil, nil
+}
+
+func (f SpareCapacityCheckAction) Start(_ context.Context, state *SpareCapacityCheckState) (*action_kit_api.StartResult, error) {
+	return startSpareCapacityCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func startSpareCapacityCheckInternal(k8s *client.Client, state *SpareCapacityCheckState) *action_kit_api.StartResult {
+	var node *corev1.Node
+	for _, n := range k8s.Nodes() {
+		if n.Name == state.Node {
+			node = n
+		}
+	}
+	if node == nil {
+		return &action_kit_api.StartResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Node %s not found", state.Node),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	required := requiredForRescheduling(k8s, node.Name)
+	spare := spareCapacityWithout(k8s, node)
+
+	if required.cpuMillis > spare.cpuMillis || required.memoryBytes > spare.memoryBytes {
+		return &action_kit_api.StartResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title: fmt.Sprintf("Draining node %s requires %s cpu and %s memory, but only %s cpu and %s memory are spare on the remaining nodes.",
+					node.Name, formatCpu(required.cpuMillis), formatMemory(required.memoryBytes), formatCpu(spare.cpuMillis), formatMemory(spare.memoryBytes)),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{
+			{
+				Message: fmt.Sprintf("Pods of node %s require %s cpu and %s memory, %s cpu and %s memory are spare on the remaining nodes.",
+					node.Name, formatCpu(required.cpuMillis), formatMemory(required.memoryBytes), formatCpu(spare.cpuMillis), formatMemory(spare.memoryBytes)),
+				Level: extutil.Ptr(action_kit_api.Info),
+			},
+		}),
+	}
+}
+
+// requiredForRescheduling sums up the requests of all pods on the node that would be rescheduled elsewhere.
+// DaemonSet pods are skipped, as they are not rescheduled to other nodes.
+func requiredForRescheduling(k8s *client.Client, nodeName string) resources {
+	var result resources
+	for _, pod := range k8s.PodsByNode(nodeName) {
+		if !isActive(pod) || isDaemonSetPod(pod) {
+			continue
+		}
+		requests := podRequests(pod)
+		result.cpuMillis += requests.cpuMillis
+		result.memoryBytes += requests.memoryBytes
+	}
+	return result
+}
+
+// spareCapacityWithout returns the allocatable resources of all schedulable and ready nodes except the given one,
+// minus the requests of the pods already running on them.
+func spareCapacityWithout(k8s *client.Client, node *corev1.Node) resources {
+	var result resources
+	result.cpuMillis, result.memoryBytes = k8s.ClusterCapacity()
+	for _, n := range k8s.SchedulableReadyNodes() {
+		if n.Name == node.Name {
+			result.cpuMillis -= n.Status.Allocatable.Cpu().MilliValue()
+			result.memoryBytes -= n.Status.Allocatable.Memory().Value()
+			continue
+		}
+		for _, pod := range k8s.PodsByNode(n.Name) {
+			if !isActive(pod) {
+				continue
+			}
+			requests := podRequests(pod)
+			result.cpuMillis -= requests.cpuMillis
+			result.memoryBytes -= requests.memoryBytes
+		}
+	}
+	return result
+}
+
+func podRequests(pod *corev1.Pod) resources {
+	var result resources
+	for _, container := range pod.Spec.Containers {
+		result.cpuMillis += container.Resources.Requests.Cpu().MilliValue()
+		result.memoryBytes += container.Resources.Requests.Memory().Value()
+	}
+	return result
+}
+
+func isActive(pod *corev1.Pod) bool {
+	return pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && owner.Kind == "DaemonSet"
+}
+
+func formatCpu(cpuMillis int64) string {
+	return resource.NewMilliQuantity(cpuMillis, resource.DecimalSI).String()
+}
+
+func formatMemory(memoryBytes int64) string {
+	return resource.NewQuantity(memoryBytes, resource.BinarySI).String()
+}
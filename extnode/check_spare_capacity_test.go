@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestSpareCapacityCheckSucceedsWithSufficientCapacity(t *testing.T) {
+	// Given
+	k8sclient := createSpareCapacityTestClient(t, "500m")
+	state := SpareCapacityCheckState{Node: "node1"}
+
+	// When
+	result := startSpareCapacityCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Nil(t, result.Error)
+	require.Equal(t, "Pods of node node1 require 500m cpu and 1Gi memory, 1 cpu and 2Gi memory are spare on the remaining nodes.", (*result.Messages)[0].Message)
+}
+
+func TestSpareCapacityCheckFailsWithInsufficientCapacity(t *testing.T) {
+	// Given
+	k8sclient := createSpareCapacityTestClient(t, "1500m")
+	state := SpareCapacityCheckState{Node: "node1"}
+
+	// When
+	result := startSpareCapacityCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Equal(t, "Draining node node1 requires 1500m cpu and 1Gi memory, but only 1 cpu and 2Gi memory are spare on the remaining nodes.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestSpareCapacityCheckErrorsForUnknownNode(t *testing.T) {
+	// Given
+	k8sclient := createSpareCapacityTestClient(t, "500m")
+	state := SpareCapacityCheckState{Node: "unknown"}
+
+	// When
+	result := startSpareCapacityCheckInternal(k8sclient, &state)
+
+	// Then
+	require.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func createSpareCapacityTestClient(t *testing.T, appCpuRequest string) *client.Client {
+	return testsupport.NewClientBuilder(t).
+		WithNodes(
+			nodeWithAllocatable("node1", "2", "4Gi"),
+			nodeWithAllocatable("node2", "2", "4Gi"),
+		).
+		WithPods(
+			podWithRequests("app", "node1", appCpuRequest, "1Gi", nil),
+			podWithRequests("agent", "node1", "500m", "1Gi", []metav1.OwnerReference{
+				{Kind: "DaemonSet", Name: "agent", Controller: extutil.Ptr(true)},
+			}),
+			podWithRequests("existing", "node2", "1", "2Gi", nil),
+		).
+		Build()
+}
+
+func nodeWithAllocatable(name string, cpu string, memory string) *corev1.Node {
+	node := testsupport.ReadyNode(name)
+	node.Status.Allocatable = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+	return node
+}
+
+func podWithRequests(name string, nodeName string, cpu string, memory string, owners []metav1.OwnerReference) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: owners,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{
+				{
+					Name: name,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse(cpu),
+							corev1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+}
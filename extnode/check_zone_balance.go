@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+type NodeZoneBalanceCheckAction struct {
+}
+
+type NodeZoneBalanceCheckState struct {
+	Timeout      time.Time
+	Cluster      string
+	MinZoneShare int
+}
+
+type NodeZoneBalanceCheckConfig struct {
+	Duration     int
+	MinZoneShare int
+}
+
+func NewNodeZoneBalanceCheckAction() action_kit_sdk.Action[NodeZoneBalanceCheckState] {
+	return NodeZoneBalanceCheckAction{}
+}
+
+var _ action_kit_sdk.Action[NodeZoneBalanceCheckState] = (*NodeZoneBalanceCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[NodeZoneBalanceCheckState] = (*NodeZoneBalanceCheckAction)(nil)
+
+func (f NodeZoneBalanceCheckAction) NewEmptyState() NodeZoneBalanceCheckState {
+	return NodeZoneBalanceCheckState{}
+}
+
+func (f NodeZoneBalanceCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          zoneBalanceCheckActionId,
+		Label:       "Node Zone Balance",
+		Description: "Verify that the ready nodes are balanced across the availability zones of the cluster.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(nodeCountCheckIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the zone balance be checked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("10s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "minZoneShare",
+				Label:        "Min. zone share",
+				Description:  extutil.Ptr("Every zone needs at least this percentage of the average number of ready nodes per zone."),
+				Type:         action_kit_api.Percentage,
+				DefaultValue: extutil.Ptr("50"),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f NodeZoneBalanceCheckAction) Prepare(_ context.Context, state *NodeZoneBalanceCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config NodeZoneBalanceCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Cluster = request.Target.Attributes["k8s.cluster-name"][0]
+	state.MinZoneShare = config.MinZoneShare
+	return nil, nil
+}
+
+func (f NodeZoneBalanceCheckAction) Start(_ context.Context, _ *NodeZoneBalanceCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f NodeZoneBalanceCheckAction) Status(_ context.Context, state *NodeZoneBalanceCheckState) (*action_kit_api.StatusResult, error) {
+	return statusNodeZoneBalanceCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusNodeZoneBalanceCheckInternal(k8s *client.Client, state *NodeZoneBalanceCheckState) *action_kit_api.StatusResult {
+	readyNodesByZone := readyNodesByZone(k8s.Nodes())
+	if len(readyNodesByZone) > 0 {
+		total := 0
+		for _, count := range readyNodesByZone {
+			total += count
+		}
+		average := float64(total) / float64(len(readyNodesByZone))
+		minimum := average * float64(state.MinZoneShare) / 100
+
+		var imbalanced []string
+		for zone, count := range readyNodesByZone {
+			if float64(count) < minimum {
+				imbalanced = append(imbalanced, fmt.Sprintf("%s (%d)", zone, count))
+			}
+		}
+		sort.Strings(imbalanced)
+
+		if len(imbalanced) > 0 {
+			return &action_kit_api.StatusResult{
+				Completed: true,
+				Error: extutil.Ptr(action_kit_api.ActionKitError{
+					Title:  fmt.Sprintf("%s has zones with less than %d%% of the average of %.1f ready nodes: %s", state.Cluster, state.MinZoneShare, average, strings.Join(imbalanced, ", ")),
+					Status: extutil.Ptr(action_kit_api.Failed),
+				}),
+			}
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.Timeout),
+	}
+}
+
+// readyNodesByZone counts the ready nodes per zone. Zones without any ready node are included, nodes without a zone are ignored.
+func readyNodesByZone(nodes []*corev1.Node) map[string]int {
+	result := make(map[string]int)
+	for _, node := range nodes {
+		zone := node.Labels[corev1.LabelTopologyZone]
+		if zone == "" {
+			continue
+		}
+		count := result[zone]
+		if client.IsNodeReady(node) {
+			count++
+		}
+		result[zone] = count
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+	"time"
+)
+
+func TestNodeZoneBalanceCheckSucceedsForBalancedZones(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithNodes(
+			nodeInZone("node-1", "eu-central-1a", true),
+			nodeInZone("node-2", "eu-central-1a", true),
+			nodeInZone("node-3", "eu-central-1b", true),
+			nodeInZone("node-4", "eu-central-1b", true),
+			nodeInZone("node-5", "eu-central-1c", true),
+		).
+		Build()
+	state := NodeZoneBalanceCheckState{
+		Timeout:      time.Now().Add(time.Minute),
+		Cluster:      "development",
+		MinZoneShare: 50,
+	}
+
+	// When
+	result := statusNodeZoneBalanceCheckInternal(k8s, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestNodeZoneBalanceCheckFailsForImbalancedZones(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithNodes(
+			nodeInZone("node-1", "eu-central-1a", true),
+			nodeInZone("node-2", "eu-central-1a", true),
+			nodeInZone("node-3", "eu-central-1a", true),
+			nodeInZone("node-4", "eu-central-1b", true),
+			nodeInZone("node-5", "eu-central-1b", true),
+			nodeInZone("node-6", "eu-central-1b", true),
+			nodeInZone("node-7", "eu-central-1c", false),
+			nodeInZone("node-8", "eu-central-1c", true),
+		).
+		Build()
+	state := NodeZoneBalanceCheckState{
+		Timeout:      time.Now().Add(time.Minute),
+		Cluster:      "development",
+		MinZoneShare: 50,
+	}
+
+	// When
+	result := statusNodeZoneBalanceCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "development has zones with less than 50% of the average of 2.3 ready nodes: eu-central-1c (1)", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func nodeInZone(name string, zone string, ready bool) *corev1.Node {
+	node := testsupport.ReadyNode(name)
+	node.Labels = map[string]string{corev1.LabelTopologyZone: zone}
+	if !ready {
+		node.Status.Conditions[0].Status = corev1.ConditionFalse
+	}
+	return node
+}
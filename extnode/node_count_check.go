@@ -14,6 +14,8 @@ import (
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"k8s.io/apimachinery/pkg/labels"
 	"time"
 )
 
@@ -21,6 +23,7 @@ const (
 	nodeCountAtLeast     = "nodeCountAtLeast"
 	nodeCountDecreasedBy = "nodeCountDecreasedBy"
 	nodeCountIncreasedBy = "nodeCountIncreasedBy"
+	nodeReadyPercentage  = "nodeReadyPercentage"
 )
 
 type NodeCountCheckAction struct {
@@ -32,12 +35,14 @@ type NodeCountCheckState struct {
 	Cluster            string
 	NodeCount          int
 	InitialNodeCount   int
+	NodeSelector       string
 }
 
 type NodeCountCheckConfig struct {
 	Duration           int
 	NodeCountCheckMode string
 	NodeCount          int
+	NodeSelector       string
 }
 
 func NewNodeCountCheckAction() action_kit_sdk.Action[NodeCountCheckState] {
@@ -85,7 +90,7 @@ func (f NodeCountCheckAction) Describe() action_kit_api.ActionDescription {
 			{
 				Name:         "nodeCount",
 				Label:        "Node count",
-				Description:  extutil.Ptr("How many nodes are required or should change to let the check pass."),
+				Description:  extutil.Ptr("How many nodes are required or should change to let the check pass. For the percentage check type, the percentage of nodes which need to be ready."),
 				Type:         action_kit_api.Integer,
 				DefaultValue: extutil.Ptr("1"),
 				Order:        extutil.Ptr(2),
@@ -112,8 +117,21 @@ func (f NodeCountCheckAction) Describe() action_kit_api.ActionDescription {
 						Label: "actual count decreases by node count",
 						Value: nodeCountDecreasedBy,
 					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "ready percentage >= node count",
+						Value: nodeReadyPercentage,
+					},
 				}),
 			},
+			{
+				Name:        "nodeSelector",
+				Label:       "Node selector",
+				Description: extutil.Ptr("Only count nodes matching the label selector, e.g. `node.kubernetes.io/instance-type=m5.large`. All nodes are counted if empty."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(3),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
 		},
 		Prepare: action_kit_api.MutatingEndpointReference{},
 		Start:   action_kit_api.MutatingEndpointReference{},
@@ -124,7 +142,10 @@ func (f NodeCountCheckAction) Describe() action_kit_api.ActionDescription {
 }
 
 func (f NodeCountCheckAction) Prepare(_ context.Context, state *NodeCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
-	return prepareNodeCountCheckInternal(client.K8S, state, request)
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareNodeCountCheckInternal(client.ForCluster(state.Cluster), state, request)
 }
 
 func prepareNodeCountCheckInternal(k8s *client.Client, state *NodeCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
@@ -136,21 +157,42 @@ func prepareNodeCountCheckInternal(k8s *client.Client, state *NodeCountCheckStat
 	state.Cluster = request.Target.Attributes["k8s.cluster-name"][0]
 	state.NodeCountCheckMode = config.NodeCountCheckMode
 	state.NodeCount = config.NodeCount
-	state.InitialNodeCount = k8s.NodesReadyCount()
+	state.NodeSelector = config.NodeSelector
+	selector, err := labels.Parse(config.NodeSelector)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid node selector %q.", config.NodeSelector), err)
+	}
+	state.InitialNodeCount, _ = readyNodeCount(k8s, selector)
 	return nil, nil
 }
 
+// readyNodeCount returns the number of ready nodes and the number of all nodes matching the selector.
+func readyNodeCount(k8s *client.Client, selector labels.Selector) (ready int, total int) {
+	for _, node := range k8s.Nodes() {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		total++
+		if client.IsNodeReady(node) {
+			ready++
+		}
+	}
+	return ready, total
+}
+
 func (f NodeCountCheckAction) Start(_ context.Context, _ *NodeCountCheckState) (*action_kit_api.StartResult, error) {
 	return nil, nil
 }
 
 func (f NodeCountCheckAction) Status(_ context.Context, state *NodeCountCheckState) (*action_kit_api.StatusResult, error) {
-	return statusNodeCountCheckInternal(client.K8S, state), nil
+	return statusNodeCountCheckInternal(client.ForCluster(state.Cluster), state), nil
 }
 
 func statusNodeCountCheckInternal(k8s *client.Client, state *NodeCountCheckState) *action_kit_api.StatusResult {
 	now := time.Now()
-	readyCount := k8s.NodesReadyCount()
+	// The selector was validated during prepare.
+	selector, _ := labels.Parse(state.NodeSelector)
+	readyCount, totalCount := readyNodeCount(k8s, selector)
 
 	var checkError *action_kit_api.ActionKitError
 	if state.NodeCountCheckMode == nodeCountAtLeast && readyCount < state.NodeCount {
@@ -168,6 +210,11 @@ func statusNodeCountCheckInternal(k8s *client.Client, state *NodeCountCheckState
 			Title:  fmt.Sprintf("%s has %d of desired %d nodes ready.", state.Cluster, readyCount, state.InitialNodeCount-state.NodeCount),
 			Status: extutil.Ptr(action_kit_api.Failed),
 		})
+	} else if state.NodeCountCheckMode == nodeReadyPercentage && (totalCount == 0 || readyCount*100 < state.NodeCount*totalCount) {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has only %d of %d nodes ready, less than %d%%.", state.Cluster, readyCount, totalCount, state.NodeCount),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
 	}
 
 	if now.After(state.Timeout) {
@@ -8,6 +8,7 @@ import (
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,7 +58,7 @@ func TestPrepareCheckExtractsState(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 	action := NewNodeCountCheckAction()
 	state := action.NewEmptyState()
 
@@ -131,7 +132,7 @@ func TestStatusCheckNodeCountAtLeastSuccess(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusNodeCountCheckInternal(k8sclient, &state)
@@ -177,7 +178,7 @@ func TestStatusCheckNodeCountAtFail(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusNodeCountCheckInternal(k8sclient, &state)
@@ -223,7 +224,7 @@ func TestStatusCheckNodeCountDecreasedBySuccess(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusNodeCountCheckInternal(k8sclient, &state)
@@ -291,7 +292,7 @@ func TestStatusCheckNodeCountDecreasedByFail(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusNodeCountCheckInternal(k8sclient, &state)
@@ -359,7 +360,7 @@ func TestStatusCheckNodeCountIncreasedBySuccess(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusNodeCountCheckInternal(k8sclient, &state)
@@ -405,7 +406,7 @@ func TestStatusCheckNodeCountIncreasedByFail(t *testing.T) {
 
 	stopCh := make(chan struct{})
 	defer close(stopCh)
-	k8sclient := client.CreateClient(clientset, stopCh, "")
+	k8sclient := client.CreateClient(clientset, stopCh, "", 0, nil)
 
 	// When
 	result := statusNodeCountCheckInternal(k8sclient, &state)
@@ -414,3 +415,99 @@ func TestStatusCheckNodeCountIncreasedByFail(t *testing.T) {
 	require.True(t, result.Completed)
 	require.Equal(t, "test has only 1 of desired 2 nodes ready.", result.Error.Title)
 }
+
+func TestPrepareCheckRejectsInvalidNodeSelector(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":           1000 * 10,
+			"nodeCountCheckMode": "nodeCountAtLeast",
+			"nodeCount":          2,
+			"nodeSelector":       "pool in (",
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+			},
+		}),
+	}
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	state := NewNodeCountCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareNodeCountCheckInternal(k8sclient, &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Invalid node selector")
+}
+
+func TestStatusCheckNodeCountAtLeastOnlyCountsSelectedNodes(t *testing.T) {
+	// Given
+	state := NodeCountCheckState{
+		Timeout:            time.Now().Add(time.Minute * -1),
+		NodeCountCheckMode: "nodeCountAtLeast",
+		Cluster:            "test",
+		NodeCount:          2,
+		NodeSelector:       "pool=spot",
+	}
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithNodes(poolNode("node1", "spot", true), poolNode("node2", "default", true)).
+		Build()
+
+	// When
+	result := statusNodeCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "test has not enough ready nodes.", result.Error.Title)
+}
+
+func TestStatusCheckNodeReadyPercentageSuccess(t *testing.T) {
+	// Given
+	state := NodeCountCheckState{
+		Timeout:            time.Now().Add(time.Minute * 1),
+		NodeCountCheckMode: "nodeReadyPercentage",
+		Cluster:            "test",
+		NodeCount:          50,
+	}
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithNodes(poolNode("node1", "spot", true), poolNode("node2", "spot", false)).
+		Build()
+
+	// When
+	result := statusNodeCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestStatusCheckNodeReadyPercentageFail(t *testing.T) {
+	// Given
+	state := NodeCountCheckState{
+		Timeout:            time.Now().Add(time.Minute * -1),
+		NodeCountCheckMode: "nodeReadyPercentage",
+		Cluster:            "test",
+		NodeCount:          75,
+		NodeSelector:       "pool=spot",
+	}
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithNodes(poolNode("node1", "spot", true), poolNode("node2", "spot", false), poolNode("node3", "default", true)).
+		Build()
+
+	// When
+	result := statusNodeCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "test has only 1 of 2 nodes ready, less than 75%.", result.Error.Title)
+}
+
+func poolNode(name string, pool string, ready bool) *corev1.Node {
+	node := testsupport.ReadyNode(name)
+	node.Labels = map[string]string{"pool": pool}
+	if !ready {
+		node.Status.Conditions[0].Status = corev1.ConditionFalse
+	}
+	return node
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	"net/http"
+)
+
+// instanceTypeLabels are set by the cloud providers, the beta label is still found on older nodes.
+var instanceTypeLabels = []string{corev1.LabelInstanceTypeStable, corev1.LabelInstanceType}
+
+func RegisterNodeDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/node/discovery", exthttp.GetterAsHandler(getNodeDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/node/discovery/target-description", exthttp.GetterAsHandler(getNodeTargetDescription))
+	exthttp.RegisterHttpHandler("/node/discovery/discovered-targets", getDiscoveredNodes)
+}
+
+func getNodeDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         NodeTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/node/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getNodeTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       NodeTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes Node", Other: "Kubernetes Nodes"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(nodeCountCheckIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.node.name"},
+				{Attribute: "k8s.node.ready"},
+				{Attribute: "k8s.node.instance-type"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.node.name",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredNodes(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredNodeTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredNodeTargets(k8s *client.Client) []discovery_kit_api.Target {
+	nodes := k8s.Nodes()
+
+	filteredNodes := make([]*corev1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !extconfig.Config.DisableDiscoveryExcludes && client.IsExcludedFromDiscovery(node.ObjectMeta) {
+			continue
+		}
+		filteredNodes = append(filteredNodes, node)
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredNodes))
+	for i, node := range filteredNodes {
+		targetName := fmt.Sprintf("%s/%s", k8s.ClusterName(), node.Name)
+		ready := readyCondition(node)
+
+		attributes := extcommon.NewAttributes()
+		attributes.Set("k8s.cluster-name", k8s.ClusterName())
+		attributes.Set("k8s.distribution", k8s.Distribution)
+		attributes.Set("k8s.node.name", node.Name)
+		attributes.SetBool("k8s.node.ready", ready != nil && ready.Status == corev1.ConditionTrue)
+		attributes.SetBool("k8s.node.unschedulable", node.Spec.Unschedulable)
+		attributes.SetAll("k8s.node.taints", taints(node))
+		attributes.Add("k8s.node.instance-type", instanceType(node))
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			attributes.Set("k8s.node.allocatable.cpu", cpu.String())
+		}
+		if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			attributes.Set("k8s.node.allocatable.memory", memory.String())
+		}
+
+		for key, value := range node.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes.Set(fmt.Sprintf("k8s.node.label.%v", key), value)
+				attributes.Set(fmt.Sprintf("k8s.label.%v", key), value)
+			}
+		}
+
+		extcommon.AddNodeAttributes(k8s, node.Name, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesNode)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: NodeTargetType,
+			Label:      node.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
+
+// taints are formatted like by `kubectl describe node`, i.e. key=value:effect or key:effect.
+func taints(node *corev1.Node) []string {
+	result := make([]string, 0, len(node.Spec.Taints))
+	for _, taint := range node.Spec.Taints {
+		if taint.Value == "" {
+			result = append(result, fmt.Sprintf("%s:%s", taint.Key, taint.Effect))
+		} else {
+			result = append(result, fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect))
+		}
+	}
+	return result
+}
+
+func instanceType(node *corev1.Node) string {
+	for _, label := range instanceTypeLabels {
+		if value := node.Labels[label]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extnode
+
+import (
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"testing"
+)
+
+func Test_getDiscoveredNodes(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	node := testsupport.ReadyNode("worker-1")
+	node.Labels = map[string]string{
+		corev1.LabelInstanceTypeStable: "m5.large",
+		"secret-label":                 "secret-value",
+	}
+	node.Spec.Unschedulable = true
+	node.Spec.Taints = []corev1.Taint{
+		{Key: "dedicated", Value: "batch", Effect: corev1.TaintEffectNoSchedule},
+		{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule},
+	}
+	node.Status.Allocatable = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1930m"),
+		corev1.ResourceMemory: resource.MustParse("7Gi"),
+	}
+	k8s := testsupport.NewClientBuilder(t).WithNodes(node).Build()
+
+	// When
+	targets := getDiscoveredNodeTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/worker-1", target.Id)
+	assert.Equal(t, "worker-1", target.Label)
+	assert.Equal(t, NodeTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.cluster-name":                                {"development"},
+		"k8s.distribution":                                {"kubernetes"},
+		"k8s.node.name":                                   {"worker-1"},
+		"k8s.node.ready":                                  {"true"},
+		"k8s.node.unschedulable":                          {"true"},
+		"k8s.node.taints":                                 {"dedicated=batch:NoSchedule", "node.kubernetes.io/unschedulable:NoSchedule"},
+		"k8s.node.instance-type":                          {"m5.large"},
+		"k8s.node.allocatable.cpu":                        {"1930m"},
+		"k8s.node.allocatable.memory":                     {"7Gi"},
+		"k8s.node.pod-count":                              {"0"},
+		"k8s.node.label.node.kubernetes.io/instance-type": {"m5.large"},
+		"k8s.label.node.kubernetes.io/instance-type":      {"m5.large"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredNodesShouldReportNotReadyNodes(t *testing.T) {
+	// Given
+	node := testsupport.ReadyNode("worker-1")
+	node.Status.Conditions[0].Status = corev1.ConditionUnknown
+	node.Labels = map[string]string{corev1.LabelInstanceType: "n1-standard-4"}
+	k8s := testsupport.NewClientBuilder(t).WithNodes(node).Build()
+
+	// When
+	targets := getDiscoveredNodeTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	attributes := targets[0].Attributes
+	assert.Equal(t, []string{"false"}, attributes["k8s.node.ready"])
+	assert.Equal(t, []string{"false"}, attributes["k8s.node.unschedulable"])
+	assert.Equal(t, []string{"n1-standard-4"}, attributes["k8s.node.instance-type"])
+	assert.NotContains(t, attributes, "k8s.node.taints")
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpod
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// blockDeletionFinalizer is never handled by any controller, so a pod carrying it stays terminating until it is removed.
+const blockDeletionFinalizer = "steadybit.com/block-deletion"
+
+type BlockDeletionAction struct {
+}
+
+type BlockDeletionState struct {
+	Cluster   string
+	Namespace string
+	Pod       string
+}
+
+type BlockDeletionConfig struct {
+	Namespace             string
+	Pod                   string
+	MaxBlastRadiusPercent *int
+}
+
+func NewBlockDeletionAction() action_kit_sdk.Action[BlockDeletionState] {
+	return BlockDeletionAction{}
+}
+
+var _ action_kit_sdk.Action[BlockDeletionState] = (*BlockDeletionAction)(nil)
+var _ action_kit_sdk.ActionWithStop[BlockDeletionState] = (*BlockDeletionAction)(nil)
+
+func (f BlockDeletionAction) NewEmptyState() BlockDeletionState {
+	return BlockDeletionState{}
+}
+
+func (f BlockDeletionAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          blockDeletionActionId,
+		Label:       "Block Pod Deletion",
+		Description: "Add a finalizer to a pod, so it hangs in Terminating when it gets deleted during the attack. The finalizer is removed at the end of the attack.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(podIcon),
+		Category:    extutil.Ptr("state"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the deletion of the pod be blocked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "namespace",
+				Label:       "Namespace",
+				Description: extutil.Ptr("The namespace of the pod."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(true),
+			},
+			{
+				Name:        "pod",
+				Label:       "Pod",
+				Description: extutil.Ptr("The name of the pod."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(3),
+				Required:    extutil.Ptr(true),
+			},
+			extcommon.MaxBlastRadiusPercentParameter(4),
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f BlockDeletionAction) Prepare(_ context.Context, state *BlockDeletionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareBlockDeletionInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareBlockDeletionInternal(k8s *client.Client, state *BlockDeletionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config BlockDeletionConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	if k8s.PodByNamespaceAndName(config.Namespace, config.Pod) == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Pod %s/%s not found", config.Namespace, config.Pod), nil)
+	}
+	if err := extcommon.CheckBlastRadius("pods", 1, len(k8s.Pods()), config.MaxBlastRadiusPercent); err != nil {
+		return nil, err
+	}
+	state.Namespace = config.Namespace
+	state.Pod = config.Pod
+	return nil, nil
+}
+
+func (f BlockDeletionAction) Start(ctx context.Context, state *BlockDeletionState) (*action_kit_api.StartResult, error) {
+	return startBlockDeletionInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func startBlockDeletionInternal(ctx context.Context, k8s *client.Client, state *BlockDeletionState) (*action_kit_api.StartResult, error) {
+	// Finalizers use the merge strategy, so the patch keeps finalizers added by others.
+	patch := fmt.Sprintf(`{"metadata":{"finalizers":[%q]}}`, blockDeletionFinalizer)
+	_, err := k8s.Clientset().CoreV1().Pods(state.Namespace).Patch(ctx, state.Pod, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to add finalizer to pod %s/%s.", state.Namespace, state.Pod), err)
+	}
+	log.Info().Msgf("Added finalizer %s to pod %s/%s", blockDeletionFinalizer, state.Namespace, state.Pod)
+	return nil, nil
+}
+
+func (f BlockDeletionAction) Stop(ctx context.Context, state *BlockDeletionState) (*action_kit_api.StopResult, error) {
+	return stopBlockDeletionInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func stopBlockDeletionInternal(ctx context.Context, k8s *client.Client, state *BlockDeletionState) (*action_kit_api.StopResult, error) {
+	patch := fmt.Sprintf(`{"metadata":{"$deleteFromPrimitiveList/finalizers":[%q]}}`, blockDeletionFinalizer)
+	_, err := k8s.Clientset().CoreV1().Pods(state.Namespace).Patch(ctx, state.Pod, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	if k8sErrors.IsNotFound(err) {
+		log.Debug().Msgf("Pod %s/%s already gone.", state.Namespace, state.Pod)
+	} else if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to remove finalizer from pod %s/%s.", state.Namespace, state.Pod), err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpod
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestBlockDeletionAddsAndRemovesFinalizer(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithPods(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "checkout-1",
+			Namespace:  "shop",
+			Finalizers: []string{"example.com/other"},
+		},
+	})
+	k8s := builder.Build()
+	state := BlockDeletionState{Namespace: "shop", Pod: "checkout-1"}
+
+	// When
+	_, err := startBlockDeletionInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	pod, err := builder.Clientset.CoreV1().Pods("shop").Get(context.Background(), "checkout-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"example.com/other", blockDeletionFinalizer}, pod.Finalizers)
+
+	// When
+	_, err = stopBlockDeletionInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	pod, err = builder.Clientset.CoreV1().Pods("shop").Get(context.Background(), "checkout-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com/other"}, pod.Finalizers)
+}
+
+func TestBlockDeletionStopIgnoresDeletedPod(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).Build()
+	state := BlockDeletionState{Namespace: "shop", Pod: "checkout-1"}
+
+	// When
+	result, err := stopBlockDeletionInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func TestBlockDeletionPrepareChecksBlastRadius(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 40
+	defer func() { extconfig.Config.MaxBlastRadiusPercent = 100 }()
+	k8s := testsupport.NewClientBuilder(t).WithPods(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "checkout-1", Namespace: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "checkout-2", Namespace: "shop"}},
+	).Build()
+	state := BlockDeletionState{}
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"namespace": "shop",
+			"pod":       "checkout-1",
+		},
+	}
+
+	// When
+	_, err := prepareBlockDeletionInternal(k8s, &state, request)
+
+	// Then
+	require.EqualError(t, err, "Attack would affect 1 of 2 pods (50%), which exceeds the max. blast radius of 40%.")
+
+	// When
+	request.Config[extcommon.MaxBlastRadiusPercentParameterName] = 50
+	_, err = prepareBlockDeletionInternal(k8s, &state, request)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "checkout-1", state.Pod)
+}
+
+func TestBlockDeletionPrepareRejectsUnknownPod(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).Build()
+	state := BlockDeletionState{}
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"namespace": "shop",
+			"pod":       "checkout-1",
+		},
+	}
+
+	// When
+	_, err := prepareBlockDeletionInternal(k8s, &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Pod shop/checkout-1 not found")
+}
@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpod
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+type RestartCountCheckAction struct {
+}
+
+type RestartCountCheckState struct {
+	Cluster       string
+	Timeout       time.Time
+	Namespace     string
+	Deployment    string
+	LabelSelector string
+	MaxRestarts   int32
+	// BaselineRestarts holds the restart count per pod uid when the check was prepared.
+	BaselineRestarts map[string]int32
+}
+
+type RestartCountCheckConfig struct {
+	Duration      int
+	Namespace     string
+	Deployment    string
+	LabelSelector string
+	MaxRestarts   int32
+}
+
+func NewRestartCountCheckAction() action_kit_sdk.Action[RestartCountCheckState] {
+	return RestartCountCheckAction{}
+}
+
+var _ action_kit_sdk.Action[RestartCountCheckState] = (*RestartCountCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[RestartCountCheckState] = (*RestartCountCheckAction)(nil)
+
+func (f RestartCountCheckAction) NewEmptyState() RestartCountCheckState {
+	return RestartCountCheckState{}
+}
+
+func (f RestartCountCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          restartCountCheckActionId,
+		Label:       "Pod Restarts",
+		Description: "Verify that the containers of the pods of a deployment or label selector don't restart more often than allowed during the check, e.g. because they are crash-looping.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(podIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the restarts be counted."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "namespace",
+				Label:       "Namespace",
+				Description: extutil.Ptr("The namespace of the pods."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(true),
+			},
+			{
+				Name:        "deployment",
+				Label:       "Deployment",
+				Description: extutil.Ptr("The deployment whose pods are checked. Either a deployment or a label selector is required."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(3),
+				Required:    extutil.Ptr(false),
+			},
+			{
+				Name:        "labelSelector",
+				Label:       "Label selector",
+				Description: extutil.Ptr("The label selector of the checked pods, e.g. `app=checkout`. Either a deployment or a label selector is required."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(4),
+				Required:    extutil.Ptr(false),
+			},
+			{
+				Name:         "maxRestarts",
+				Label:        "Max. restarts",
+				Description:  extutil.Ptr("How many container restarts are tolerated across all pods during the check."),
+				Type:         action_kit_api.Integer,
+				DefaultValue: extutil.Ptr("0"),
+				Order:        extutil.Ptr(5),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f RestartCountCheckAction) Prepare(_ context.Context, state *RestartCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareRestartCountCheckInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareRestartCountCheckInternal(k8s *client.Client, state *RestartCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config RestartCountCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	if (config.Deployment == "") == (config.LabelSelector == "") {
+		return nil, extension_kit.ToError("Either a deployment or a label selector is required.", nil)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = config.Namespace
+	state.Deployment = config.Deployment
+	state.LabelSelector = config.LabelSelector
+	state.MaxRestarts = config.MaxRestarts
+
+	if err := recordBaselineRestarts(k8s, state); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// NewDeploymentRestartCountCheckState prepares the check for the pods of a deployment, e.g. as a sub-check of the
+// deployment health check.
+func NewDeploymentRestartCountCheckState(k8s *client.Client, namespace string, deployment string, maxRestarts int32, timeout time.Time) (RestartCountCheckState, error) {
+	state := RestartCountCheckState{
+		Timeout:     timeout,
+		Namespace:   namespace,
+		Deployment:  deployment,
+		MaxRestarts: maxRestarts,
+	}
+	err := recordBaselineRestarts(k8s, &state)
+	return state, err
+}
+
+func recordBaselineRestarts(k8s *client.Client, state *RestartCountCheckState) error {
+	pods, err := selectedPods(k8s, state)
+	if err != nil {
+		return extension_kit.ToError(err.Error(), nil)
+	}
+	state.BaselineRestarts = make(map[string]int32, len(pods))
+	for _, pod := range pods {
+		state.BaselineRestarts[string(pod.UID)] = restartCount(pod)
+	}
+	return nil
+}
+
+func (f RestartCountCheckAction) Start(_ context.Context, _ *RestartCountCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f RestartCountCheckAction) Status(_ context.Context, state *RestartCountCheckState) (*action_kit_api.StatusResult, error) {
+	return statusRestartCountCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+// RestartCountCheckStatus evaluates the check, e.g. as a sub-check of the deployment health check.
+func RestartCountCheckStatus(k8s *client.Client, state *RestartCountCheckState) *action_kit_api.StatusResult {
+	return statusRestartCountCheckInternal(k8s, state)
+}
+
+func statusRestartCountCheckInternal(k8s *client.Client, state *RestartCountCheckState) *action_kit_api.StatusResult {
+	pods, err := selectedPods(k8s, state)
+	if err != nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  err.Error(),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	total := int32(0)
+	var restarted []string
+	for _, pod := range pods {
+		// Pods created after the baseline count all of their restarts.
+		restarts := restartCount(pod) - state.BaselineRestarts[string(pod.UID)]
+		if restarts > 0 {
+			total += restarts
+			restarted = append(restarted, fmt.Sprintf("%s (%d)", pod.Name, restarts))
+		}
+	}
+	sort.Strings(restarted)
+
+	if total > state.MaxRestarts {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s restarted %d times, more than the allowed %d: %s", selectionName(state), total, state.MaxRestarts, strings.Join(restarted, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.Timeout),
+	}
+}
+
+func selectedPods(k8s *client.Client, state *RestartCountCheckState) ([]*corev1.Pod, error) {
+	if state.Deployment != "" {
+		deployment := k8s.DeploymentByNamespaceAndName(state.Namespace, state.Deployment)
+		if deployment == nil {
+			return nil, fmt.Errorf("Deployment %s not found", state.Deployment)
+		}
+		return k8s.PodsByDeployment(deployment), nil
+	}
+	selector, err := metav1.ParseToLabelSelector(state.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid label selector %q: %s", state.LabelSelector, err.Error())
+	}
+	return k8s.PodsBySelector(state.Namespace, selector), nil
+}
+
+func selectionName(state *RestartCountCheckState) string {
+	if state.Deployment != "" {
+		return fmt.Sprintf("Pods of %s", state.Deployment)
+	}
+	return fmt.Sprintf("Pods matching %s", state.LabelSelector)
+}
+
+func restartCount(pod *corev1.Pod) int32 {
+	count := int32(0)
+	for _, status := range pod.Status.ContainerStatuses {
+		count += status.RestartCount
+	}
+	return count
+}
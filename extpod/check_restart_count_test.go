@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpod
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+	"time"
+)
+
+func TestRestartCountCheckCapturesBaselineOfDeploymentPods(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithDeployments(checkoutDeployment()).
+		WithPods(restartedPod("checkout-1", 2), restartedPod("checkout-2", 0)).
+		Build()
+	state := NewRestartCountCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareRestartCountCheckInternal(k8sclient, &state, restartCountRequest(map[string]interface{}{"deployment": "checkout"}))
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, map[string]int32{"checkout-1-uid": 2, "checkout-2-uid": 0}, state.BaselineRestarts)
+}
+
+func TestRestartCountCheckRequiresDeploymentOrLabelSelector(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	state := NewRestartCountCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareRestartCountCheckInternal(k8sclient, &state, restartCountRequest(map[string]interface{}{}))
+
+	// Then
+	require.ErrorContains(t, err, "Either a deployment or a label selector is required.")
+}
+
+func TestRestartCountCheckPassesWithoutNewRestarts(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithPods(restartedPod("checkout-1", 2)).
+		Build()
+	state := restartCountTestState(map[string]int32{"checkout-1-uid": 2})
+
+	// When
+	result := statusRestartCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestRestartCountCheckFailsWhenRestartsExceedThreshold(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithPods(restartedPod("checkout-1", 4), restartedPod("checkout-2", 1)).
+		Build()
+	state := restartCountTestState(map[string]int32{"checkout-1-uid": 2, "checkout-2-uid": 1})
+
+	// When
+	result := statusRestartCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Pods matching app=checkout restarted 2 times, more than the allowed 1: checkout-1 (2)", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestRestartCountCheckCountsAllRestartsOfNewPods(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).
+		WithPods(restartedPod("checkout-1", 2), restartedPod("checkout-3", 3)).
+		Build()
+	state := restartCountTestState(map[string]int32{"checkout-1-uid": 2})
+
+	// When
+	result := statusRestartCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Pods matching app=checkout restarted 3 times, more than the allowed 1: checkout-3 (3)", result.Error.Title)
+}
+
+func TestRestartCountCheckDeploymentNotFound(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	state := restartCountTestState(map[string]int32{})
+	state.Deployment = "checkout"
+
+	// When
+	result := statusRestartCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Equal(t, "Deployment checkout not found", result.Error.Title)
+	require.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func restartCountRequest(config map[string]interface{}) action_kit_api.PrepareActionRequestBody {
+	config["duration"] = 60000
+	config["namespace"] = "shop"
+	return action_kit_api.PrepareActionRequestBody{Config: config}
+}
+
+func restartCountTestState(baseline map[string]int32) RestartCountCheckState {
+	return RestartCountCheckState{
+		Timeout:          time.Now().Add(time.Minute),
+		Namespace:        "shop",
+		LabelSelector:    "app=checkout",
+		MaxRestarts:      1,
+		BaselineRestarts: baseline,
+	}
+}
+
+func checkoutDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: extutil.Ptr(metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}}),
+		},
+	}
+}
+
+func restartedPod(name string, restarts int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "shop",
+			UID:       types.UID(name + "-uid"),
+			Labels:    map[string]string{"app": "checkout"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+		},
+	}
+}
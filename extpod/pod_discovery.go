@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpod
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	"net/http"
+)
+
+func RegisterPodDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/pod/discovery", exthttp.GetterAsHandler(getPodDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/pod/discovery/target-description", exthttp.GetterAsHandler(getPodTargetDescription))
+	exthttp.RegisterHttpHandler("/pod/discovery/discovered-targets", getDiscoveredPods)
+}
+
+func getPodDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         PodTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/pod/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getPodTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       PodTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes Pod", Other: "Kubernetes Pods"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(podIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.pod.name"},
+				{Attribute: "k8s.pod.phase"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.pod.name",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredPods(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredPodTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredPodTargets(k8s *client.Client) []discovery_kit_api.Target {
+	pods := k8s.PodsByLabelSelector(extconfig.PodSelector())
+
+	filteredPods := make([]*corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if !extconfig.Config.DisableDiscoveryExcludes && client.IsExcludedFromDiscovery(pod.ObjectMeta) {
+			continue
+		}
+		filteredPods = append(filteredPods, pod)
+	}
+
+	endpoints := extcommon.NewEndpointMembership(k8s)
+	targets := make([]discovery_kit_api.Target, len(filteredPods))
+	for i, pod := range filteredPods {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), pod.Namespace, pod.Name)
+		readyContainers, totalContainers := client.ContainersReady(pod)
+
+		attributes := extcommon.NewAttributes()
+		attributes.Set("k8s.cluster-name", k8s.ClusterName())
+		attributes.Set("k8s.distribution", k8s.Distribution)
+		attributes.Set("k8s.namespace", pod.Namespace)
+		attributes.Set("k8s.pod.name", pod.Name)
+		attributes.Add("k8s.pod.generate-name", client.GenerateNameBase(pod))
+		attributes.Add("k8s.pod.phase", string(pod.Status.Phase))
+		attributes.SetBool("k8s.pod.ready", client.IsPodReady(pod))
+		attributes.Set("k8s.pod.containers-ready", fmt.Sprintf("%d/%d", readyContainers, totalContainers))
+		attributes.Set("k8s.pod.qos-class", string(client.QOSClass(pod)))
+		attributes.Add("k8s.node.name", pod.Spec.NodeName)
+		attributes.SetAll("k8s.pod.ip", client.PodIPs(pod.Status))
+		attributes.Add("k8s.pod.service-account", pod.Spec.ServiceAccountName)
+
+		if pod.DeletionTimestamp != nil {
+			attributes.SetBool("k8s.pod.terminating", true)
+		}
+
+		if client.IsStandalonePod(pod) {
+			attributes.SetBool("k8s.pod.standalone", true)
+		}
+
+		ownerRefs := client.OwnerReferences(k8s, &pod.ObjectMeta).OwnerRefs
+		for _, ownerRef := range ownerRefs {
+			attributes.Set(fmt.Sprintf("k8s.%v", ownerRef.Kind), ownerRef.Name)
+		}
+		// The owners are resolved up to the workload, e.g. the deployment of the replicaset of the pod.
+		if len(ownerRefs) > 0 {
+			workload := ownerRefs[len(ownerRefs)-1]
+			attributes.Set("k8s.pod.owner-kind", workload.Kind)
+			attributes.Set("k8s.pod.owner-name", workload.Name)
+		}
+
+		for key, value := range pod.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes.Set(fmt.Sprintf("k8s.pod.label.%v", key), value)
+				attributes.Set(fmt.Sprintf("k8s.label.%v", key), value)
+			}
+		}
+
+		endpoints.AddAttributes(pod, attributes)
+		extcommon.AddNamespaceAttributes(k8s, pod.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesPod)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: PodTargetType,
+			Label:      pod.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpod
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getDiscoveredPods(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	k8s := testsupport.NewClientBuilder(t).
+		WithStatefulSets(&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		}).
+		WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "db-0",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "db", Controller: extutil.Ptr(true)}},
+				Labels: map[string]string{
+					"best-city":    "Kevelaer",
+					"secret-label": "secret-value",
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: "worker-1", ServiceAccountName: "db"},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				PodIPs:     []corev1.PodIP{{IP: "10.0.0.7"}, {IP: "fd00::7"}},
+				QOSClass:   corev1.PodQOSBurstable,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "db", Ready: true},
+					{Name: "exporter", Ready: false},
+				},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredPodTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/db-0", target.Id)
+	assert.Equal(t, "db-0", target.Label)
+	assert.Equal(t, PodTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":            {"default"},
+		"k8s.pod.name":             {"db-0"},
+		"k8s.pod.phase":            {"Running"},
+		"k8s.pod.ready":            {"true"},
+		"k8s.pod.containers-ready": {"1/2"},
+		"k8s.pod.qos-class":        {"Burstable"},
+		"k8s.node.name":            {"worker-1"},
+		"k8s.statefulset":          {"db"},
+		"k8s.pod.owner-kind":       {"statefulset"},
+		"k8s.pod.owner-name":       {"db"},
+		"k8s.pod.ip":               {"10.0.0.7", "fd00::7"},
+		"k8s.pod.in-endpoints":     {"false"},
+		"k8s.pod.service-account":  {"db"},
+		"k8s.cluster-name":         {"development"},
+		"k8s.distribution":         {"kubernetes"},
+		"k8s.pod.label.best-city":  {"Kevelaer"},
+		"k8s.label.best-city":      {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredPodsShouldReportStandalonePendingPods(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredPodTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	attributes := targets[0].Attributes
+	assert.Equal(t, []string{"Pending"}, attributes["k8s.pod.phase"])
+	assert.Equal(t, []string{"false"}, attributes["k8s.pod.ready"])
+	assert.Equal(t, []string{"true"}, attributes["k8s.pod.standalone"])
+	assert.NotContains(t, attributes, "k8s.node.name")
+	assert.NotContains(t, attributes, "k8s.pod.owner-kind")
+}
+
+func Test_getDiscoveredPodsShouldReportReadyEndpoints(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithPods(
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "shop-1", Namespace: "default"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "shop-2", Namespace: "default"}},
+		).
+		WithEndpointSlices(&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "shop"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses:  []string{"10.0.0.1"},
+					TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "shop-1"},
+					Conditions: discoveryv1.EndpointConditions{Ready: extutil.Ptr(true)},
+				},
+				{
+					Addresses:  []string{"10.0.0.2"},
+					TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "shop-2"},
+					Conditions: discoveryv1.EndpointConditions{Ready: extutil.Ptr(false)},
+				},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredPodTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 2)
+	attributesByPod := map[string]map[string][]string{}
+	for _, target := range targets {
+		attributesByPod[target.Label] = target.Attributes
+	}
+	assert.Equal(t, []string{"true"}, attributesByPod["shop-1"]["k8s.pod.in-endpoints"])
+	assert.Equal(t, []string{"shop"}, attributesByPod["shop-1"]["k8s.pod.services-endpoints"])
+	assert.Equal(t, []string{"false"}, attributesByPod["shop-2"]["k8s.pod.in-endpoints"])
+	assert.NotContains(t, attributesByPod["shop-2"], "k8s.pod.services-endpoints")
+}
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpvc
+
+const (
+	PersistentVolumeClaimTargetType = "com.steadybit.extension_kubernetes.kubernetes-persistent-volume-claim"
+	pvcIcon                         = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M12%202C7.58%202%204%203.57%204%205.5V18.5C4%2020.43%207.58%2022%2012%2022C16.42%2022%2020%2020.43%2020%2018.5V5.5C20%203.57%2016.42%202%2012%202ZM6%208.3V12C6%2012.55%208.24%2014%2012%2014C15.76%2014%2018%2012.55%2018%2012V8.3C16.55%208.97%2014.39%209.4%2012%209.4C9.61%209.4%207.45%208.97%206%208.3ZM6%2014.3V18.5C6%2019.050%208.24%2020%2012%2020C15.76%2020%2018%2019.05%2018%2018.5V14.3C16.55%2014.97%2014.39%2015.4%2012%2015.4C9.610%2015.4%207.45%2014.97%206%2014.3ZM12%204C8.24%204%206%204.95%206%205.5C6%206.05%208.24%207.4%2012%207.4C15.76%207.4%2018%206.05%2018%205.5C18%204.95%2015.76%204%2012%204Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+)
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpvc
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	"net/http"
+	"sort"
+)
+
+func RegisterPersistentVolumeClaimDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/pvc/discovery", exthttp.GetterAsHandler(getPersistentVolumeClaimDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/pvc/discovery/target-description", exthttp.GetterAsHandler(getPersistentVolumeClaimTargetDescription))
+	exthttp.RegisterHttpHandler("/pvc/discovery/discovered-targets", getDiscoveredPersistentVolumeClaims)
+}
+
+func getPersistentVolumeClaimDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         PersistentVolumeClaimTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/pvc/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getPersistentVolumeClaimTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       PersistentVolumeClaimTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes PersistentVolumeClaim", Other: "Kubernetes PersistentVolumeClaims"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(pvcIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.pvc"},
+				{Attribute: "k8s.pvc.phase"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.pvc",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredPersistentVolumeClaims(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredPersistentVolumeClaimTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredPersistentVolumeClaimTargets(k8s *client.Client) []discovery_kit_api.Target {
+	pvcs := k8s.AllPersistentVolumeClaims()
+
+	filteredPvcs := make([]*corev1.PersistentVolumeClaim, 0, len(pvcs))
+	if extconfig.Config.DisableDiscoveryExcludes {
+		filteredPvcs = pvcs
+	} else {
+		for _, pvc := range pvcs {
+			if client.IsExcludedFromDiscovery(pvc.ObjectMeta) {
+				continue
+			}
+			filteredPvcs = append(filteredPvcs, pvc)
+		}
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredPvcs))
+	for i, pvc := range filteredPvcs {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), pvc.Namespace, pvc.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":    {pvc.Namespace},
+			"k8s.pvc":          {pvc.Name},
+			"k8s.pvc.phase":    {string(pvc.Status.Phase)},
+			"k8s.cluster-name": {k8s.ClusterName()},
+			"k8s.distribution": {k8s.Distribution},
+		}
+
+		if pvc.Spec.StorageClassName != nil {
+			attributes["k8s.pvc.storage-class"] = []string{*pvc.Spec.StorageClassName}
+		}
+		// The capacity is only reported once the claim is bound.
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			attributes["k8s.pvc.capacity"] = []string{capacity.String()}
+		}
+		if pvc.Spec.VolumeName != "" {
+			attributes["k8s.pvc.volume-name"] = []string{pvc.Spec.VolumeName}
+		}
+
+		for key, values := range mountingWorkloads(k8s, pvc) {
+			attributes[key] = values
+		}
+
+		for key, value := range pvc.ObjectMeta.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes[fmt.Sprintf("k8s.pvc.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		extcommon.AddNamespaceAttributes(k8s, pvc.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesPvc)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: PersistentVolumeClaimTargetType,
+			Label:      pvc.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
+
+// mountingWorkloads returns the sorted names of the pods mounting the claim as k8s.pod.name, and the names of their
+// owners by kind, e.g. k8s.statefulset.
+func mountingWorkloads(k8s *client.Client, pvc *corev1.PersistentVolumeClaim) map[string][]string {
+	namesByAttribute := map[string]map[string]bool{}
+	add := func(attribute string, name string) {
+		if namesByAttribute[attribute] == nil {
+			namesByAttribute[attribute] = map[string]bool{}
+		}
+		namesByAttribute[attribute][name] = true
+	}
+	for _, pod := range k8s.PodsByPersistentVolumeClaim(pvc) {
+		add("k8s.pod.name", pod.Name)
+		for _, ownerRef := range client.OwnerReferences(k8s, &pod.ObjectMeta).OwnerRefs {
+			add(fmt.Sprintf("k8s.%v", ownerRef.Kind), ownerRef.Name)
+		}
+	}
+
+	result := make(map[string][]string, len(namesByAttribute))
+	for attribute, names := range namesByAttribute {
+		values := make([]string, 0, len(names))
+		for name := range names {
+			values = append(values, name)
+		}
+		sort.Strings(values)
+		result[attribute] = values
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extpvc
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getDiscoveredPersistentVolumeClaims(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	k8s := testsupport.NewClientBuilder(t).
+		WithPersistentVolumeClaims(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "data-db-0",
+				Namespace: "default",
+				Labels: map[string]string{
+					"best-city":    "Kevelaer",
+					"secret-label": "secret-value",
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: extutil.Ptr("gp3"),
+				VolumeName:       "pvc-1234",
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase:    corev1.ClaimBound,
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredPersistentVolumeClaimTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/data-db-0", target.Id)
+	assert.Equal(t, "data-db-0", target.Label)
+	assert.Equal(t, PersistentVolumeClaimTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":           {"default"},
+		"k8s.pvc":                 {"data-db-0"},
+		"k8s.pvc.phase":           {"Bound"},
+		"k8s.pvc.storage-class":   {"gp3"},
+		"k8s.pvc.capacity":        {"10Gi"},
+		"k8s.pvc.volume-name":     {"pvc-1234"},
+		"k8s.cluster-name":        {"development"},
+		"k8s.distribution":        {"kubernetes"},
+		"k8s.pvc.label.best-city": {"Kevelaer"},
+		"k8s.label.best-city":     {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredPersistentVolumeClaimsShouldReportMountingPods(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithPersistentVolumeClaims(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-db-0", Namespace: "default"},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+		}).
+		WithStatefulSets(&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		}).
+		WithPods(
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "db-0",
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "db"}},
+				},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"},
+							},
+						},
+					},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"},
+			},
+		).
+		Build()
+
+	// When
+	targets := getDiscoveredPersistentVolumeClaimTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"Pending"}, targets[0].Attributes["k8s.pvc.phase"])
+	assert.Equal(t, []string{"db-0"}, targets[0].Attributes["k8s.pod.name"])
+	assert.Equal(t, []string{"db"}, targets[0].Attributes["k8s.statefulset"])
+	assert.NotContains(t, targets[0].Attributes, "k8s.pvc.capacity")
+}
+
+func Test_getDiscoveredPersistentVolumeClaimsShouldIgnoreExcludedClaims(t *testing.T) {
+	// Given
+	extconfig.Config.DisableDiscoveryExcludes = false
+	k8s := testsupport.NewClientBuilder(t).
+		WithPersistentVolumeClaims(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "data-db-0",
+				Namespace: "default",
+				Labels:    map[string]string{"steadybit.com/discovery-disabled": "true"},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredPersistentVolumeClaimTargets(k8s)
+
+	// Then
+	require.Empty(t, targets)
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extreplicaset
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strconv"
+)
+
+func RegisterReplicaSetDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/replicaset/discovery", exthttp.GetterAsHandler(getReplicaSetDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/replicaset/discovery/target-description", exthttp.GetterAsHandler(getReplicaSetTargetDescription))
+	exthttp.RegisterHttpHandler("/replicaset/discovery/discovered-targets", getDiscoveredReplicaSets)
+}
+
+func getReplicaSetDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         ReplicaSetTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/replicaset/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getReplicaSetTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       ReplicaSetTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes ReplicaSet", Other: "Kubernetes ReplicaSets"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(replicaSetIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.replicaset"},
+				{Attribute: "k8s.replicaset.ready"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.replicaset",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredReplicaSets(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredReplicaSetTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredReplicaSetTargets(k8s *client.Client) []discovery_kit_api.Target {
+	replicaSets := k8s.ReplicaSets()
+
+	filteredReplicaSets := make([]*appsv1.ReplicaSet, 0, len(replicaSets))
+	for _, replicaSet := range replicaSets {
+		// The replicasets of deployments are already covered by the deployment targets.
+		if !extconfig.Config.DiscoverOwnedReplicaSets && owningDeployment(replicaSet) != "" {
+			continue
+		}
+		if !extconfig.Config.DisableDiscoveryExcludes && client.IsExcludedFromDiscovery(replicaSet.ObjectMeta) {
+			continue
+		}
+		filteredReplicaSets = append(filteredReplicaSets, replicaSet)
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredReplicaSets))
+	for i, replicaSet := range filteredReplicaSets {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), replicaSet.Namespace, replicaSet.Name)
+		desired := int32(1)
+		if replicaSet.Spec.Replicas != nil {
+			desired = *replicaSet.Spec.Replicas
+		}
+		attributes := map[string][]string{
+			"k8s.namespace":          {replicaSet.Namespace},
+			"k8s.replicaset":         {replicaSet.Name},
+			"k8s.replicaset.desired": {strconv.Itoa(int(desired))},
+			"k8s.replicaset.ready":   {strconv.Itoa(int(replicaSet.Status.ReadyReplicas))},
+			"k8s.cluster-name":       {k8s.ClusterName()},
+			"k8s.distribution":       {k8s.Distribution},
+		}
+
+		if deployment := owningDeployment(replicaSet); deployment != "" {
+			attributes["k8s.deployment"] = []string{deployment}
+		}
+
+		for key, value := range replicaSet.ObjectMeta.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes[fmt.Sprintf("k8s.replicaset.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		extcommon.AddNamespaceAttributes(k8s, replicaSet.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesReplicaSet)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: ReplicaSetTargetType,
+			Label:      replicaSet.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
+
+// owningDeployment returns the name of the deployment controlling the replicaset, empty for bare replicasets.
+func owningDeployment(replicaSet *appsv1.ReplicaSet) string {
+	if owner := metav1.GetControllerOf(replicaSet); owner != nil && owner.Kind == "Deployment" {
+		return owner.Name
+	}
+	return ""
+}
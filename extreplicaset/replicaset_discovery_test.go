@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extreplicaset
+
+import (
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getDiscoveredReplicaSets(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	k8s := testsupport.NewClientBuilder(t).
+		WithReplicaSets(&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-canary",
+				Namespace: "default",
+				Labels: map[string]string{
+					"best-city":    "Kevelaer",
+					"secret-label": "secret-value",
+				},
+			},
+			Spec:   appsv1.ReplicaSetSpec{Replicas: extutil.Ptr(int32(3))},
+			Status: appsv1.ReplicaSetStatus{ReadyReplicas: 2},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredReplicaSetTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/shop-canary", target.Id)
+	assert.Equal(t, "shop-canary", target.Label)
+	assert.Equal(t, ReplicaSetTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":                  {"default"},
+		"k8s.replicaset":                 {"shop-canary"},
+		"k8s.replicaset.desired":         {"3"},
+		"k8s.replicaset.ready":           {"2"},
+		"k8s.cluster-name":               {"development"},
+		"k8s.distribution":               {"kubernetes"},
+		"k8s.replicaset.label.best-city": {"Kevelaer"},
+		"k8s.label.best-city":            {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredReplicaSetsShouldSkipReplicaSetsOwnedByDeployments(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithReplicaSets(&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "shop-7d4b9c",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{deploymentOwner("shop")},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredReplicaSetTargets(k8s)
+
+	// Then
+	require.Empty(t, targets)
+}
+
+func Test_getDiscoveredReplicaSetsShouldReportOwningDeploymentIfEnabled(t *testing.T) {
+	// Given
+	extconfig.Config.DiscoverOwnedReplicaSets = true
+	defer func() { extconfig.Config.DiscoverOwnedReplicaSets = false }()
+	k8s := testsupport.NewClientBuilder(t).
+		WithReplicaSets(&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "shop-7d4b9c",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{deploymentOwner("shop")},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredReplicaSetTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"shop"}, targets[0].Attributes["k8s.deployment"])
+	assert.Equal(t, []string{"1"}, targets[0].Attributes["k8s.replicaset.desired"])
+}
+
+func Test_getDiscoveredReplicaSetsShouldIgnoreExcludedReplicaSets(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithReplicaSets(&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-canary",
+				Namespace: "default",
+				Labels:    map[string]string{"steadybit.com/discovery-disabled": "true"},
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredReplicaSetTargets(k8s)
+
+	// Then
+	require.Empty(t, targets)
+}
+
+func deploymentOwner(name string) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       name,
+		UID:        "deployment-uid",
+		Controller: extutil.Ptr(true),
+	}
+}
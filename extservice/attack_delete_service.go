@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"time"
+)
+
+// serviceTerminationPollInterval is a variable so tests don't need to wait.
+var serviceTerminationPollInterval = 2 * time.Second
+
+// serviceTerminationTimeout bounds the wait for the deleted service to be gone, which takes until the load balancer is
+// released for services of type LoadBalancer.
+var serviceTerminationTimeout = 2 * time.Minute
+
+type DeleteServiceAction struct {
+}
+
+type DeleteServiceState struct {
+	Cluster   string
+	Namespace string
+	Service   string
+	// Snapshot holds the service as it was right before the deletion, it is recreated from it at the end of the attack.
+	Snapshot   *corev1.Service
+	DeletedUid types.UID
+}
+
+type DeleteServiceConfig struct {
+	MaxBlastRadiusPercent *int
+}
+
+func NewDeleteServiceAction() action_kit_sdk.Action[DeleteServiceState] {
+	return DeleteServiceAction{}
+}
+
+var _ action_kit_sdk.Action[DeleteServiceState] = (*DeleteServiceAction)(nil)
+var _ action_kit_sdk.ActionWithStop[DeleteServiceState] = (*DeleteServiceAction)(nil)
+
+func (f DeleteServiceAction) NewEmptyState() DeleteServiceState {
+	return DeleteServiceState{}
+}
+
+func (f DeleteServiceAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          deleteServiceActionId,
+		Label:       "Delete Service",
+		Description: "Delete the service, so that it can't be resolved and its traffic isn't routed anymore. The service is recreated with the same spec at the end of the attack, with a new cluster IP if the original one was taken in the meantime. A service of type LoadBalancer is recreated once the deleted one released its load balancer.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(serviceIcon),
+		Category:    extutil.Ptr("state"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: ServiceTargetType,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find service by cluster, namespace and service"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.service=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the service be deleted."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			extcommon.MaxBlastRadiusPercentParameter(2),
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f DeleteServiceAction) Prepare(_ context.Context, state *DeleteServiceState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareDeleteServiceInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareDeleteServiceInternal(k8s *client.Client, state *DeleteServiceState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config DeleteServiceConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Service = request.Target.Attributes["k8s.service"][0]
+
+	// The api server recreates its own service immediately.
+	if state.Namespace == metav1.NamespaceDefault && state.Service == "kubernetes" {
+		return nil, extension_kit.ToError("The service of the Kubernetes API can't be deleted.", nil)
+	}
+
+	service := k8s.ServiceByNamespaceAndName(state.Namespace, state.Service)
+	if service == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Service %s not found", state.Service), nil)
+	}
+	// The pods behind the service can't be reached through it anymore. Services without a selector don't select pods.
+	if len(service.Spec.Selector) > 0 {
+		pods := k8s.PodsBySelector(state.Namespace, &metav1.LabelSelector{MatchLabels: service.Spec.Selector})
+		if err := extcommon.CheckBlastRadius("pods", len(pods), len(k8s.Pods()), config.MaxBlastRadiusPercent); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// serviceSnapshot keeps what is needed to recreate the service, server-side fields like the uid and the status are
+// dropped. It is taken from the live service, as the cached one lacks stripped annotations like
+// kubectl.kubernetes.io/last-applied-configuration.
+func serviceSnapshot(service *corev1.Service) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            service.Name,
+			Namespace:       service.Namespace,
+			Labels:          service.Labels,
+			Annotations:     service.Annotations,
+			OwnerReferences: service.OwnerReferences,
+		},
+		Spec: *service.Spec.DeepCopy(),
+	}
+}
+
+func (f DeleteServiceAction) Start(ctx context.Context, state *DeleteServiceState) (*action_kit_api.StartResult, error) {
+	return startDeleteServiceInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func startDeleteServiceInternal(ctx context.Context, k8s *client.Client, state *DeleteServiceState) (*action_kit_api.StartResult, error) {
+	services := k8s.Clientset().CoreV1().Services(state.Namespace)
+	service, err := services.Get(ctx, state.Service, metav1.GetOptions{})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get service %s/%s.", state.Namespace, state.Service), err)
+	}
+
+	log.Info().Msgf("Deleting service %s/%s", state.Namespace, state.Service)
+	err = services.Delete(ctx, state.Service, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &service.UID},
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to delete service %s/%s.", state.Namespace, state.Service), err)
+	}
+	state.DeletedUid = service.UID
+	state.Snapshot = serviceSnapshot(service)
+	return nil, nil
+}
+
+func (f DeleteServiceAction) Stop(ctx context.Context, state *DeleteServiceState) (*action_kit_api.StopResult, error) {
+	return stopDeleteServiceInternal(ctx, client.ForCluster(state.Cluster), state)
+}
+
+func stopDeleteServiceInternal(ctx context.Context, k8s *client.Client, state *DeleteServiceState) (*action_kit_api.StopResult, error) {
+	if state.DeletedUid == "" {
+		return nil, nil
+	}
+
+	services := k8s.Clientset().CoreV1().Services(state.Namespace)
+	existing, err := createService(ctx, services, state.DeletedUid, state.Snapshot)
+	if existing {
+		log.Info().Msgf("Service %s/%s was already recreated, e.g. by a GitOps controller", state.Namespace, state.Service)
+		return nil, nil
+	}
+	if wait.Interrupted(err) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Service %s/%s is still terminating, it couldn't be recreated within %s.", state.Namespace, state.Service, serviceTerminationTimeout), err)
+	}
+
+	var messages []action_kit_api.Message
+	if k8sErrors.IsInvalid(err) && hasClusterIp(state.Snapshot) {
+		message := fmt.Sprintf("The cluster IP %s of service %s/%s couldn't be reclaimed, the service is recreated with a new cluster IP.", state.Snapshot.Spec.ClusterIP, state.Namespace, state.Service)
+		log.Warn().Err(err).Msg(message)
+		messages = append(messages, action_kit_api.Message{
+			Message: message,
+			Level:   extutil.Ptr(action_kit_api.Warn),
+		})
+		service := state.Snapshot.DeepCopy()
+		service.Spec.ClusterIP = ""
+		service.Spec.ClusterIPs = nil
+		_, err = services.Create(ctx, service, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to recreate service %s/%s.", state.Namespace, state.Service), err)
+	}
+
+	log.Info().Msgf("Recreated service %s/%s", state.Namespace, state.Service)
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	return &action_kit_api.StopResult{Messages: extutil.Ptr(messages)}, nil
+}
+
+// createService creates the service once the deleted one is gone. A load balancer service is kept terminating by the
+// service.kubernetes.io/load-balancer-cleanup finalizer until the cloud provider released the load balancer. A
+// service with the same name that was created by someone else in the meantime is kept, existing is true then. The
+// error is interrupted if the deleted service doesn't go away in time.
+func createService(ctx context.Context, services corev1client.ServiceInterface, deletedUid types.UID, service *corev1.Service) (existing bool, err error) {
+	var createErr error
+	err = wait.PollUntilContextTimeout(ctx, serviceTerminationPollInterval, serviceTerminationTimeout, true, func(ctx context.Context) (bool, error) {
+		_, createErr = services.Create(ctx, service, metav1.CreateOptions{})
+		if !k8sErrors.IsAlreadyExists(createErr) {
+			return true, nil
+		}
+		current, err := services.Get(ctx, service.Name, metav1.GetOptions{})
+		if k8sErrors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		if current.UID != deletedUid && current.DeletionTimestamp == nil {
+			existing, createErr = true, nil
+			return true, nil
+		}
+		log.Info().Msgf("Service %s/%s is still terminating, waiting to recreate it", service.Namespace, service.Name)
+		return false, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return existing, createErr
+}
+
+// hasClusterIp is false for headless services, which don't allocate an IP.
+func hasClusterIp(service *corev1.Service) bool {
+	return service.Spec.ClusterIP != "" && service.Spec.ClusterIP != corev1.ClusterIPNone
+}
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8stesting "k8s.io/client-go/testing"
+	"testing"
+	"time"
+)
+
+func TestDeleteServiceDeletesAndRecreatesService(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithServices(checkoutService())
+	k8s := builder.Build()
+	state := NewDeleteServiceAction().NewEmptyState()
+	_, err := prepareDeleteServiceInternal(k8s, &state, deleteServiceRequest("default", "checkout"))
+	require.NoError(t, err)
+
+	// When
+	_, err = startDeleteServiceInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "1", string(state.DeletedUid))
+	_, err = builder.Clientset.CoreV1().Services("default").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.True(t, k8sErrors.IsNotFound(err))
+
+	// When
+	result, err := stopDeleteServiceInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	service, err := builder.Clientset.CoreV1().Services("default").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "10.96.0.42", service.Spec.ClusterIP)
+	require.Equal(t, map[string]string{"app": "checkout"}, service.Spec.Selector)
+	require.Equal(t, "checkout", service.Labels["app"])
+}
+
+func TestDeleteServiceKeepsAnnotationsStrippedFromCache(t *testing.T) {
+	// Given
+	service := checkoutService()
+	service.Annotations = map[string]string{
+		corev1.LastAppliedConfigAnnotation: `{"kind":"Service"}`,
+		"team":                             "checkout",
+	}
+	builder := testsupport.NewClientBuilder(t).WithServices(service)
+	k8s := builder.Build()
+	state := NewDeleteServiceAction().NewEmptyState()
+	_, err := prepareDeleteServiceInternal(k8s, &state, deleteServiceRequest("default", "checkout"))
+	require.NoError(t, err)
+	_, err = startDeleteServiceInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+
+	// When
+	_, err = stopDeleteServiceInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	recreated, err := builder.Clientset.CoreV1().Services("default").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, service.Annotations, recreated.Annotations)
+}
+
+func TestDeleteServiceRecreatesServiceWithNewClusterIpIfTaken(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithServices(checkoutService())
+	k8s := builder.Build()
+	state := NewDeleteServiceAction().NewEmptyState()
+	_, err := prepareDeleteServiceInternal(k8s, &state, deleteServiceRequest("default", "checkout"))
+	require.NoError(t, err)
+	_, err = startDeleteServiceInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+	builder.Clientset.PrependReactor("create", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		service := action.(k8stesting.CreateAction).GetObject().(*corev1.Service)
+		if service.Spec.ClusterIP == "" {
+			return false, nil, nil
+		}
+		return true, nil, k8sErrors.NewInvalid(schema.GroupKind{Kind: "Service"}, service.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "clusterIPs"), service.Spec.ClusterIP, "provided IP is already allocated"),
+		})
+	})
+
+	// When
+	result, err := stopDeleteServiceInternal(context.Background(), k8s, &state)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, *result.Messages, 1)
+	require.Equal(t, "The cluster IP 10.96.0.42 of service default/checkout couldn't be reclaimed, the service is recreated with a new cluster IP.", (*result.Messages)[0].Message)
+	service, err := builder.Clientset.CoreV1().Services("default").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, service.Spec.ClusterIP)
+}
+
+func TestDeleteServiceWaitsForTerminatingLoadBalancer(t *testing.T) {
+	// Given
+	builder, k8s, state := startDeletedCheckoutService(t)
+	require.NoError(t, builder.Clientset.Tracker().Add(terminatingCheckoutService()))
+	gets := 0
+	builder.Clientset.PrependReactor("get", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		if gets == 2 {
+			require.NoError(t, builder.Clientset.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("services"), "default", "checkout"))
+		}
+		return false, nil, nil
+	})
+
+	// When
+	result, err := stopDeleteServiceInternal(context.Background(), k8s, state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	service, err := builder.Clientset.CoreV1().Services("default").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Nil(t, service.DeletionTimestamp)
+	require.Equal(t, "10.96.0.42", service.Spec.ClusterIP)
+}
+
+func TestDeleteServiceFailsIfLoadBalancerKeepsTerminating(t *testing.T) {
+	// Given
+	builder, k8s, state := startDeletedCheckoutService(t)
+	require.NoError(t, builder.Clientset.Tracker().Add(terminatingCheckoutService()))
+
+	// When
+	_, err := stopDeleteServiceInternal(context.Background(), k8s, state)
+
+	// Then
+	require.EqualError(t, err, "Service default/checkout is still terminating, it couldn't be recreated within 50ms.")
+}
+
+func TestDeleteServiceKeepsServiceRecreatedByOthers(t *testing.T) {
+	// Given
+	builder, k8s, state := startDeletedCheckoutService(t)
+	recreated := checkoutService()
+	recreated.UID = "2"
+	recreated.Spec.ClusterIP = "10.96.0.43"
+	require.NoError(t, builder.Clientset.Tracker().Add(recreated))
+
+	// When
+	result, err := stopDeleteServiceInternal(context.Background(), k8s, state)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	service, err := builder.Clientset.CoreV1().Services("default").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, types.UID("2"), service.UID)
+	require.Equal(t, "10.96.0.43", service.Spec.ClusterIP)
+}
+
+func TestDeleteServicePrepareChecksBlastRadiusOfSelectedPods(t *testing.T) {
+	// Given
+	extconfig.Config.MaxBlastRadiusPercent = 60
+	defer func() { extconfig.Config.MaxBlastRadiusPercent = 100 }()
+	k8s := testsupport.NewClientBuilder(t).
+		WithServices(checkoutService()).
+		WithPods(
+			servicePod("checkout-1", map[string]string{"app": "checkout"}, true),
+			servicePod("checkout-2", map[string]string{"app": "checkout"}, true),
+			servicePod("cart-1", map[string]string{"app": "cart"}, true),
+		).
+		Build()
+	state := NewDeleteServiceAction().NewEmptyState()
+
+	// When
+	_, err := prepareDeleteServiceInternal(k8s, &state, deleteServiceRequest("default", "checkout"))
+
+	// Then
+	require.EqualError(t, err, "Attack would affect 2 of 3 pods (67%), which exceeds the max. blast radius of 60%.")
+}
+
+func TestDeleteServicePrepareRejectsApiServerService(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithServices(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"}}).
+		Build()
+	state := NewDeleteServiceAction().NewEmptyState()
+
+	// When
+	_, err := prepareDeleteServiceInternal(k8s, &state, deleteServiceRequest("default", "kubernetes"))
+
+	// Then
+	require.EqualError(t, err, "The service of the Kubernetes API can't be deleted.")
+}
+
+func checkoutService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout",
+			Namespace:       "default",
+			UID:             "1",
+			ResourceVersion: "42",
+			Labels:          map[string]string{"app": "checkout"},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:   map[string]string{"app": "checkout"},
+			ClusterIP:  "10.96.0.42",
+			ClusterIPs: []string{"10.96.0.42"},
+			Ports:      []corev1.ServicePort{{Port: 80}},
+		},
+	}
+}
+
+// terminatingCheckoutService is the deleted checkout service held by the finalizer of its load balancer.
+func terminatingCheckoutService() *corev1.Service {
+	service := checkoutService()
+	service.Spec.Type = corev1.ServiceTypeLoadBalancer
+	service.Finalizers = []string{"service.kubernetes.io/load-balancer-cleanup"}
+	service.DeletionTimestamp = extutil.Ptr(metav1.Now())
+	return service
+}
+
+func startDeletedCheckoutService(t *testing.T) (*testsupport.ClientBuilder, *client.Client, *DeleteServiceState) {
+	previousInterval, previousTimeout := serviceTerminationPollInterval, serviceTerminationTimeout
+	serviceTerminationPollInterval, serviceTerminationTimeout = time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() {
+		serviceTerminationPollInterval, serviceTerminationTimeout = previousInterval, previousTimeout
+	})
+	builder := testsupport.NewClientBuilder(t).WithServices(checkoutService())
+	k8s := builder.Build()
+	state := NewDeleteServiceAction().NewEmptyState()
+	_, err := prepareDeleteServiceInternal(k8s, &state, deleteServiceRequest("default", "checkout"))
+	require.NoError(t, err)
+	_, err = startDeleteServiceInternal(context.Background(), k8s, &state)
+	require.NoError(t, err)
+	return builder, k8s, &state
+}
+
+func deleteServiceRequest(namespace string, service string) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 60000,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace": {namespace},
+				"k8s.service":   {service},
+			},
+		}),
+	}
+}
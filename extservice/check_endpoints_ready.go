@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"time"
+)
+
+const (
+	endpointsMin1            = "endpointsMin1"
+	endpointsAllReady        = "endpointsAllReady"
+	endpointsEqualsReadyPods = "endpointsEqualsReadyPods"
+)
+
+type EndpointsReadyCheckAction struct {
+}
+
+type EndpointsReadyCheckState struct {
+	Cluster   string
+	Timeout   time.Time
+	Namespace string
+	Service   string
+	Mode      string
+}
+
+type EndpointsReadyCheckConfig struct {
+	Duration                int
+	EndpointsReadyCheckMode string
+}
+
+func NewEndpointsReadyCheckAction() action_kit_sdk.Action[EndpointsReadyCheckState] {
+	return EndpointsReadyCheckAction{}
+}
+
+var _ action_kit_sdk.Action[EndpointsReadyCheckState] = (*EndpointsReadyCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[EndpointsReadyCheckState] = (*EndpointsReadyCheckAction)(nil)
+
+func (f EndpointsReadyCheckAction) NewEmptyState() EndpointsReadyCheckState {
+	return EndpointsReadyCheckState{}
+}
+
+func (f EndpointsReadyCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          endpointsReadyCheckActionId,
+		Label:       "Service Endpoints Ready",
+		Description: "Verify the ready endpoints of the service within the timeout, based on its endpoint slices. Services without selector and manually managed endpoints are supported as well.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(serviceIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          ServiceTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find service by cluster, namespace and service"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.service=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Timeout",
+				Description:  extutil.Ptr("How long should the check wait for the ready endpoints."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "endpointsReadyCheckMode",
+				Label:        "Check type",
+				Description:  extutil.Ptr("Which endpoints should be ready?"),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr(endpointsMin1),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "ready endpoints > 0",
+						Value: endpointsMin1,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "all endpoints ready",
+						Value: endpointsAllReady,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "ready endpoints = ready pods matching the selector",
+						Value: endpointsEqualsReadyPods,
+					},
+				}),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f EndpointsReadyCheckAction) Prepare(_ context.Context, state *EndpointsReadyCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareEndpointsReadyCheckInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareEndpointsReadyCheckInternal(k8s *client.Client, state *EndpointsReadyCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config EndpointsReadyCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Service = request.Target.Attributes["k8s.service"][0]
+	state.Mode = config.EndpointsReadyCheckMode
+
+	service := k8s.ServiceByNamespaceAndName(state.Namespace, state.Service)
+	if service == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Service %s not found", state.Service), nil)
+	}
+	if state.Mode == endpointsEqualsReadyPods && len(service.Spec.Selector) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Service %s has no selector to match pods.", state.Service), nil)
+	}
+	return nil, nil
+}
+
+func (f EndpointsReadyCheckAction) Start(_ context.Context, _ *EndpointsReadyCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f EndpointsReadyCheckAction) Status(_ context.Context, state *EndpointsReadyCheckState) (*action_kit_api.StatusResult, error) {
+	return statusEndpointsReadyCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusEndpointsReadyCheckInternal(k8s *client.Client, state *EndpointsReadyCheckState) *action_kit_api.StatusResult {
+	service := k8s.ServiceByNamespaceAndName(state.Namespace, state.Service)
+	if service == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Service %s not found", state.Service),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	ready, total := countEndpoints(k8s.EndpointSlicesByService(state.Namespace, state.Service))
+
+	var failure string
+	switch state.Mode {
+	case endpointsMin1:
+		if ready < 1 {
+			failure = fmt.Sprintf("%s has no ready endpoints.", state.Service)
+		}
+	case endpointsAllReady:
+		if ready < total || total == 0 {
+			failure = fmt.Sprintf("%s has %d of %d endpoints ready.", state.Service, ready, total)
+		}
+	case endpointsEqualsReadyPods:
+		if readyPods := readyEndpoints(k8s, service); ready != readyPods {
+			failure = fmt.Sprintf("%s has %d ready endpoints, but %d ready pods match its selector.", state.Service, ready, readyPods)
+		}
+	}
+
+	if failure == "" {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+		}
+	}
+
+	if time.Now().After(state.Timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  failure,
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: false,
+	}
+}
+
+// countEndpoints counts the ready and all endpoints of the slices. Endpoints of the same pod are counted once, as
+// dual-stack services have a slice per address family.
+func countEndpoints(slices []*discoveryv1.EndpointSlice) (ready int, total int) {
+	readyByKey := map[string]bool{}
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			key := fmt.Sprintf("%v", endpoint.Addresses)
+			if endpoint.TargetRef != nil {
+				key = fmt.Sprintf("%s/%s/%s", endpoint.TargetRef.Kind, endpoint.TargetRef.Namespace, endpoint.TargetRef.Name)
+			}
+			// An unknown ready condition is to be interpreted as ready.
+			readyByKey[key] = readyByKey[key] || endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+		}
+	}
+	for _, isReady := range readyByKey {
+		total++
+		if isReady {
+			ready++
+		}
+	}
+	return ready, total
+}
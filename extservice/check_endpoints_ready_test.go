@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestEndpointsReadyCheckSucceedsWithReadyEndpoint(t *testing.T) {
+	// Given
+	k8s := createEndpointsReadyTestClient(t, true, false)
+	state := endpointsReadyCheckState(endpointsMin1, time.Minute)
+
+	// When
+	result := statusEndpointsReadyCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestEndpointsReadyCheckFailsWithEndpointNotReadyAfterTimeout(t *testing.T) {
+	// Given
+	k8s := createEndpointsReadyTestClient(t, true, false)
+	state := endpointsReadyCheckState(endpointsAllReady, -time.Second)
+
+	// When
+	result := statusEndpointsReadyCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "shop has 1 of 2 endpoints ready.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestEndpointsReadyCheckWaitsForEndpointsWithinTimeout(t *testing.T) {
+	// Given
+	k8s := createEndpointsReadyTestClient(t, false, false)
+	state := endpointsReadyCheckState(endpointsMin1, time.Minute)
+
+	// When
+	result := statusEndpointsReadyCheckInternal(k8s, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestEndpointsReadyCheckComparesWithReadyPods(t *testing.T) {
+	// Given
+	k8s := createEndpointsReadyTestClient(t, true, true)
+	state := endpointsReadyCheckState(endpointsEqualsReadyPods, -time.Second)
+
+	// When
+	result := statusEndpointsReadyCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "shop has 1 ready endpoints, but 2 ready pods match its selector.", result.Error.Title)
+}
+
+func TestEndpointsReadyCheckSupportsServicesWithoutSelector(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithServices(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "legacy-db", Namespace: "default"}}).
+		WithEndpointSlices(&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy-db-1",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "legacy-db"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"192.168.0.10"}},
+			},
+		}).
+		Build()
+	state := EndpointsReadyCheckState{Timeout: time.Now().Add(time.Minute), Namespace: "default", Service: "legacy-db", Mode: endpointsAllReady}
+
+	// When
+	result := statusEndpointsReadyCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestEndpointsReadyCheckPrepareRejectsReadyPodsModeWithoutSelector(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithServices(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "legacy-db", Namespace: "default"}}).
+		Build()
+	state := NewEndpointsReadyCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareEndpointsReadyCheckInternal(k8s, &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":                30000,
+			"endpointsReadyCheckMode": endpointsEqualsReadyPods,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace": {"default"},
+				"k8s.service":   {"legacy-db"},
+			},
+		}),
+	})
+
+	// Then
+	require.EqualError(t, err, "Service legacy-db has no selector to match pods.")
+}
+
+func TestCountEndpointsCountsDualStackEndpointsOnce(t *testing.T) {
+	// Given
+	pod := &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "shop-1"}
+	slices := []*discoveryv1.EndpointSlice{
+		{Endpoints: []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}, TargetRef: pod, Conditions: discoveryv1.EndpointConditions{Ready: extutil.Ptr(true)}}}},
+		{Endpoints: []discoveryv1.Endpoint{{Addresses: []string{"fd00::1"}, TargetRef: pod, Conditions: discoveryv1.EndpointConditions{Ready: extutil.Ptr(true)}}}},
+	}
+
+	// When
+	ready, total := countEndpoints(slices)
+
+	// Then
+	assert.Equal(t, 1, ready)
+	assert.Equal(t, 1, total)
+}
+
+func endpointsReadyCheckState(mode string, timeout time.Duration) EndpointsReadyCheckState {
+	return EndpointsReadyCheckState{
+		Timeout:   time.Now().Add(timeout),
+		Namespace: "default",
+		Service:   "shop",
+		Mode:      mode,
+	}
+}
+
+func createEndpointsReadyTestClient(t *testing.T, firstReady bool, secondPodReady bool) *client.Client {
+	selector := map[string]string{"app": "shop"}
+	return testsupport.NewClientBuilder(t).
+		WithServices(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		}).
+		WithPods(servicePod("shop-1", selector, true), servicePod("shop-2", selector, secondPodReady)).
+		WithEndpointSlices(&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "shop"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses:  []string{"10.0.0.1"},
+					TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "shop-1"},
+					Conditions: discoveryv1.EndpointConditions{Ready: extutil.Ptr(firstReady)},
+				},
+				{
+					Addresses:  []string{"10.0.0.2"},
+					TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "shop-2"},
+					Conditions: discoveryv1.EndpointConditions{Ready: extutil.Ptr(false)},
+				},
+			},
+		}).
+		Build()
+}
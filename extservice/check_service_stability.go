@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"time"
+)
+
+type ServiceStabilityCheckAction struct {
+}
+
+type ServiceStabilityCheckState struct {
+	Cluster   string
+	Timeout   time.Time
+	Namespace string
+	Service   string
+	ClusterIP string
+}
+
+type ServiceStabilityCheckConfig struct {
+	Duration int
+}
+
+func NewServiceStabilityCheckAction() action_kit_sdk.Action[ServiceStabilityCheckState] {
+	return ServiceStabilityCheckAction{}
+}
+
+var _ action_kit_sdk.Action[ServiceStabilityCheckState] = (*ServiceStabilityCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ServiceStabilityCheckState] = (*ServiceStabilityCheckAction)(nil)
+
+func (f ServiceStabilityCheckAction) NewEmptyState() ServiceStabilityCheckState {
+	return ServiceStabilityCheckState{}
+}
+
+func (f ServiceStabilityCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          serviceStabilityCheckActionId,
+		Label:       "Service Stability",
+		Description: "Verify that the service keeps its cluster IP for the duration of the check. The check fails as soon as the service is deleted or recreated with a different cluster IP, which breaks clients caching the resolved address.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(serviceIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          ServiceTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find service by cluster, namespace and service"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.service=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the cluster IP of the service be observed."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f ServiceStabilityCheckAction) Prepare(_ context.Context, state *ServiceStabilityCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	return prepareServiceStabilityCheckInternal(client.ForCluster(state.Cluster), state, request)
+}
+
+func prepareServiceStabilityCheckInternal(k8s *client.Client, state *ServiceStabilityCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config ServiceStabilityCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Service = request.Target.Attributes["k8s.service"][0]
+
+	service := k8s.ServiceByNamespaceAndName(state.Namespace, state.Service)
+	if service == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Service %s not found", state.Service), nil)
+	}
+	state.ClusterIP = service.Spec.ClusterIP
+	return nil, nil
+}
+
+func (f ServiceStabilityCheckAction) Start(_ context.Context, _ *ServiceStabilityCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f ServiceStabilityCheckAction) Status(_ context.Context, state *ServiceStabilityCheckState) (*action_kit_api.StatusResult, error) {
+	return statusServiceStabilityCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusServiceStabilityCheckInternal(k8s *client.Client, state *ServiceStabilityCheckState) *action_kit_api.StatusResult {
+	var failure string
+	if service := k8s.ServiceByNamespaceAndName(state.Namespace, state.Service); service == nil {
+		failure = fmt.Sprintf("%s was deleted.", state.Service)
+	} else if service.Spec.ClusterIP != state.ClusterIP {
+		failure = fmt.Sprintf("%s changed its cluster IP from %s to %s.", state.Service, state.ClusterIP, service.Spec.ClusterIP)
+	}
+
+	if failure != "" {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  failure,
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.Timeout),
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestServiceStabilityCheckSucceedsWithStableClusterIp(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).WithServices(checkoutService()).Build()
+	state := NewServiceStabilityCheckAction().NewEmptyState()
+	_, err := prepareServiceStabilityCheckInternal(k8s, &state, deleteServiceRequest("default", "checkout"))
+	require.NoError(t, err)
+	require.Equal(t, "10.96.0.42", state.ClusterIP)
+
+	// When
+	result := statusServiceStabilityCheckInternal(k8s, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+
+	// When
+	state.Timeout = time.Now().Add(-time.Second)
+	result = statusServiceStabilityCheckInternal(k8s, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestServiceStabilityCheckFailsWithChangedClusterIp(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithServices(checkoutService())
+	k8s := builder.Build()
+	state := ServiceStabilityCheckState{Namespace: "default", Service: "checkout", ClusterIP: "10.96.0.42", Timeout: time.Now().Add(time.Minute)}
+	recreated := checkoutService()
+	recreated.Spec.ClusterIP = "10.96.0.99"
+	_, err := builder.Clientset.CoreV1().Services("default").Update(context.Background(), recreated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// When
+	var result *action_kit_api.StatusResult
+	assert.Eventually(t, func() bool {
+		result = statusServiceStabilityCheckInternal(k8s, &state)
+		return result.Error != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout changed its cluster IP from 10.96.0.42 to 10.96.0.99.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestServiceStabilityCheckFailsWithDeletedService(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t).WithServices(checkoutService())
+	k8s := builder.Build()
+	state := ServiceStabilityCheckState{Namespace: "default", Service: "checkout", ClusterIP: "10.96.0.42", Timeout: time.Now().Add(time.Minute)}
+	err := builder.Clientset.CoreV1().Services("default").Delete(context.Background(), "checkout", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	// When
+	var result *action_kit_api.StatusResult
+	assert.Eventually(t, func() bool {
+		result = statusServiceStabilityCheckInternal(k8s, &state)
+		return result.Error != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout was deleted.", result.Error.Title)
+}
@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+const (
+	ServiceTargetType             = "com.steadybit.extension_kubernetes.kubernetes-service"
+	serviceIcon                   = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20d%3D%22M3%2012C3%2011.4477%203.44772%2011%204%2011H14.5858L11.2929%207.70711C10.9024%207.31658%2010.9024%206.68342%2011.2929%206.29289C11.6834%205.90237%2012.3166%205.90237%2012.7071%206.29289L17.7071%2011.2929C18.0976%2011.6834%2018.0976%2012.3166%2017.7071%2012.7071L12.7071%2017.7071C12.3166%2018.0976%2011.6834%2018.0976%2011.2929%2017.7071C10.9024%2017.3166%2010.9024%2016.6834%2011.2929%2016.2929L14.5858%2013H4C3.44772%2013%203%2012.5523%203%2012Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M20%203C20.5523%203%2021%203.44772%2021%204V20C21%2020.5523%2020.5523%2021%2020%2021C19.4477%2021%2019%2020.5523%2019%2020V4C19%203.44772%2019.4477%203%2020%203Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+	endpointsReadyCheckActionId   = "com.steadybit.extension_kubernetes.endpoints-ready-check"
+	serviceStabilityCheckActionId = "com.steadybit.extension_kubernetes.service-stability-check"
+	deleteServiceActionId         = "com.steadybit.extension_kubernetes.delete-service"
+)
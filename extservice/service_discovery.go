@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"net/http"
+	"strconv"
+)
+
+func RegisterServiceDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/service/discovery", exthttp.GetterAsHandler(getServiceDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/service/discovery/target-description", exthttp.GetterAsHandler(getServiceTargetDescription))
+	exthttp.RegisterHttpHandler("/service/discovery/discovered-targets", getDiscoveredServices)
+}
+
+func getServiceDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         ServiceTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/service/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getServiceTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       ServiceTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes Service", Other: "Kubernetes Services"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(serviceIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.service"},
+				{Attribute: "k8s.service.type"},
+				{Attribute: "k8s.service.ready-endpoints"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.service",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredServices(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredServiceTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredServiceTargets(k8s *client.Client) []discovery_kit_api.Target {
+	services := k8s.Services()
+
+	filteredServices := make([]*corev1.Service, 0, len(services))
+	if extconfig.Config.DisableDiscoveryExcludes {
+		filteredServices = services
+	} else {
+		for _, service := range services {
+			if client.IsExcludedFromDiscovery(service.ObjectMeta) {
+				continue
+			}
+			filteredServices = append(filteredServices, service)
+		}
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredServices))
+	for i, service := range filteredServices {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), service.Namespace, service.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":    {service.Namespace},
+			"k8s.service":      {service.Name},
+			"k8s.service.type": {string(service.Spec.Type)},
+			"k8s.cluster-name": {k8s.ClusterName()},
+			"k8s.distribution": {k8s.Distribution},
+		}
+
+		// Headless services have no cluster ip, their DNS name resolves to the pod ips instead.
+		if service.Spec.ClusterIP == corev1.ClusterIPNone {
+			attributes["k8s.service.headless"] = []string{"true"}
+		} else if service.Spec.ClusterIP != "" {
+			attributes["k8s.service.cluster-ip"] = []string{service.Spec.ClusterIP}
+		}
+
+		// The endpoints of services without selector are managed externally and can't be derived from pods.
+		if len(service.Spec.Selector) > 0 {
+			attributes["k8s.service.selector"] = []string{labels.Set(service.Spec.Selector).String()}
+			attributes["k8s.service.ready-endpoints"] = []string{strconv.Itoa(readyEndpoints(k8s, service))}
+		}
+
+		for key, value := range service.ObjectMeta.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes[fmt.Sprintf("k8s.service.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		extcommon.AddNamespaceAttributes(k8s, service.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesService)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: ServiceTargetType,
+			Label:      service.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
+
+// readyEndpoints counts the ready pods matching the selector of the service, which are the pods the endpoints
+// controller adds as ready addresses. Terminating pods are removed from the endpoints.
+func readyEndpoints(k8s *client.Client, service *corev1.Service) int {
+	count := 0
+	for _, pod := range k8s.PodsBySelector(service.Namespace, &metav1.LabelSelector{MatchLabels: service.Spec.Selector}) {
+		if pod.DeletionTimestamp == nil && client.IsPodReady(pod) {
+			count++
+		}
+	}
+	return count
+}
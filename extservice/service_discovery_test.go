@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extservice
+
+import (
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getDiscoveredServices(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	selector := map[string]string{"app": "shop", "tier": "web"}
+	k8s := testsupport.NewClientBuilder(t).
+		WithServices(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+				Labels: map[string]string{
+					"best-city":    "Kevelaer",
+					"secret-label": "secret-value",
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: "10.96.0.42",
+				Selector:  selector,
+			},
+		}).
+		WithPods(
+			servicePod("shop-1", selector, true),
+			servicePod("shop-2", selector, false),
+			servicePod("other", map[string]string{"app": "shop"}, true),
+		).
+		Build()
+
+	// When
+	targets := getDiscoveredServiceTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/shop", target.Id)
+	assert.Equal(t, "shop", target.Label)
+	assert.Equal(t, ServiceTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":               {"default"},
+		"k8s.service":                 {"shop"},
+		"k8s.service.type":            {"ClusterIP"},
+		"k8s.service.cluster-ip":      {"10.96.0.42"},
+		"k8s.service.selector":        {"app=shop,tier=web"},
+		"k8s.service.ready-endpoints": {"1"},
+		"k8s.cluster-name":            {"development"},
+		"k8s.distribution":            {"kubernetes"},
+		"k8s.service.label.best-city": {"Kevelaer"},
+		"k8s.label.best-city":         {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredServicesShouldReportHeadlessServices(t *testing.T) {
+	// Given
+	selector := map[string]string{"app": "db"}
+	k8s := testsupport.NewClientBuilder(t).
+		WithServices(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: corev1.ClusterIPNone,
+				Selector:  selector,
+			},
+		}).
+		WithPods(servicePod("db-0", selector, true), servicePod("db-1", selector, true)).
+		Build()
+
+	// When
+	targets := getDiscoveredServiceTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"true"}, targets[0].Attributes["k8s.service.headless"])
+	assert.NotContains(t, targets[0].Attributes, "k8s.service.cluster-ip")
+	assert.Equal(t, []string{"2"}, targets[0].Attributes["k8s.service.ready-endpoints"])
+}
+
+func Test_getDiscoveredServicesShouldOmitReadyEndpointsWithoutSelector(t *testing.T) {
+	// Given
+	k8s := testsupport.NewClientBuilder(t).
+		WithServices(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy-db", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:         corev1.ServiceTypeExternalName,
+				ExternalName: "db.example.com",
+			},
+		}).
+		Build()
+
+	// When
+	targets := getDiscoveredServiceTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"ExternalName"}, targets[0].Attributes["k8s.service.type"])
+	assert.NotContains(t, targets[0].Attributes, "k8s.service.selector")
+	assert.NotContains(t, targets[0].Attributes, "k8s.service.ready-endpoints")
+}
+
+func servicePod(name string, labels map[string]string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	corev1 "k8s.io/api/core/v1"
+	"time"
+)
+
+type HeadlessServiceCheckAction struct {
+}
+
+type HeadlessServiceCheckState struct {
+	Cluster     string
+	Timeout     time.Time
+	Namespace   string
+	StatefulSet string
+}
+
+type HeadlessServiceCheckConfig struct {
+	Duration int
+}
+
+func NewHeadlessServiceCheckAction() action_kit_sdk.Action[HeadlessServiceCheckState] {
+	return HeadlessServiceCheckAction{}
+}
+
+var _ action_kit_sdk.Action[HeadlessServiceCheckState] = (*HeadlessServiceCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[HeadlessServiceCheckState] = (*HeadlessServiceCheckAction)(nil)
+
+func (f HeadlessServiceCheckAction) NewEmptyState() HeadlessServiceCheckState {
+	return HeadlessServiceCheckState{}
+}
+
+func (f HeadlessServiceCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          headlessServiceCheckActionId,
+		Label:       "Headless Service",
+		Description: "Verify that the governing service of a StatefulSet exists and is headless. Without it the DNS names of the pods can't be resolved.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(statefulSetIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          StatefulSetTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find statefulset by cluster, namespace and statefulset"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.statefulset=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the service be checked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("10s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f HeadlessServiceCheckAction) Prepare(_ context.Context, state *HeadlessServiceCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config HeadlessServiceCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.StatefulSet = request.Target.Attributes["k8s.statefulset"][0]
+	return nil, nil
+}
+
+func (f HeadlessServiceCheckAction) Start(_ context.Context, _ *HeadlessServiceCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f HeadlessServiceCheckAction) Status(_ context.Context, state *HeadlessServiceCheckState) (*action_kit_api.StatusResult, error) {
+	return statusHeadlessServiceCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusHeadlessServiceCheckInternal(k8s *client.Client, state *HeadlessServiceCheckState) *action_kit_api.StatusResult {
+	statefulSet := k8s.StatefulSetByNamespaceAndName(state.Namespace, state.StatefulSet)
+	if statefulSet == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("StatefulSet %s not found", state.StatefulSet),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	serviceName := statefulSet.Spec.ServiceName
+	service := k8s.ServiceByNamespaceAndName(state.Namespace, serviceName)
+	var checkError *action_kit_api.ActionKitError
+	if service == nil {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("Governing service %s of %s doesn't exist.", serviceName, state.StatefulSet),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if service.Spec.ClusterIP != corev1.ClusterIPNone {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("Governing service %s of %s isn't headless (cluster ip %s).", serviceName, state.StatefulSet, service.Spec.ClusterIP),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+
+	if checkError != nil {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error:     checkError,
+		}
+	}
+	return &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.Timeout),
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestHeadlessServiceCheckSucceedsForHeadlessService(t *testing.T) {
+	// Given
+	k8sclient := createHeadlessServiceTestClient(t, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+	})
+	state := HeadlessServiceCheckState{
+		Timeout:     time.Now().Add(time.Minute),
+		Namespace:   "default",
+		StatefulSet: "db",
+	}
+
+	// When
+	result := statusHeadlessServiceCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestHeadlessServiceCheckFailsForNonHeadlessService(t *testing.T) {
+	// Given
+	k8sclient := createHeadlessServiceTestClient(t, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.12"},
+	})
+	state := HeadlessServiceCheckState{
+		Timeout:     time.Now().Add(time.Minute),
+		Namespace:   "default",
+		StatefulSet: "db",
+	}
+
+	// When
+	result := statusHeadlessServiceCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Governing service db of db isn't headless (cluster ip 10.0.0.12).", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestHeadlessServiceCheckFailsForMissingService(t *testing.T) {
+	// Given
+	k8sclient := createHeadlessServiceTestClient(t, nil)
+	state := HeadlessServiceCheckState{
+		Timeout:     time.Now().Add(time.Minute),
+		Namespace:   "default",
+		StatefulSet: "db",
+	}
+
+	// When
+	result := statusHeadlessServiceCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "Governing service db of db doesn't exist.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func createHeadlessServiceTestClient(t *testing.T, service *corev1.Service) *client.Client {
+	builder := testsupport.NewClientBuilder(t)
+	_, err := builder.Clientset.AppsV1().StatefulSets("default").Create(context.Background(), &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec:       appsv1.StatefulSetSpec{ServiceName: "db"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	if service != nil {
+		_, err = builder.Clientset.CoreV1().Services("default").Create(context.Background(), service, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	return builder.Build()
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type OrphanedPvcCheckAction struct {
+}
+
+type OrphanedPvcCheckState struct {
+	Cluster     string
+	Timeout     time.Time
+	Namespace   string
+	StatefulSet string
+}
+
+type OrphanedPvcCheckConfig struct {
+	Duration int
+}
+
+func NewOrphanedPvcCheckAction() action_kit_sdk.Action[OrphanedPvcCheckState] {
+	return OrphanedPvcCheckAction{}
+}
+
+var _ action_kit_sdk.Action[OrphanedPvcCheckState] = (*OrphanedPvcCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[OrphanedPvcCheckState] = (*OrphanedPvcCheckAction)(nil)
+
+func (f OrphanedPvcCheckAction) NewEmptyState() OrphanedPvcCheckState {
+	return OrphanedPvcCheckState{}
+}
+
+func (f OrphanedPvcCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          orphanedPvcCheckActionId,
+		Label:       "Orphaned Persistent Volume Claims",
+		Description: "Verify that every persistent volume claim created from the volume claim templates of a StatefulSet still belongs to a replica, e.g. after scaling down.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(statefulSetIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          StatefulSetTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find statefulset by cluster, namespace and statefulset"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.statefulset=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the persistent volume claims be checked."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("10s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f OrphanedPvcCheckAction) Prepare(_ context.Context, state *OrphanedPvcCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config OrphanedPvcCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.StatefulSet = request.Target.Attributes["k8s.statefulset"][0]
+	return nil, nil
+}
+
+func (f OrphanedPvcCheckAction) Start(_ context.Context, _ *OrphanedPvcCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f OrphanedPvcCheckAction) Status(_ context.Context, state *OrphanedPvcCheckState) (*action_kit_api.StatusResult, error) {
+	return statusOrphanedPvcCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusOrphanedPvcCheckInternal(k8s *client.Client, state *OrphanedPvcCheckState) *action_kit_api.StatusResult {
+	statefulSet := k8s.StatefulSetByNamespaceAndName(state.Namespace, state.StatefulSet)
+	if statefulSet == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("StatefulSet %s not found", state.StatefulSet),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	orphans := orphanedPvcs(k8s, statefulSet)
+	if len(orphans) > 0 {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s has orphaned persistent volume claims: %s", state.StatefulSet, strings.Join(orphans, ", ")),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.Timeout),
+	}
+}
+
+// orphanedPvcs returns the claims named <template>-<statefulset>-<ordinal> of deleted replicas, i.e. with an ordinal
+// beyond the replicas, for which no pod <statefulset>-<ordinal> is running anymore. Claims of replicas which are just
+// rescheduled or rolled are still in use.
+func orphanedPvcs(k8s *client.Client, statefulSet *appsv1.StatefulSet) []string {
+	pods := make(map[string]bool)
+	for _, pod := range k8s.PodsBySelector(statefulSet.Namespace, statefulSet.Spec.Selector) {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			pods[pod.Name] = true
+		}
+	}
+	replicas := 1
+	if statefulSet.Spec.Replicas != nil {
+		replicas = int(*statefulSet.Spec.Replicas)
+	}
+	start := 0
+	if statefulSet.Spec.Ordinals != nil {
+		start = int(statefulSet.Spec.Ordinals.Start)
+	}
+
+	var orphans []string
+	for _, pvc := range k8s.PersistentVolumeClaims(statefulSet.Namespace) {
+		for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+			ordinal, found := strings.CutPrefix(pvc.Name, fmt.Sprintf("%s-%s-", template.Name, statefulSet.Name))
+			if !found {
+				continue
+			}
+			index, err := strconv.Atoi(ordinal)
+			if err != nil || (index >= start && index < start+replicas) {
+				continue
+			}
+			if !pods[fmt.Sprintf("%s-%s", statefulSet.Name, ordinal)] {
+				orphans = append(orphans, pvc.Name)
+			}
+		}
+	}
+	return orphans
+}
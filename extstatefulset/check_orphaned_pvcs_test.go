@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestOrphanedPvcCheckFailsForOrphanedPvc(t *testing.T) {
+	// Given
+	k8sclient := createOrphanedPvcTestClient(t, []string{"data-db-0", "data-db-1", "data-db-2"}, []string{"db-0", "db-1"})
+	state := OrphanedPvcCheckState{
+		Timeout:     time.Now().Add(time.Minute),
+		Namespace:   "default",
+		StatefulSet: "db",
+	}
+
+	// When
+	result := statusOrphanedPvcCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "db has orphaned persistent volume claims: data-db-2", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestOrphanedPvcCheckIgnoresUnrelatedPvcs(t *testing.T) {
+	// Given
+	k8sclient := createOrphanedPvcTestClient(t, []string{"data-db-0", "data-dbx-3", "data-db-backup", "other"}, []string{"db-0"})
+	state := OrphanedPvcCheckState{
+		Timeout:     time.Now().Add(time.Minute),
+		Namespace:   "default",
+		StatefulSet: "db",
+	}
+
+	// When
+	result := statusOrphanedPvcCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestOrphanedPvcCheckPassesWhileReplicaIsRescheduled(t *testing.T) {
+	// Given
+	k8sclient := createOrphanedPvcTestClient(t, []string{"data-db-0", "data-db-1"}, []string{"db-1"})
+	state := OrphanedPvcCheckState{
+		Timeout:     time.Now().Add(time.Minute),
+		Namespace:   "default",
+		StatefulSet: "db",
+	}
+
+	// When
+	result := statusOrphanedPvcCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func createOrphanedPvcTestClient(t *testing.T, pvcNames []string, podNames []string) *client.Client {
+	labels := map[string]string{"app": "db"}
+	builder := testsupport.NewClientBuilder(t)
+	_, err := builder.Clientset.AppsV1().StatefulSets("default").Create(context.Background(), &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: extutil.Ptr(int32(2)),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	for _, name := range pvcNames {
+		_, err = builder.Clientset.CoreV1().PersistentVolumeClaims("default").Create(context.Background(), &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	for _, name := range podNames {
+		builder.WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+	}
+	return builder.Build()
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"time"
+)
+
+type StatefulSetPodCountCheckAction struct {
+}
+
+type StatefulSetPodCountCheckState struct {
+	Cluster           string
+	Timeout           time.Time
+	PodCountCheckMode string
+	Namespace         string
+	StatefulSet       string
+}
+
+type StatefulSetPodCountCheckConfig struct {
+	Duration          int
+	PodCountCheckMode string
+}
+
+func NewStatefulSetPodCountCheckAction() action_kit_sdk.Action[StatefulSetPodCountCheckState] {
+	return StatefulSetPodCountCheckAction{}
+}
+
+var _ action_kit_sdk.Action[StatefulSetPodCountCheckState] = (*StatefulSetPodCountCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[StatefulSetPodCountCheckState] = (*StatefulSetPodCountCheckAction)(nil)
+
+func (f StatefulSetPodCountCheckAction) NewEmptyState() StatefulSetPodCountCheckState {
+	return StatefulSetPodCountCheckState{}
+}
+
+func (f StatefulSetPodCountCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          podCountCheckActionId,
+		Label:       "StatefulSet Pod Count",
+		Description: "Verify the ready pod count of a StatefulSet against its desired replicas.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(statefulSetIcon),
+		Category:    extutil.Ptr("kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          StatefulSetTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find statefulset by cluster, namespace and statefulset"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.statefulset=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Timeout",
+				Description:  extutil.Ptr("How long should the check wait for the specified pod count."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("10s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "podCountCheckMode",
+				Label:        "Pod count",
+				Description:  extutil.Ptr("How many pods are required to let the check pass."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr(extcommon.PodCountEqualsDesiredCount),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Options:      extutil.Ptr(extcommon.PodCountCheckModeOptions()),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f StatefulSetPodCountCheckAction) Prepare(_ context.Context, state *StatefulSetPodCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if err := extcommon.TargetCluster(request.Target, &state.Cluster); err != nil {
+		return nil, err
+	}
+	var config StatefulSetPodCountCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.PodCountCheckMode = config.PodCountCheckMode
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.StatefulSet = request.Target.Attributes["k8s.statefulset"][0]
+	return nil, nil
+}
+
+func (f StatefulSetPodCountCheckAction) Start(_ context.Context, _ *StatefulSetPodCountCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f StatefulSetPodCountCheckAction) Status(_ context.Context, state *StatefulSetPodCountCheckState) (*action_kit_api.StatusResult, error) {
+	return statusStatefulSetPodCountCheckInternal(client.ForCluster(state.Cluster), state), nil
+}
+
+func statusStatefulSetPodCountCheckInternal(k8s *client.Client, state *StatefulSetPodCountCheckState) *action_kit_api.StatusResult {
+	statefulSet := k8s.StatefulSetByNamespaceAndName(state.Namespace, state.StatefulSet)
+	if statefulSet == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("StatefulSet %s not found", state.StatefulSet),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	return extcommon.PodCountStatus("StatefulSet", state.StatefulSet, state.PodCountCheckMode, statefulSet.Status.ReadyReplicas, statefulSet.Spec.Replicas, state.Timeout)
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestStatefulSetPodCountCheckSucceedsWhenAllReplicasAreReady(t *testing.T) {
+	// Given
+	k8sclient := createPodCountTestClient(t, extutil.Ptr(int32(3)), 3)
+	state := podCountTestState(extcommon.PodCountEqualsDesiredCount)
+
+	// When
+	result := statusStatefulSetPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestStatefulSetPodCountCheckFailsWhenReplicasAreMissing(t *testing.T) {
+	// Given
+	k8sclient := createPodCountTestClient(t, extutil.Ptr(int32(3)), 2)
+	state := podCountTestState(extcommon.PodCountEqualsDesiredCount)
+	state.Timeout = time.Now().Add(-time.Second)
+
+	// When
+	result := statusStatefulSetPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "db has only 2 of desired 3 pods ready.", result.Error.Title)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestStatefulSetPodCountCheckFailsWithoutReadyPods(t *testing.T) {
+	// Given
+	k8sclient := createPodCountTestClient(t, extutil.Ptr(int32(3)), 0)
+	state := podCountTestState(extcommon.PodCountMin1)
+	state.Timeout = time.Now().Add(-time.Second)
+
+	// When
+	result := statusStatefulSetPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "db has no ready pods.", result.Error.Title)
+}
+
+func TestStatefulSetPodCountCheckSucceedsWhenLessThanDesiredAreReady(t *testing.T) {
+	// Given
+	k8sclient := createPodCountTestClient(t, extutil.Ptr(int32(3)), 1)
+	state := podCountTestState(extcommon.PodCountLessThanDesiredCount)
+
+	// When
+	result := statusStatefulSetPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestStatefulSetPodCountCheckErrorsWithoutDesiredCount(t *testing.T) {
+	// Given
+	k8sclient := createPodCountTestClient(t, nil, 1)
+	state := podCountTestState(extcommon.PodCountEqualsDesiredCount)
+
+	// When
+	result := statusStatefulSetPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Equal(t, "StatefulSet db has no desired count.", result.Error.Title)
+	require.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func TestStatefulSetPodCountCheckStatefulSetNotFound(t *testing.T) {
+	// Given
+	k8sclient := testsupport.NewClientBuilder(t).Build()
+	state := podCountTestState(extcommon.PodCountMin1)
+
+	// When
+	result := statusStatefulSetPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Equal(t, "StatefulSet db not found", result.Error.Title)
+}
+
+func podCountTestState(mode string) StatefulSetPodCountCheckState {
+	return StatefulSetPodCountCheckState{
+		Timeout:           time.Now().Add(time.Minute),
+		PodCountCheckMode: mode,
+		Namespace:         "default",
+		StatefulSet:       "db",
+	}
+}
+
+func createPodCountTestClient(t *testing.T, replicas *int32, readyReplicas int32) *client.Client {
+	return testsupport.NewClientBuilder(t).
+		WithStatefulSets(&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: replicas},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: readyReplicas},
+		}).
+		Build()
+}
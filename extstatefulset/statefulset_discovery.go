@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	"net/http"
+	"strconv"
+)
+
+func RegisterStatefulSetDiscoveryHandlers() {
+	exthttp.RegisterHttpHandler("/statefulset/discovery", exthttp.GetterAsHandler(getStatefulSetDiscoveryDescription))
+	exthttp.RegisterHttpHandler("/statefulset/discovery/target-description", exthttp.GetterAsHandler(getStatefulSetTargetDescription))
+	exthttp.RegisterHttpHandler("/statefulset/discovery/discovered-targets", getDiscoveredStatefulSets)
+}
+
+func getStatefulSetDiscoveryDescription() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:         StatefulSetTargetType,
+		RestrictTo: extutil.Ptr(discovery_kit_api.LEADER),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			Method:       "GET",
+			Path:         "/statefulset/discovery/discovered-targets",
+			CallInterval: extcommon.DiscoveryCallInterval(client.K8S),
+		},
+	}
+}
+
+func getStatefulSetTargetDescription() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       StatefulSetTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes StatefulSet", Other: "Kubernetes StatefulSets"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(statefulSetIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.statefulset"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.statefulset",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func getDiscoveredStatefulSets(w http.ResponseWriter, _ *http.Request, _ []byte) {
+	targets := extcommon.DiscoverAllClusters(getDiscoveredStatefulSetTargets)
+	exthttp.WriteBody(w, discovery_kit_api.DiscoveryData{Targets: &targets})
+}
+
+func getDiscoveredStatefulSetTargets(k8s *client.Client) []discovery_kit_api.Target {
+	statefulSets := k8s.StatefulSets()
+
+	filteredStatefulSets := make([]*appsv1.StatefulSet, 0, len(statefulSets))
+	if extconfig.Config.DisableDiscoveryExcludes {
+		filteredStatefulSets = statefulSets
+	} else {
+		for _, s := range statefulSets {
+			if client.IsExcludedFromDiscovery(s.ObjectMeta) {
+				continue
+			}
+			filteredStatefulSets = append(filteredStatefulSets, s)
+		}
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredStatefulSets))
+	for i, s := range filteredStatefulSets {
+		targetName := fmt.Sprintf("%s/%s/%s", k8s.ClusterName(), s.Namespace, s.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":    {s.Namespace},
+			"k8s.statefulset":  {s.Name},
+			"k8s.cluster-name": {k8s.ClusterName()},
+			"k8s.distribution": {k8s.Distribution},
+			// Compared to the desired replicas, this shows how far a rollout has progressed.
+			"k8s.statefulset.updated-replicas": {strconv.Itoa(int(s.Status.UpdatedReplicas))},
+		}
+
+		if templates := volumeClaimTemplates(s); len(templates) > 0 {
+			attributes["k8s.statefulset.volume-claim-templates"] = templates
+		}
+
+		for key, value := range s.ObjectMeta.Labels {
+			if !extconfig.IsLabelFiltered(key) {
+				attributes[fmt.Sprintf("k8s.statefulset.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		extcommon.AddNamespaceAttributes(k8s, s.Namespace, attributes)
+		extcommon.ApplyAttributeAliases(attributes)
+		extcommon.ExcludeAttributes(attributes, extconfig.Config.DiscoveryAttributesExcludesStatefulSet)
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: StatefulSetTargetType,
+			Label:      s.Name,
+			Attributes: attributes,
+		}
+	}
+	return targets
+}
+
+// volumeClaimTemplates returns the templates as <name>:<storage class>. The storage class is omitted if the
+// template relies on the default storage class.
+func volumeClaimTemplates(s *appsv1.StatefulSet) []string {
+	templates := make([]string, 0, len(s.Spec.VolumeClaimTemplates))
+	for _, template := range s.Spec.VolumeClaimTemplates {
+		if template.Spec.StorageClassName != nil && *template.Spec.StorageClassName != "" {
+			templates = append(templates, fmt.Sprintf("%s:%s", template.Name, *template.Spec.StorageClassName))
+		} else {
+			templates = append(templates, template.Name)
+		}
+	}
+	return templates
+}
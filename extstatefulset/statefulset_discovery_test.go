@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"context"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/steadybit/extension-kubernetes/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getDiscoveredStatefulSets(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	builder := testsupport.NewClientBuilder(t)
+	_, err := builder.Clientset.AppsV1().StatefulSets("default").Create(context.Background(), &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+			Labels: map[string]string{
+				"best-city":    "Kevelaer",
+				"secret-label": "secret-value",
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	k8s := builder.Build()
+
+	// When
+	targets := getDiscoveredStatefulSetTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/db", target.Id)
+	assert.Equal(t, "db", target.Label)
+	assert.Equal(t, StatefulSetTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace":                    {"default"},
+		"k8s.statefulset":                  {"db"},
+		"k8s.statefulset.updated-replicas": {"0"},
+		"k8s.cluster-name":                 {"development"},
+		"k8s.distribution":                 {"kubernetes"},
+		"k8s.statefulset.label.best-city":  {"Kevelaer"},
+		"k8s.label.best-city":              {"Kevelaer"},
+	}, target.Attributes)
+}
+
+func Test_getDiscoveredStatefulSetsWithVolumeClaimTemplates(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t)
+	_, err := builder.Clientset.AppsV1().StatefulSets("default").Create(context.Background(), &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: extutil.Ptr("fast-ssd")},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "logs"},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	k8s := builder.Build()
+
+	// When
+	targets := getDiscoveredStatefulSetTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"data:fast-ssd", "logs"}, targets[0].Attributes["k8s.statefulset.volume-claim-templates"])
+}
+
+func Test_getDiscoveredStatefulSetsShouldReportUpdatedReplicas(t *testing.T) {
+	// Given
+	builder := testsupport.NewClientBuilder(t)
+	_, err := builder.Clientset.AppsV1().StatefulSets("default").Create(context.Background(), &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: extutil.Ptr(int32(3)),
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        3,
+			UpdatedReplicas: 1,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	k8s := builder.Build()
+
+	// When
+	targets := getDiscoveredStatefulSetTargets(k8s)
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"1"}, targets[0].Attributes["k8s.statefulset.updated-replicas"])
+}
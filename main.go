@@ -15,11 +15,22 @@ import (
 	"github.com/steadybit/extension-kit/extruntime"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extcluster"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	"github.com/steadybit/extension-kubernetes/extcontainer"
+	"github.com/steadybit/extension-kubernetes/extcronjob"
+	"github.com/steadybit/extension-kubernetes/extdaemonset"
 	"github.com/steadybit/extension-kubernetes/extdeployment"
 	"github.com/steadybit/extension-kubernetes/extevents"
+	"github.com/steadybit/extension-kubernetes/exthpa"
+	"github.com/steadybit/extension-kubernetes/extingress"
+	"github.com/steadybit/extension-kubernetes/extjob"
 	"github.com/steadybit/extension-kubernetes/extnode"
+	"github.com/steadybit/extension-kubernetes/extpod"
+	"github.com/steadybit/extension-kubernetes/extpvc"
+	"github.com/steadybit/extension-kubernetes/extreplicaset"
+	"github.com/steadybit/extension-kubernetes/extservice"
+	"github.com/steadybit/extension-kubernetes/extstatefulset"
 )
 
 func main() {
@@ -32,24 +43,68 @@ func main() {
 	exthealth.SetReady(false)
 	exthealth.StartProbes(8089)
 
-	client.PrepareClient(stopCh)
-
+	// The client is configured by the cluster contexts, namespaces, resync period and user agent suffix.
 	extconfig.ParseConfiguration()
 	extconfig.ValidateConfiguration()
 
+	client.PrepareClient(stopCh)
+
 	exthttp.RegisterHttpHandler("/", exthttp.GetterAsHandler(getExtensionList))
 
-	action_kit_sdk.RegisterAction(extdeployment.NewDeploymentRolloutRestartAction())
-	action_kit_sdk.RegisterAction(extdeployment.NewCheckDeploymentRolloutStatusAction())
-	action_kit_sdk.RegisterAction(extdeployment.NewPodCountCheckAction())
-	action_kit_sdk.RegisterAction(extdeployment.NewPodCountMetricsAction())
-	action_kit_sdk.RegisterAction(extnode.NewNodeCountCheckAction())
-	action_kit_sdk.RegisterAction(extevents.NewK8sEventsAction())
+	extcommon.RegisterAction(extdeployment.NewDeploymentRolloutRestartAction())
+	extcommon.RegisterAction(extdeployment.NewCheckDeploymentRolloutStatusAction())
+	extcommon.RegisterAction(extdeployment.NewPodCountCheckAction())
+	extcommon.RegisterAction(extdeployment.NewRolloutTimeCheckAction())
+	extcommon.RegisterAction(extdeployment.NewRolloutProgressCheckAction())
+	extcommon.RegisterAction(extdeployment.NewNoUnexpectedRolloutCheckAction())
+	extcommon.RegisterAction(extdeployment.NewStuckTerminationCheckAction())
+	extcommon.RegisterAction(extdeployment.NewReadinessGateCheckAction())
+	extcommon.RegisterAction(extdeployment.NewPodContainersReadyCheckAction())
+	extcommon.RegisterAction(extdeployment.NewImageConsistencyCheckAction())
+	extcommon.RegisterAction(extdeployment.NewAvailabilityCheckAction())
+	extcommon.RegisterAction(extdeployment.NewCompositeCheckAction())
+	extcommon.RegisterAction(extdeployment.NewEvictionCheckAction())
+	extcommon.RegisterAction(extdeployment.NewEventRateCheckAction())
+	extcommon.RegisterAction(extdeployment.NewServiceAccountTokenCheckAction())
+	extcommon.RegisterAction(extdeployment.NewScaleConvergenceCheckAction())
+	extcommon.RegisterAction(extdeployment.NewStartupCheckAction())
+	extcommon.RegisterAction(extdeployment.NewUnschedulableAction())
+	extcommon.RegisterAction(extdeployment.NewThrottleHpaAction())
+	extcommon.RegisterAction(extpod.NewBlockDeletionAction())
+	extcommon.RegisterAction(extpod.NewRestartCountCheckAction())
+	extcommon.RegisterAction(extdeployment.NewPodCountMetricsAction())
+	extcommon.RegisterAction(extnode.NewNodeCountCheckAction())
+	extcommon.RegisterAction(extnode.NewSpareCapacityCheckAction())
+	extcommon.RegisterAction(extnode.NewNodeDrainHeadroomCheckAction())
+	extcommon.RegisterAction(extnode.NewDeleteNodeObjectAction())
+	extcommon.RegisterAction(extnode.NewSimulateNodeNotReadyAction())
+	extcommon.RegisterAction(extnode.NewNodeZoneBalanceCheckAction())
+	extcommon.RegisterAction(extcluster.NewDnsResolutionCheckAction())
+	extcommon.RegisterAction(extstatefulset.NewHeadlessServiceCheckAction())
+	extcommon.RegisterAction(extstatefulset.NewOrphanedPvcCheckAction())
+	extcommon.RegisterAction(extstatefulset.NewStatefulSetPodCountCheckAction())
+	extcommon.RegisterAction(extdaemonset.NewDaemonSetReadyCheckAction())
+	extcommon.RegisterAction(exthpa.NewScaleUpCheckAction())
+	extcommon.RegisterAction(extservice.NewEndpointsReadyCheckAction())
+	extcommon.RegisterAction(extservice.NewServiceStabilityCheckAction())
+	extcommon.RegisterAction(extservice.NewDeleteServiceAction())
+	extcommon.RegisterAction(extevents.NewK8sEventsAction())
+	extcommon.RegisterAction(extevents.NewWarningEventsCheckAction())
 
 	extdeployment.RegisterAttributeDescriptionHandlers()
 	extdeployment.RegisterDeploymentDiscoveryHandlers()
 	extcontainer.RegisterContainerDiscoveryHandlers()
 	extcluster.RegisterClusterDiscoveryHandlers()
+	extstatefulset.RegisterStatefulSetDiscoveryHandlers()
+	extcronjob.RegisterCronJobDiscoveryHandlers()
+	extjob.RegisterJobDiscoveryHandlers()
+	extingress.RegisterIngressDiscoveryHandlers()
+	extpvc.RegisterPersistentVolumeClaimDiscoveryHandlers()
+	exthpa.RegisterHorizontalPodAutoscalerDiscoveryHandlers()
+	extreplicaset.RegisterReplicaSetDiscoveryHandlers()
+	extservice.RegisterServiceDiscoveryHandlers()
+	extpod.RegisterPodDiscoveryHandlers()
+	extnode.RegisterNodeDiscoveryHandlers()
 
 	action_kit_sdk.InstallSignalHandler()
 
@@ -84,6 +139,46 @@ func getExtensionList() ExtensionListResponse {
 					Method: "GET",
 					Path:   "/cluster/discovery",
 				},
+				{
+					Method: "GET",
+					Path:   "/statefulset/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/cronjob/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/job/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/ingress/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/pvc/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/hpa/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/replicaset/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/service/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/pod/discovery",
+				},
+				{
+					Method: "GET",
+					Path:   "/node/discovery",
+				},
 			},
 			TargetTypes: []discovery_kit_api.DescribingEndpointReference{
 				{
@@ -98,6 +193,46 @@ func getExtensionList() ExtensionListResponse {
 					Method: "GET",
 					Path:   "/cluster/discovery/target-description",
 				},
+				{
+					Method: "GET",
+					Path:   "/statefulset/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/cronjob/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/job/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/ingress/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/pvc/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/hpa/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/replicaset/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/service/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/pod/discovery/target-description",
+				},
+				{
+					Method: "GET",
+					Path:   "/node/discovery/target-description",
+				},
 			},
 			TargetAttributes: []discovery_kit_api.DescribingEndpointReference{
 				{
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+// Package testsupport helps to set up a client.Client backed by a fake clientset in tests.
+package testsupport
+
+import (
+	"context"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+)
+
+type ClientBuilder struct {
+	t           *testing.T
+	rootApiPath string
+	// Clientset can be used to seed or modify objects, also after the client has been built.
+	Clientset *testclient.Clientset
+}
+
+func NewClientBuilder(t *testing.T) *ClientBuilder {
+	return &ClientBuilder{
+		t:         t,
+		Clientset: testclient.NewSimpleClientset(),
+	}
+}
+
+// WithRootApiPath sets the root api path used to detect the distribution, e.g. "/oapi" for openshift.
+func (b *ClientBuilder) WithRootApiPath(rootApiPath string) *ClientBuilder {
+	b.rootApiPath = rootApiPath
+	return b
+}
+
+func (b *ClientBuilder) WithPods(pods ...*corev1.Pod) *ClientBuilder {
+	for _, pod := range pods {
+		_, err := b.Clientset.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithDeployments(deployments ...*appsv1.Deployment) *ClientBuilder {
+	for _, deployment := range deployments {
+		_, err := b.Clientset.AppsV1().Deployments(deployment.Namespace).Create(context.Background(), deployment, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithReplicaSets(replicaSets ...*appsv1.ReplicaSet) *ClientBuilder {
+	for _, replicaSet := range replicaSets {
+		_, err := b.Clientset.AppsV1().ReplicaSets(replicaSet.Namespace).Create(context.Background(), replicaSet, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithStatefulSets(statefulSets ...*appsv1.StatefulSet) *ClientBuilder {
+	for _, statefulSet := range statefulSets {
+		_, err := b.Clientset.AppsV1().StatefulSets(statefulSet.Namespace).Create(context.Background(), statefulSet, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithDaemonSets(daemonSets ...*appsv1.DaemonSet) *ClientBuilder {
+	for _, daemonSet := range daemonSets {
+		_, err := b.Clientset.AppsV1().DaemonSets(daemonSet.Namespace).Create(context.Background(), daemonSet, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithNodes(nodes ...*corev1.Node) *ClientBuilder {
+	for _, node := range nodes {
+		_, err := b.Clientset.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithHorizontalPodAutoscalers(hpas ...*autoscalingv2.HorizontalPodAutoscaler) *ClientBuilder {
+	for _, hpa := range hpas {
+		_, err := b.Clientset.AutoscalingV2().HorizontalPodAutoscalers(hpa.Namespace).Create(context.Background(), hpa, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithServices(services ...*corev1.Service) *ClientBuilder {
+	for _, service := range services {
+		_, err := b.Clientset.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithEndpointSlices(endpointSlices ...*discoveryv1.EndpointSlice) *ClientBuilder {
+	for _, endpointSlice := range endpointSlices {
+		_, err := b.Clientset.DiscoveryV1().EndpointSlices(endpointSlice.Namespace).Create(context.Background(), endpointSlice, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithServiceAccounts(serviceAccounts ...*corev1.ServiceAccount) *ClientBuilder {
+	for _, serviceAccount := range serviceAccounts {
+		_, err := b.Clientset.CoreV1().ServiceAccounts(serviceAccount.Namespace).Create(context.Background(), serviceAccount, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithJobs(jobs ...*batchv1.Job) *ClientBuilder {
+	for _, job := range jobs {
+		_, err := b.Clientset.BatchV1().Jobs(job.Namespace).Create(context.Background(), job, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithCronJobs(cronJobs ...*batchv1.CronJob) *ClientBuilder {
+	for _, cronJob := range cronJobs {
+		_, err := b.Clientset.BatchV1().CronJobs(cronJob.Namespace).Create(context.Background(), cronJob, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithIngresses(ingresses ...*networkingv1.Ingress) *ClientBuilder {
+	for _, ingress := range ingresses {
+		_, err := b.Clientset.NetworkingV1().Ingresses(ingress.Namespace).Create(context.Background(), ingress, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithPersistentVolumeClaims(pvcs ...*corev1.PersistentVolumeClaim) *ClientBuilder {
+	for _, pvc := range pvcs {
+		_, err := b.Clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+func (b *ClientBuilder) WithEvents(events ...*corev1.Event) *ClientBuilder {
+	for _, event := range events {
+		_, err := b.Clientset.CoreV1().Events(event.Namespace).Create(context.Background(), event, metav1.CreateOptions{})
+		require.NoError(b.t, err)
+	}
+	return b
+}
+
+// Build creates the client and waits for the caches to be synced. The informers are stopped when the test finishes.
+func (b *ClientBuilder) Build() *client.Client {
+	stopCh := make(chan struct{})
+	b.t.Cleanup(func() { close(stopCh) })
+	return client.CreateClient(b.Clientset, stopCh, b.rootApiPath, 0, nil)
+}
+
+// ReadyNode returns a node with a ready condition.
+func ReadyNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+}
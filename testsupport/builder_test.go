@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 Steadybit GmbH
+
+package testsupport
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestClientBuilderSeedsObjects(t *testing.T) {
+	// Given
+	builder := NewClientBuilder(t).
+		WithRootApiPath("/oapi").
+		WithNodes(ReadyNode("worker-1"), ReadyNode("worker-2")).
+		WithDeployments(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default"},
+		}).
+		WithPods(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop-1", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "worker-1"},
+		})
+
+	// When
+	k8s := builder.Build()
+
+	// Then
+	assert.Equal(t, "openshift", k8s.Distribution)
+	assert.Equal(t, 2, k8s.NodesReadyCount())
+	assert.NotNil(t, k8s.DeploymentByNamespaceAndName("default", "shop"))
+	assert.Len(t, k8s.PodsByNode("worker-1"), 1)
+}
+
+func TestClientBuilderClientsetStaysWritable(t *testing.T) {
+	// Given
+	builder := NewClientBuilder(t)
+	k8s := builder.Build()
+
+	// When
+	_, err := builder.Clientset.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "late", Namespace: "default"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Then
+	assert.Eventually(t, func() bool {
+		return k8s.PodByNamespaceAndName("default", "late") != nil
+	}, time.Second, 100*time.Millisecond)
+}